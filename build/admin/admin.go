@@ -0,0 +1,309 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+	"mint.minio.io/mintest"
+)
+
+// Prefix of the names of all the users and policies created by this suite
+const namePrefix = "admin-test-"
+
+// configValue returns the value of key in the output of GetConfigKV for a
+// single sub-system, like "api requests_max=0 stale_uploads_expiry=24h".
+func configValue(config []byte, key string) (string, bool) {
+	for _, field := range strings.Fields(string(config)) {
+		if k, v, ok := strings.Cut(field, "="); ok && k == key {
+			return strings.Trim(v, `"`), true
+		}
+	}
+	return "", false
+}
+
+// Check the server reports itself online, along with its servers and drives
+func testServerInfo() {
+	startTime := time.Now()
+	function := "testServerInfo"
+	args := map[string]interface{}{}
+
+	info, err := adminClient.ServerInfo(context.Background())
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ServerInfo failed", err).Fatal()
+		return
+	}
+	args["mode"] = info.Mode
+	args["servers"] = len(info.Servers)
+
+	if info.Mode != string(madmin.ItemOnline) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ServerInfo expected mode %s but got %s", madmin.ItemOnline, info.Mode), errors.New("server not online")).Fatal()
+		return
+	}
+	if len(info.Servers) == 0 || info.DeploymentID == "" {
+		mintest.FailureLog(function, args, startTime, "", "ServerInfo expected a deployment ID and at least one server", errors.New("incomplete server info")).Fatal()
+		return
+	}
+	for _, server := range info.Servers {
+		if server.Endpoint == "" || server.Version == "" || len(server.Disks) == 0 {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ServerInfo expected the endpoint, version and drives of server %+v", server), errors.New("incomplete server info")).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Check the storage info lists drives with consistent capacities
+func testStorageInfo() {
+	startTime := time.Now()
+	function := "testStorageInfo"
+	args := map[string]interface{}{}
+
+	info, err := adminClient.StorageInfo(context.Background())
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "StorageInfo failed", err).Fatal()
+		return
+	}
+	args["drives"] = len(info.Disks)
+
+	if len(info.Disks) == 0 {
+		mintest.FailureLog(function, args, startTime, "", "StorageInfo expected at least one drive", errors.New("no drives")).Fatal()
+		return
+	}
+	for _, disk := range info.Disks {
+		if disk.State == madmin.DriveStateOk && (disk.TotalSpace == 0 || disk.UsedSpace > disk.TotalSpace || disk.AvailableSpace > disk.TotalSpace) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("StorageInfo of drive %s reports %d bytes used and %d available out of %d",
+				disk.Endpoint, disk.UsedSpace, disk.AvailableSpace, disk.TotalSpace), errors.New("inconsistent drive capacity")).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Check data usage info is served. It is computed in the background, so
+// its counts may lag behind and are only checked for consistency.
+func testDataUsageInfo() {
+	startTime := time.Now()
+	function := "testDataUsageInfo"
+	args := map[string]interface{}{}
+
+	info, err := adminClient.DataUsageInfo(context.Background())
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DataUsageInfo failed", err).Fatal()
+		return
+	}
+	args["lastUpdate"] = info.LastUpdate
+	args["buckets"] = info.BucketsCount
+	args["objects"] = info.ObjectsTotalCount
+
+	if info.BucketsCount != uint64(len(info.BucketsUsage)) && len(info.BucketsUsage) != 0 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DataUsageInfo counts %d buckets but reports the usage of %d", info.BucketsCount, len(info.BucketsUsage)),
+			errors.New("inconsistent data usage")).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Check the background healing status is served
+func testBackgroundHealStatus() {
+	startTime := time.Now()
+	function := "testBackgroundHealStatus"
+	args := map[string]interface{}{}
+
+	status, err := adminClient.BackgroundHealStatus(context.Background())
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "BackgroundHealStatus failed", err).Fatal()
+		return
+	}
+	args["scannedItems"] = status.ScannedItemsCount
+	args["healDrives"] = len(status.HealDisks)
+	args["offlineEndpoints"] = status.OfflineEndpoints
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Change the expiry of stale multipart uploads, which does not affect the
+// other tests, read it back, then restore its original value.
+func testConfigKV() {
+	startTime := time.Now()
+	function := "testConfigKV"
+	key := "stale_uploads_expiry"
+	args := map[string]interface{}{
+		"subSys": "api",
+		"key":    key,
+	}
+
+	config, err := adminClient.GetConfigKV(context.Background(), "api")
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetConfigKV failed", err).Fatal()
+		return
+	}
+	original, ok := configValue(config, key)
+	if !ok {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetConfigKV expected %s in %q", key, config), errors.New("missing config key")).Fatal()
+		return
+	}
+	value := "25h"
+	if original == value {
+		value = "26h"
+	}
+	args["original"] = original
+	args["value"] = value
+
+	defer func() {
+		if _, err := adminClient.SetConfigKV(context.Background(), "api "+key+"="+original); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "SetConfigKV restoring the original value failed", err).Fatal()
+		}
+	}()
+
+	if _, err = adminClient.SetConfigKV(context.Background(), "api "+key+"="+value); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "SetConfigKV failed", err).Fatal()
+		return
+	}
+
+	config, err = adminClient.GetConfigKV(context.Background(), "api")
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetConfigKV failed", err).Fatal()
+		return
+	}
+	if got, _ := configValue(config, key); got != value {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetConfigKV expected %s=%s but got %q", key, value, got), errors.New("config mismatch")).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Create a canned policy and a user, attach the policy to the user, read
+// both back, then remove them and check they are gone.
+func testUserPolicy() {
+	startTime := time.Now()
+	function := "testUserPolicy"
+	user := mintest.RandString(30, rand.NewSource(time.Now().UnixNano()), namePrefix)
+	policyName := mintest.RandString(30, rand.NewSource(time.Now().UnixNano()+1), namePrefix)
+	policy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::mint/*"]}]}`
+	args := map[string]interface{}{
+		"user":       user,
+		"policyName": policyName,
+		"policy":     policy,
+	}
+	ctx := context.Background()
+
+	if err := adminClient.AddCannedPolicy(ctx, policyName, []byte(policy)); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AddCannedPolicy failed", err).Fatal()
+		return
+	}
+	policyRemoved := false
+	defer func() {
+		if !policyRemoved {
+			adminClient.RemoveCannedPolicy(ctx, policyName)
+		}
+	}()
+
+	info, err := adminClient.InfoCannedPolicyV2(ctx, policyName)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "InfoCannedPolicyV2 failed", err).Fatal()
+		return
+	}
+	var expected, got interface{}
+	json.Unmarshal([]byte(policy), &expected)
+	json.Unmarshal(info.Policy, &got)
+	if info.PolicyName != policyName || !reflect.DeepEqual(expected, got) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("InfoCannedPolicyV2 expected %s %s but got %s %s", policyName, policy, info.PolicyName, info.Policy),
+			errors.New("policy mismatch")).Fatal()
+		return
+	}
+
+	secretKey := mintest.RandString(40, rand.NewSource(time.Now().UnixNano()+2), "")
+	if err = adminClient.AddUser(ctx, user, secretKey); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AddUser failed", err).Fatal()
+		return
+	}
+	userRemoved := false
+	defer func() {
+		if !userRemoved {
+			adminClient.RemoveUser(ctx, user)
+		}
+	}()
+
+	if _, err = adminClient.AttachPolicy(ctx, madmin.PolicyAssociationReq{Policies: []string{policyName}, User: user}); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AttachPolicy failed", err).Fatal()
+		return
+	}
+
+	userInfo, err := adminClient.GetUserInfo(ctx, user)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetUserInfo failed", err).Fatal()
+		return
+	}
+	if userInfo.PolicyName != policyName || userInfo.Status != madmin.AccountEnabled {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetUserInfo expected an enabled user with policy %s but got %s with %q", policyName, userInfo.Status, userInfo.PolicyName),
+			errors.New("user mismatch")).Fatal()
+		return
+	}
+
+	users, err := adminClient.ListUsers(ctx)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ListUsers failed", err).Fatal()
+		return
+	}
+	if _, ok := users[user]; !ok {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListUsers expected to list %s", user), errors.New("user not listed")).Fatal()
+		return
+	}
+
+	if err = adminClient.RemoveUser(ctx, user); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "RemoveUser failed", err).Fatal()
+		return
+	}
+	userRemoved = true
+	if _, err = adminClient.GetUserInfo(ctx, user); err == nil {
+		mintest.FailureLog(function, args, startTime, "", "GetUserInfo of a removed user expected to fail but succeeded", nil).Fatal()
+		return
+	}
+
+	if err = adminClient.RemoveCannedPolicy(ctx, policyName); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "RemoveCannedPolicy failed", err).Fatal()
+		return
+	}
+	policyRemoved = true
+	policies, err := adminClient.ListCannedPolicies(ctx)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ListCannedPolicies failed", err).Fatal()
+		return
+	}
+	if _, ok := policies[policyName]; ok {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListCannedPolicies expected %s to be removed", policyName), errors.New("policy not removed")).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
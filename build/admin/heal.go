@@ -0,0 +1,172 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/minio/madmin-go/v3"
+	"mint.minio.io/mintest"
+)
+
+// Size of the object healed by the heal test, spanning several erasure
+// coded blocks
+const healObjectSize = 3 * 1024 * 1024
+
+// Time the heal test waits for its heal sequence to finish, polling its
+// status at healInterval
+const (
+	healTimeout  = 2 * time.Minute
+	healInterval = time.Second
+)
+
+// healObject runs a deep heal of object, checking the bitrot checksums of
+// its parts, and returns the result items of the heal sequence once it is
+// finished.
+func healObject(ctx context.Context, bucket, object string) ([]madmin.HealResultItem, error) {
+	opts := madmin.HealOpts{Recursive: true, ScanMode: madmin.HealDeepScan}
+	start, _, err := adminClient.Heal(ctx, bucket, object, opts, "", false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []madmin.HealResultItem
+	for deadline := time.Now().Add(healTimeout); time.Now().Before(deadline); time.Sleep(healInterval) {
+		// Each status holds the items healed since the previous one
+		_, status, err := adminClient.Heal(ctx, bucket, object, opts, start.ClientToken, false, false)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, status.Items...)
+		switch status.Summary {
+		case "finished":
+			return items, nil
+		case "stopped":
+			return nil, fmt.Errorf("heal sequence stopped: %s", status.FailureDetail)
+		}
+	}
+	return nil, fmt.Errorf("heal sequence not finished after %v", healTimeout)
+}
+
+// Upload an object to an erasure coded deployment, heal it with a deep
+// scan, which verifies the bitrot checksums of its parts on every drive,
+// then check the heal result reports no corrupt drive after healing and
+// the object still reads back with its checksum. Corruption cannot be
+// injected into the drives from a client, so the test checks healing
+// leaves sound objects sound. A deep scan reads every part from every
+// drive, so the test only runs in full mode.
+func testHealObject() {
+	startTime := time.Now()
+	function := "testHealObject"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "heal-object"
+	seed := mintest.DataSeed()
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"seed":       seed,
+	}
+	ctx := context.Background()
+
+	info, err := adminClient.StorageInfo(ctx)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "StorageInfo failed", err).Fatal()
+		return
+	}
+	if info.Backend.Type != madmin.Erasure {
+		mintest.IgnoreLog(function, args, startTime, "Healing requires an erasure coded deployment").Info()
+		return
+	}
+
+	_, err = s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   mintest.NewDataReader(seed, healObjectSize),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+
+	items, err := healObject(ctx, bucket, object)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Heal failed", err).Fatal()
+		return
+	}
+	var healed *madmin.HealResultItem
+	for i := range items {
+		if items[i].Type == madmin.HealItemObject && items[i].Object == object {
+			healed = &items[i]
+		}
+	}
+	if healed == nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Heal expected a result item for %s but got %d items", object, len(items)),
+			errors.New("object not healed")).Fatal()
+		return
+	}
+	args["drives"] = healed.DiskCount
+	for _, drive := range healed.After.Drives {
+		if drive.State == madmin.DriveStateCorrupt {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Heal left drive %s of %s corrupt", drive.Endpoint, object),
+				errors.New("corrupt drive after heal")).Fatal()
+			return
+		}
+	}
+
+	output, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject of the healed object failed", err).Fatal()
+		return
+	}
+	defer output.Body.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, output.Body); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Reading the healed object failed", err).Fatal()
+		return
+	}
+	if sum, expected := hex.EncodeToString(hash.Sum(nil)), mintest.DataSHA256(seed, healObjectSize); sum != expected {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Healed object expected SHA256 %s but got %s", expected, sum),
+			errors.New("content mismatch")).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,258 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/minio/madmin-go/v3"
+	"mint.minio.io/mintest"
+)
+
+// addRestrictedUser creates a user with a canned policy of its own, and
+// returns an S3 client signing as this user along with a function removing
+// both.
+func addRestrictedUser(policy string) (*s3.S3, func(), error) {
+	ctx := context.Background()
+	user := mintest.RandString(30, rand.NewSource(time.Now().UnixNano()), namePrefix)
+	secretKey := mintest.RandString(40, rand.NewSource(time.Now().UnixNano()+1), "")
+
+	if err := adminClient.AddCannedPolicy(ctx, user, []byte(policy)); err != nil {
+		return nil, nil, err
+	}
+	if err := adminClient.AddUser(ctx, user, secretKey); err != nil {
+		adminClient.RemoveCannedPolicy(ctx, user)
+		return nil, nil, err
+	}
+	remove := func() {
+		adminClient.RemoveUser(ctx, user)
+		adminClient.RemoveCannedPolicy(ctx, user)
+	}
+	if _, err := adminClient.AttachPolicy(ctx, madmin.PolicyAssociationReq{Policies: []string{user}, User: user}); err != nil {
+		remove()
+		return nil, nil, err
+	}
+
+	s3Config := config.S3Config()
+	s3Config.Credentials = credentials.NewStaticCredentials(user, secretKey, "")
+	client := s3.New(session.New(), s3Config)
+	mintest.TrackBuckets(client)
+	return client, remove, nil
+}
+
+// checkAccessDenied returns an error unless err is an AccessDenied error
+func checkAccessDenied(op string, err error) error {
+	if err == nil {
+		return fmt.Errorf("%s expected to be denied but succeeded", op)
+	}
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "AccessDenied" {
+		return fmt.Errorf("%s expected to fail with AccessDenied but got %v", op, err)
+	}
+	return nil
+}
+
+// Create a user allowed to list, read and write under a single prefix of a
+// bucket, listing being allowed through an s3:prefix condition. Everything
+// under the prefix must succeed, and everything outside of it must be
+// denied.
+func testIAMPrefixPolicy() {
+	startTime := time.Now()
+	function := "testIAMPrefixPolicy"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	prefix := "allowed/"
+	policy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[`+
+		`{"Effect":"Allow","Action":["s3:ListBucket"],"Resource":["arn:aws:s3:::%[1]s"],"Condition":{"StringLike":{"s3:prefix":["%[2]s*"]}}},`+
+		`{"Effect":"Allow","Action":["s3:GetObject","s3:PutObject"],"Resource":["arn:aws:s3:::%[1]s/%[2]s*"]}]}`, bucket, prefix)
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"prefix":     prefix,
+		"policy":     policy,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	for _, object := range []string{prefix + "object", "denied/object"} {
+		_, err = s3Client.PutObject(&s3.PutObjectInput{
+			Body:   bytes.NewReader([]byte("iam")),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+			return
+		}
+	}
+
+	client, remove, err := addRestrictedUser(policy)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Creating a restricted user failed", err).Fatal()
+		return
+	}
+	defer remove()
+
+	put := func(object string) error {
+		_, err := client.PutObject(&s3.PutObjectInput{
+			Body:   bytes.NewReader([]byte("iam")),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		return err
+	}
+	get := func(object string) error {
+		output, err := client.GetObject(&s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err == nil {
+			output.Body.Close()
+		}
+		return err
+	}
+	list := func(prefix string) error {
+		_, err := client.ListObjectsV2(&s3.ListObjectsV2Input{
+			Bucket: aws.String(bucket),
+			Prefix: aws.String(prefix),
+		})
+		return err
+	}
+
+	allowed := map[string]error{
+		"PutObject under the prefix":     put(prefix + "new"),
+		"GetObject under the prefix":     get(prefix + "object"),
+		"ListObjectsV2 of the prefix":    list(prefix),
+		"ListObjectsV2 under the prefix": list(prefix + "obj"),
+	}
+	for op, err := range allowed {
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("%s expected to be allowed", op), err).Fatal()
+			return
+		}
+	}
+
+	denied := map[string]error{
+		"PutObject outside the prefix":      put("denied/new"),
+		"GetObject outside the prefix":      get("denied/object"),
+		"ListObjectsV2 of another prefix":   list("denied/"),
+		"ListObjectsV2 of the whole bucket": list(""),
+	}
+	_, err = client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(prefix + "object"),
+	})
+	denied["DeleteObject not granted by the policy"] = err
+	_, err = client.ListBuckets(&s3.ListBucketsInput{})
+	denied["ListBuckets not granted by the policy"] = err
+	for op, err := range denied {
+		if err = checkAccessDenied(op, err); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Operation outside the policy was not denied", err).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Create a user allowed to read a bucket only with an aws:Referer
+// condition. GETs with a matching Referer header must succeed, and those
+// with another or no Referer must be denied.
+func testIAMRefererCondition() {
+	startTime := time.Now()
+	function := "testIAMRefererCondition"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	policy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[`+
+		`{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"],"Condition":{"StringLike":{"aws:Referer":["https://mint.example.com/*"]}}}]}`, bucket)
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"policy":     policy,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   bytes.NewReader([]byte("iam")),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+
+	client, remove, err := addRestrictedUser(policy)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Creating a restricted user failed", err).Fatal()
+		return
+	}
+	defer remove()
+
+	get := func(referer string) error {
+		var opts []request.Option
+		if referer != "" {
+			opts = append(opts, request.WithSetRequestHeaders(map[string]string{"Referer": referer}))
+		}
+		output, err := client.GetObjectWithContext(context.Background(), &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		}, opts...)
+		if err == nil {
+			output.Body.Close()
+		}
+		return err
+	}
+
+	if err = get("https://mint.example.com/page"); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject with a matching Referer expected to be allowed", err).Fatal()
+		return
+	}
+	for _, referer := range []string{"https://other.example.com/page", ""} {
+		if err = checkAccessDenied(fmt.Sprintf("GetObject with Referer %q", referer), get(referer)); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "GetObject without a matching Referer was not denied", err).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,79 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/minio/madmin-go/v3"
+	"mint.minio.io/mintest"
+)
+
+// Prefix of the names of all the buckets created by this suite
+const bucketPrefix = "admin-test-"
+
+// Admin client for testing
+var adminClient *madmin.AdminClient
+
+// S3 client for testing, signed with the same credentials
+var s3Client *s3.S3
+
+// Server under test, from which the clients of the users created by the
+// tests are derived
+var config mintest.Config
+
+func cleanupBucket(bucket string, function string, args map[string]interface{}, startTime time.Time) {
+	if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucket failed", err).Fatal()
+	}
+}
+
+func main() {
+	runStartTime := time.Now()
+	mintest.Init("admin")
+	config = mintest.LoadConfig()
+
+	tests := []mintest.Test{
+		{Name: "testServerInfo", Description: "Check the server reports itself online with its servers and drives", Requires: []string{mintest.RequiresAdmin}, Run: testServerInfo},
+		{Name: "testStorageInfo", Description: "Check the storage info lists drives with consistent capacities", Requires: []string{mintest.RequiresAdmin}, Run: testStorageInfo},
+		{Name: "testDataUsageInfo", Description: "Check the data usage info is served and consistent", Requires: []string{mintest.RequiresAdmin}, Run: testDataUsageInfo},
+		{Name: "testBackgroundHealStatus", Description: "Check the background healing status is served", Requires: []string{mintest.RequiresAdmin}, Run: testBackgroundHealStatus},
+		{Name: "testHealObject", Description: "Deep heal an object of an erasure coded deployment and read it back, in full mode", Requires: []string{mintest.RequiresAdmin, mintest.RequiresFull}, Run: testHealObject},
+		{Name: "testConfigKV", Description: "Change, read back and restore a config key", Requires: []string{mintest.RequiresAdmin}, Run: testConfigKV},
+		{Name: "testUserPolicy", Description: "Create, attach, read back and remove a canned policy and a user", Requires: []string{mintest.RequiresAdmin}, Run: testUserPolicy},
+		{Name: "testIAMPrefixPolicy", Description: "Check a user policy restricted to a prefix through an s3:prefix condition", Requires: []string{mintest.RequiresAdmin}, Run: testIAMPrefixPolicy},
+		{Name: "testIAMRefererCondition", Description: "Check a user policy with an aws:Referer condition", Requires: []string{mintest.RequiresAdmin}, Run: testIAMRefererCondition},
+		{Name: "testBucketQuota", Description: "Check PUTs are rejected over a hard bucket quota", Requires: []string{mintest.RequiresAdmin}, Run: testBucketQuota},
+	}
+	// Listed even without an endpoint to create the admin client for
+	mintest.ListTests(tests)
+
+	var err error
+	adminClient, err = madmin.New(config.Endpoint, config.AccessKey, config.SecretKey, config.Secure)
+	if err != nil {
+		mintest.FailureLog("main", map[string]interface{}{"endpoint": config.Endpoint}, time.Now(), "", "Unable to create the admin client", err).Fatal()
+	}
+	adminClient.SetCustomTransport(mintest.TraceTransport(config.Transport()))
+
+	s3Client = config.NewS3Client()
+	mintest.TrackBuckets(s3Client)
+	// Remove the buckets left behind, even by a failed test
+	defer mintest.StartJanitor(s3Client)()
+
+	mintest.RunTests(tests, mintest.NewCapabilities(config, s3Client).Supports)
+	mintest.TestResourceLeaks(s3Client, bucketPrefix, runStartTime)
+}
@@ -0,0 +1,156 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/minio/madmin-go/v3"
+	"mint.minio.io/mintest"
+)
+
+// Hard quota of the bucket of the quota test
+const bucketQuota = 1024 * 1024
+
+// The server accounts the usage of a bucket asynchronously, changes are
+// only seen by the quota after a while
+const (
+	quotaUsageTimeout  = 5 * time.Minute
+	quotaUsageInterval = 5 * time.Second
+)
+
+// isQuotaExceeded reports whether err is the error of a PUT over quota
+func isQuotaExceeded(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == "XMinioAdminBucketQuotaExceeded"
+}
+
+// Set a hard quota on a bucket and read it back. A single object larger
+// than the quota must be rejected at once. Once the bucket is filled over
+// the quota, PUTs must fail with XMinioAdminBucketQuotaExceeded, and
+// succeed again once the objects are deleted.
+func testBucketQuota() {
+	startTime := time.Now()
+	function := "testBucketQuota"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"quota":      bucketQuota,
+	}
+	ctx := context.Background()
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	// Quota is deprecated in favor of Size, still set for older servers
+	quota := &madmin.BucketQuota{Quota: bucketQuota, Size: bucketQuota, Type: madmin.HardQuota}
+	if err = adminClient.SetBucketQuota(ctx, bucket, quota); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "SetBucketQuota failed", err).Fatal()
+		return
+	}
+	defer adminClient.SetBucketQuota(ctx, bucket, &madmin.BucketQuota{})
+
+	got, err := adminClient.GetBucketQuota(ctx, bucket)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetBucketQuota failed", err).Fatal()
+		return
+	}
+	if got.Type != madmin.HardQuota || (got.Size != bucketQuota && got.Quota != bucketQuota) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetBucketQuota expected a %s quota of %d bytes but got %+v", madmin.HardQuota, bucketQuota, got),
+			errors.New("quota mismatch")).Fatal()
+		return
+	}
+
+	put := func(object string, size int) error {
+		_, err := s3Client.PutObject(&s3.PutObjectInput{
+			Body:   bytes.NewReader(make([]byte, size)),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		return err
+	}
+
+	if err = put("too-large", 2*bucketQuota); !isQuotaExceeded(err) {
+		mintest.FailureLog(function, args, startTime, "", "PutObject larger than the quota expected to fail with XMinioAdminBucketQuotaExceeded", err).Fatal()
+		return
+	}
+
+	// Fill the bucket over the quota, with objects each within it
+	objects := []string{"object-1", "object-2", "object-3"}
+	for _, object := range objects {
+		if err = put(object, bucketQuota/2); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "PutObject within the quota failed", err).Fatal()
+			return
+		}
+	}
+
+	// waitPut retries a small PUT until it is rejected for the quota, or
+	// accepted when exceeded is false
+	waitPut := func(exceeded bool) error {
+		for start := time.Now(); ; time.Sleep(quotaUsageInterval) {
+			err := put("probe", 1024)
+			if (exceeded && isQuotaExceeded(err)) || (!exceeded && err == nil) {
+				return nil
+			}
+			if err != nil && !isQuotaExceeded(err) {
+				return err
+			}
+			if time.Since(start) > quotaUsageTimeout {
+				return fmt.Errorf("PutObject result unchanged after %s, last error %v", quotaUsageTimeout, err)
+			}
+		}
+	}
+
+	if err = waitPut(true); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject over the quota expected to fail with XMinioAdminBucketQuotaExceeded", err).Fatal()
+		return
+	}
+
+	for _, object := range append(objects, "probe") {
+		_, err = s3Client.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "DeleteObject failed", err).Fatal()
+			return
+		}
+	}
+	if err = waitPut(false); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject expected to succeed once deleting objects freed the quota", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,113 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Time sequential and concurrent PUTs then GETs of objects of every size
+// set in MINT_BENCH_SIZES, and log the throughput, operations per
+// second and latency percentiles of each run. Only run in benchmark mode.
+func benchmarkPutGetObject() {
+	startTime := time.Now()
+	function := "benchmarkPutGetObject"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	args := map[string]interface{}{
+		"bucketName": bucket,
+	}
+	ctx := context.Background()
+
+	sizes, objects, concurrency, err := mintest.BenchmarkSettings()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Invalid benchmark settings", err).Fatal()
+		return
+	}
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	seed := mintest.DataSeed()
+	args["seed"] = seed
+
+	for _, size := range sizes {
+		key := func(i int) string {
+			return fmt.Sprintf("benchmark-%d/object-%d", size, i)
+		}
+		put := func(i int) error {
+			_, err := client.PutObject(ctx, &s3.PutObjectInput{
+				Body:          mintest.NewDataReader(seed, size),
+				Bucket:        aws.String(bucket),
+				Key:           aws.String(key(i)),
+				ContentLength: aws.Int64(size),
+			})
+			return err
+		}
+		get := func(i int) error {
+			output, err := client.GetObject(ctx, &s3.GetObjectInput{
+				Bucket: aws.String(bucket),
+				Key:    aws.String(key(i)),
+			})
+			if err != nil {
+				return err
+			}
+			defer output.Body.Close()
+			n, err := io.Copy(io.Discard, output.Body)
+			if err == nil && n != size {
+				err = fmt.Errorf("read %d bytes of object %s, expected %d", n, key(i), size)
+			}
+			return err
+		}
+
+		for _, workers := range []int{1, concurrency} {
+			for _, run := range []struct {
+				function string
+				op       func(i int) error
+			}{
+				{"benchmarkPutObject", put},
+				{"benchmarkGetObject", get},
+			} {
+				runStartTime := time.Now()
+				runArgs := map[string]interface{}{
+					"bucketName":  bucket,
+					"objectSize":  size,
+					"objects":     objects,
+					"concurrency": workers,
+				}
+				elapsed, latencies, err := mintest.RunBenchmark(objects, workers, run.op)
+				if err != nil {
+					mintest.FailureLog(run.function, runArgs, runStartTime, "", "Benchmark run failed", err).Fatal()
+					return
+				}
+				mintest.SuccessLogger(run.function, runArgs, runStartTime).
+					WithField("metrics", mintest.NewBenchmarkMetrics(size, elapsed, latencies)).Info()
+			}
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,166 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"mint.minio.io/mintest"
+)
+
+// bucketNames returns the names of buckets
+func bucketNames(buckets []types.Bucket) []string {
+	names := make([]string, 0, len(buckets))
+	for _, bucket := range buckets {
+		names = append(names, aws.ToString(bucket.Name))
+	}
+	return names
+}
+
+// Create buckets under a prefix of their own, then page through them with
+// the Prefix, MaxBuckets and ContinuationToken parameters of ListBuckets:
+// each page must hold at most MaxBuckets buckets under the prefix, and the
+// pages together all the buckets, in order, exactly once. A prefix under
+// which there is no bucket must list none. Servers which predate these
+// parameters ignore them and list all the buckets at once, which must then
+// hold the buckets created.
+func testListBucketsPaging() {
+	startTime := time.Now()
+	function := "testListBucketsPaging"
+	prefix := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix) + "-"
+	count := 12
+	maxBuckets := int32(5)
+	args := map[string]interface{}{
+		"prefix":     prefix,
+		"buckets":    count,
+		"maxBuckets": maxBuckets,
+	}
+
+	var buckets []string
+	defer func() {
+		for _, bucket := range buckets {
+			cleanupBucket(bucket, function, args, startTime)
+		}
+	}()
+	// Named so that they are created in the order they are listed in
+	for i := 0; i < count; i++ {
+		bucket := fmt.Sprintf("%s%02d", prefix, i)
+		if err := makeBucket(bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+			return
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	input := &s3.ListBucketsInput{
+		MaxBuckets: aws.Int32(maxBuckets),
+		Prefix:     aws.String(prefix),
+	}
+	output, err := client.ListBuckets(context.Background(), input)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ListBuckets failed", err).Fatal()
+		return
+	}
+
+	names := bucketNames(output.Buckets)
+	ignored := output.ContinuationToken == nil && len(names) > int(maxBuckets)
+	for _, name := range names {
+		ignored = ignored || (output.ContinuationToken == nil && !strings.HasPrefix(name, prefix))
+	}
+	if ignored {
+		args["parameters"] = "ignored"
+		output, err = client.ListBuckets(context.Background(), &s3.ListBucketsInput{})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "ListBuckets failed", err).Fatal()
+			return
+		}
+		var listed []string
+		for _, name := range bucketNames(output.Buckets) {
+			if strings.HasPrefix(name, prefix) {
+				listed = append(listed, name)
+			}
+		}
+		if !reflect.DeepEqual(listed, buckets) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListBuckets expected to list %v under the prefix but got %v", buckets, listed), nil).Fatal()
+			return
+		}
+		mintest.SuccessLogger(function, args, startTime).Info()
+		return
+	}
+
+	var listed []string
+	for pages := 1; ; pages++ {
+		args["page"] = pages
+		if pages > count {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListBuckets returned more than %d pages of %d buckets", count, maxBuckets), nil).Fatal()
+			return
+		}
+		names = bucketNames(output.Buckets)
+		if len(names) > int(maxBuckets) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListBuckets expected at most %d buckets per page but got %d", maxBuckets, len(names)), nil).Fatal()
+			return
+		}
+		for _, name := range names {
+			if !strings.HasPrefix(name, prefix) {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListBuckets listed %s, which is not under the prefix", name), nil).Fatal()
+				return
+			}
+		}
+		if output.Prefix != nil && aws.ToString(output.Prefix) != prefix {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListBuckets expected to return the prefix %s but got %s", prefix, aws.ToString(output.Prefix)), nil).Fatal()
+			return
+		}
+		listed = append(listed, names...)
+
+		if aws.ToString(output.ContinuationToken) == "" {
+			break
+		}
+		input.ContinuationToken = output.ContinuationToken
+		output, err = client.ListBuckets(context.Background(), input)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "ListBuckets with a continuation token failed", err).Fatal()
+			return
+		}
+	}
+	delete(args, "page")
+	if !reflect.DeepEqual(listed, buckets) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListBuckets pages expected to list %v but got %v", buckets, listed), nil).Fatal()
+		return
+	}
+
+	output, err = client.ListBuckets(context.Background(), &s3.ListBucketsInput{
+		MaxBuckets: aws.Int32(maxBuckets),
+		Prefix:     aws.String(prefix + "none-"),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ListBuckets failed", err).Fatal()
+		return
+	}
+	if len(output.Buckets) != 0 || aws.ToString(output.ContinuationToken) != "" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListBuckets under a prefix of no bucket expected none but got %v", bucketNames(output.Buckets)), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
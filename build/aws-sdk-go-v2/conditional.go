@@ -0,0 +1,132 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Run the matrix of conditional GETs on an object, with matching and
+// mismatching ETags, earlier and later timestamps, and their combinations,
+// and check the exact status and error code of each.
+func testGetObjectConditions() {
+	startTime := time.Now()
+	function := "testGetObjectConditions"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	content := "get me if you can"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	put, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Body:   strings.NewReader(content),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+	head, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "HeadObject failed", err).Fatal()
+		return
+	}
+	etag := aws.ToString(put.ETag)
+	otherETag := `"00000000000000000000000000000000"`
+	lastModified := aws.ToTime(head.LastModified)
+	before := lastModified.Add(-time.Hour)
+	after := lastModified.Add(time.Hour)
+
+	testCases := []struct {
+		name       string
+		input      s3.GetObjectInput
+		statusCode int
+		code       string
+	}{
+		{"IfMatch", s3.GetObjectInput{IfMatch: aws.String(etag)}, http.StatusOK, ""},
+		{"IfMatch wildcard", s3.GetObjectInput{IfMatch: aws.String("*")}, http.StatusOK, ""},
+		{"IfMatch mismatch", s3.GetObjectInput{IfMatch: aws.String(otherETag)}, http.StatusPreconditionFailed, "PreconditionFailed"},
+		{"IfNoneMatch", s3.GetObjectInput{IfNoneMatch: aws.String(otherETag)}, http.StatusOK, ""},
+		{"IfNoneMatch mismatch", s3.GetObjectInput{IfNoneMatch: aws.String(etag)}, http.StatusNotModified, "NotModified"},
+		{"IfNoneMatch wildcard", s3.GetObjectInput{IfNoneMatch: aws.String("*")}, http.StatusNotModified, "NotModified"},
+		{"IfModifiedSince", s3.GetObjectInput{IfModifiedSince: aws.Time(before)}, http.StatusOK, ""},
+		{"IfModifiedSince mismatch", s3.GetObjectInput{IfModifiedSince: aws.Time(after)}, http.StatusNotModified, "NotModified"},
+		{"IfUnmodifiedSince", s3.GetObjectInput{IfUnmodifiedSince: aws.Time(after)}, http.StatusOK, ""},
+		{"IfUnmodifiedSince mismatch", s3.GetObjectInput{IfUnmodifiedSince: aws.Time(before)}, http.StatusPreconditionFailed, "PreconditionFailed"},
+		// A matching If-Match overrides a failing If-Unmodified-Since
+		{"IfMatch with IfUnmodifiedSince mismatch", s3.GetObjectInput{IfMatch: aws.String(etag), IfUnmodifiedSince: aws.Time(before)}, http.StatusOK, ""},
+		{"IfMatch mismatch with IfUnmodifiedSince", s3.GetObjectInput{IfMatch: aws.String(otherETag), IfUnmodifiedSince: aws.Time(after)}, http.StatusPreconditionFailed, "PreconditionFailed"},
+		// A failing If-None-Match wins over a matching If-Modified-Since
+		{"IfNoneMatch mismatch with IfModifiedSince", s3.GetObjectInput{IfNoneMatch: aws.String(etag), IfModifiedSince: aws.Time(before)}, http.StatusNotModified, "NotModified"},
+		{"IfMatch with IfNoneMatch", s3.GetObjectInput{IfMatch: aws.String(etag), IfNoneMatch: aws.String(otherETag)}, http.StatusOK, ""},
+		{"IfMatch with IfNoneMatch mismatch", s3.GetObjectInput{IfMatch: aws.String(etag), IfNoneMatch: aws.String(etag)}, http.StatusNotModified, "NotModified"},
+		{"All conditions", s3.GetObjectInput{IfMatch: aws.String(etag), IfNoneMatch: aws.String(otherETag), IfModifiedSince: aws.Time(before), IfUnmodifiedSince: aws.Time(after)}, http.StatusOK, ""},
+	}
+	for _, testCase := range testCases {
+		input := testCase.input
+		input.Bucket = aws.String(bucket)
+		input.Key = aws.String(object)
+		output, err := client.GetObject(ctx, &input)
+		if testCase.statusCode == http.StatusOK {
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject %s expected to succeed", testCase.name), err).Fatal()
+				return
+			}
+			body, err := io.ReadAll(output.Body)
+			output.Body.Close()
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject %s reading body failed", testCase.name), err).Fatal()
+				return
+			}
+			if string(body) != content || aws.ToString(output.ETag) != etag {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject %s expected %q with ETag %s but got %q with ETag %s",
+					testCase.name, content, etag, body, aws.ToString(output.ETag)), errors.New("content mismatch")).Fatal()
+				return
+			}
+			continue
+		}
+		if statusCode(err) != testCase.statusCode || errorCode(err) != testCase.code {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject %s expected to fail with %d %s", testCase.name, testCase.statusCode, testCase.code), err).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
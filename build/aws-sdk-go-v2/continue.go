@@ -0,0 +1,163 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"mint.minio.io/mintest"
+)
+
+// expectRecorder records the Expect header of the requests sent through
+// its HTTP client
+type expectRecorder struct {
+	s3.HTTPClient
+
+	mutex  sync.Mutex
+	expect []string
+}
+
+func (r *expectRecorder) Do(req *http.Request) (*http.Response, error) {
+	r.mutex.Lock()
+	r.expect = append(r.expect, req.Header.Get("Expect"))
+	r.mutex.Unlock()
+	return r.HTTPClient.Do(req)
+}
+
+// sent reports whether every request recorded so far asked to continue
+func (r *expectRecorder) sent() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, expect := range r.expect {
+		if expect != "100-continue" {
+			return false
+		}
+	}
+	return len(r.expect) > 0
+}
+
+// PUT objects with Expect: 100-continue, which the SDK sends on bodies
+// from ContinueHeaderThresholdBytes on, then read them back: the interim
+// response of the server must not cut nor corrupt the uploads. A PUT to a
+// missing bucket must be answered with NoSuchBucket rather than the body
+// being waited for, and the client must go on with a PUT next.
+func testPutObjectExpectContinue() {
+	startTime := time.Now()
+	function := "testPutObjectExpectContinue"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	seed := mintest.DataSeed()
+	sizes := []int64{1, 64 * 1024, 6 * 1024 * 1024}
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"seed":       seed,
+		"sizes":      sizes,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	recorder := &expectRecorder{}
+	expectContinue := func(o *s3.Options) {
+		recorder.HTTPClient = o.HTTPClient
+		o.HTTPClient = recorder
+		o.ContinueHeaderThresholdBytes = 1
+	}
+
+	for i, size := range sizes {
+		object := fmt.Sprintf("object-%d", size)
+		args["objectName"] = object
+		_, err := client.PutObject(ctx, &s3.PutObjectInput{
+			Body:          mintest.NewDataReader(seed+uint64(i), size),
+			Bucket:        aws.String(bucket),
+			Key:           aws.String(object),
+			ContentLength: aws.Int64(size),
+		}, expectContinue)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "PutObject with Expect: 100-continue failed", err).Fatal()
+			return
+		}
+		if !recorder.sent() {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PutObject expected to be sent with Expect: 100-continue but got %q", recorder.expect),
+				errors.New("no Expect header")).Fatal()
+			return
+		}
+
+		output, err := client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "GetObject failed", err).Fatal()
+			return
+		}
+		hash := sha256.New()
+		n, err := io.Copy(hash, output.Body)
+		output.Body.Close()
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Reading the object failed", err).Fatal()
+			return
+		}
+		if sum, expected := hex.EncodeToString(hash.Sum(nil)), mintest.DataSHA256(seed+uint64(i), size); n != size || sum != expected {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject expected %d bytes of SHA256 %s but got %d bytes of SHA256 %s", size, expected, n, sum),
+				errors.New("content mismatch")).Fatal()
+			return
+		}
+	}
+	delete(args, "objectName")
+
+	// The server rejects the request before the body is sent
+	missing := bucket + "-missing"
+	size := sizes[len(sizes)-1]
+	_, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Body:          mintest.NewDataReader(seed, size),
+		Bucket:        aws.String(missing),
+		Key:           aws.String("object"),
+		ContentLength: aws.Int64(size),
+	}, expectContinue)
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "NoSuchBucket" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PutObject with Expect: 100-continue to missing bucket %s expected to fail with NoSuchBucket", missing), err).Fatal()
+		return
+	}
+	_, err = client.PutObject(ctx, &s3.PutObjectInput{
+		Body:          mintest.NewDataReader(seed, size),
+		Bucket:        aws.String(bucket),
+		Key:           aws.String("object-after-rejection"),
+		ContentLength: aws.Int64(size),
+	}, expectContinue)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject with Expect: 100-continue after a rejected one failed", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,510 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"mint.minio.io/mintest"
+)
+
+// getObjectContent returns the content of object
+func getObjectContent(ctx context.Context, bucket, object string) ([]byte, error) {
+	output, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+	return io.ReadAll(output.Body)
+}
+
+// getObjectTags returns the tags of object as a map
+func getObjectTags(ctx context.Context, bucket, object string) (map[string]string, error) {
+	output, err := client.GetObjectTagging(ctx, &s3.GetObjectTaggingInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string)
+	for _, tag := range output.TagSet {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return tags, nil
+}
+
+// copySource returns the x-amz-copy-source value of object
+func copySource(bucket, object string) *string {
+	return aws.String(bucket + "/" + object)
+}
+
+// Copy an object with both metadata directives: COPY must keep the source
+// metadata and ignore the one sent, REPLACE must only keep the one sent.
+func testCopyObjectMetadataDirective() {
+	startTime := time.Now()
+	function := "testCopyObjectMetadataDirective"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "source"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Body:        strings.NewReader("copy me"),
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(object),
+		ContentType: aws.String("text/plain"),
+		Metadata:    map[string]string{"color": "red"},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+
+	testCases := []struct {
+		directive   types.MetadataDirective
+		destination string
+		contentType string
+		color       string
+	}{
+		{types.MetadataDirectiveCopy, object + "-copy", "text/plain", "red"},
+		{types.MetadataDirectiveReplace, object + "-replace", "application/json", "blue"},
+	}
+	for _, testCase := range testCases {
+		_, err = client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:            aws.String(bucket),
+			Key:               aws.String(testCase.destination),
+			CopySource:        copySource(bucket, object),
+			MetadataDirective: testCase.directive,
+			ContentType:       aws.String("application/json"),
+			Metadata:          map[string]string{"color": "blue"},
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CopyObject with %s directive failed", testCase.directive), err).Fatal()
+			return
+		}
+
+		output, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(testCase.destination),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "HeadObject failed", err).Fatal()
+			return
+		}
+		if aws.ToString(output.ContentType) != testCase.contentType || output.Metadata["color"] != testCase.color {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CopyObject with %s directive expected Content-Type %s and color %s but got %s and %s",
+				testCase.directive, testCase.contentType, testCase.color, aws.ToString(output.ContentType), output.Metadata["color"]), errors.New("metadata mismatch")).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Copy a tagged object with both tagging directives: COPY must keep the
+// source tags, REPLACE must only keep the tags sent with the copy.
+func testCopyObjectTaggingDirective() {
+	startTime := time.Now()
+	function := "testCopyObjectTaggingDirective"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "source"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Body:    strings.NewReader("copy me"),
+		Bucket:  aws.String(bucket),
+		Key:     aws.String(object),
+		Tagging: aws.String("project=mint&stage=source"),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+
+	testCases := []struct {
+		directive   types.TaggingDirective
+		destination string
+		tags        map[string]string
+	}{
+		{types.TaggingDirectiveCopy, object + "-copy", map[string]string{"project": "mint", "stage": "source"}},
+		{types.TaggingDirectiveReplace, object + "-replace", map[string]string{"stage": "copy"}},
+	}
+	for _, testCase := range testCases {
+		_, err = client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:           aws.String(bucket),
+			Key:              aws.String(testCase.destination),
+			CopySource:       copySource(bucket, object),
+			TaggingDirective: testCase.directive,
+			Tagging:          aws.String("stage=copy"),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CopyObject with %s tagging directive failed", testCase.directive), err).Fatal()
+			return
+		}
+
+		tags, err := getObjectTags(ctx, bucket, testCase.destination)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "GetObjectTagging failed", err).Fatal()
+			return
+		}
+		if !reflect.DeepEqual(tags, testCase.tags) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CopyObject with %s tagging directive expected tags %v but got %v",
+				testCase.directive, testCase.tags, tags), errors.New("tags mismatch")).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Copy an object with the x-amz-copy-source-if-* conditions, which must
+// either succeed or fail with PreconditionFailed.
+func testCopyObjectConditions() {
+	startTime := time.Now()
+	function := "testCopyObjectConditions"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "source"
+	destination := "destination"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Body:   strings.NewReader("copy me"),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+	output, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "HeadObject failed", err).Fatal()
+		return
+	}
+	etag := aws.ToString(output.ETag)
+	lastModified := aws.ToTime(output.LastModified)
+	before := lastModified.Add(-time.Hour)
+	after := lastModified.Add(time.Hour)
+
+	testCases := []struct {
+		name    string
+		input   s3.CopyObjectInput
+		success bool
+	}{
+		{"IfMatch", s3.CopyObjectInput{CopySourceIfMatch: aws.String(etag)}, true},
+		{"IfMatch mismatch", s3.CopyObjectInput{CopySourceIfMatch: aws.String(`"00000000000000000000000000000000"`)}, false},
+		{"IfNoneMatch", s3.CopyObjectInput{CopySourceIfNoneMatch: aws.String(`"00000000000000000000000000000000"`)}, true},
+		{"IfNoneMatch mismatch", s3.CopyObjectInput{CopySourceIfNoneMatch: aws.String(etag)}, false},
+		{"IfModifiedSince", s3.CopyObjectInput{CopySourceIfModifiedSince: aws.Time(before)}, true},
+		{"IfModifiedSince mismatch", s3.CopyObjectInput{CopySourceIfModifiedSince: aws.Time(after)}, false},
+		{"IfUnmodifiedSince", s3.CopyObjectInput{CopySourceIfUnmodifiedSince: aws.Time(after)}, true},
+		{"IfUnmodifiedSince mismatch", s3.CopyObjectInput{CopySourceIfUnmodifiedSince: aws.Time(before)}, false},
+	}
+	for _, testCase := range testCases {
+		input := testCase.input
+		input.Bucket = aws.String(bucket)
+		input.Key = aws.String(destination)
+		input.CopySource = copySource(bucket, object)
+		_, err = client.CopyObject(ctx, &input)
+		if testCase.success && err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CopyObject %s expected to succeed", testCase.name), err).Fatal()
+			return
+		}
+		if !testCase.success && errorCode(err) != "PreconditionFailed" {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CopyObject %s expected to fail with PreconditionFailed", testCase.name), err).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Copy an object onto itself, which is only allowed when replacing its
+// metadata, and check its content is left untouched.
+func testCopyObjectOntoSelf() {
+	startTime := time.Now()
+	function := "testCopyObjectOntoSelf"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	content := "copy me onto myself"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Body:     strings.NewReader(content),
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(object),
+		Metadata: map[string]string{"color": "red"},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+
+	_, err = client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(object),
+		CopySource: copySource(bucket, object),
+	})
+	if errorCode(err) != "InvalidRequest" {
+		mintest.FailureLog(function, args, startTime, "", "CopyObject onto itself without changes expected to fail with InvalidRequest", err).Fatal()
+		return
+	}
+
+	_, err = client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:            aws.String(bucket),
+		Key:               aws.String(object),
+		CopySource:        copySource(bucket, object),
+		MetadataDirective: types.MetadataDirectiveReplace,
+		Metadata:          map[string]string{"color": "blue"},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CopyObject onto itself with REPLACE directive failed", err).Fatal()
+		return
+	}
+
+	output, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "HeadObject failed", err).Fatal()
+		return
+	}
+	if output.Metadata["color"] != "blue" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CopyObject onto itself expected color blue but got %s", output.Metadata["color"]), errors.New("metadata mismatch")).Fatal()
+		return
+	}
+
+	data, err := getObjectContent(ctx, bucket, object)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject failed", err).Fatal()
+		return
+	}
+	if string(data) != content {
+		mintest.FailureLog(function, args, startTime, "", "CopyObject onto itself changed the object content", errors.New("content mismatch")).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Copy an object from one bucket to another
+func testCopyObjectCrossBucket() {
+	startTime := time.Now()
+	function := "testCopyObjectCrossBucket"
+	srcBucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	dstBucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()+1), bucketPrefix)
+	object := "testObject"
+	content := "copy me to another bucket"
+	args := map[string]interface{}{
+		"sourceBucketName":      srcBucket,
+		"destinationBucketName": dstBucket,
+		"objectName":            object,
+	}
+	ctx := context.Background()
+
+	for _, bucket := range []string{srcBucket, dstBucket} {
+		if err := makeBucket(bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+			return
+		}
+		defer cleanupBucket(bucket, function, args, startTime)
+	}
+
+	_, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Body:   strings.NewReader(content),
+		Bucket: aws.String(srcBucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+
+	_, err = client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(dstBucket),
+		Key:        aws.String(object),
+		CopySource: copySource(srcBucket, object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CopyObject across buckets failed", err).Fatal()
+		return
+	}
+
+	data, err := getObjectContent(ctx, dstBucket, object)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject failed", err).Fatal()
+		return
+	}
+	if string(data) != content {
+		mintest.FailureLog(function, args, startTime, "", "CopyObject across buckets returned unexpected content", errors.New("content mismatch")).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Assemble a multipart object from byte ranges of another object, copied
+// out of order, and check the result.
+func testUploadPartCopy() {
+	startTime := time.Now()
+	function := "testUploadPartCopy"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "source"
+	destination := "destination"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	content := make([]byte, 11*1024*1024)
+	rand.New(rand.NewSource(time.Now().UnixNano())).Read(content)
+	_, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Body:   bytes.NewReader(content),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+
+	upload, err := client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(destination),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateMultipartUpload failed", err).Fatal()
+		return
+	}
+
+	// The last 5 MiB, then the first 6 MiB of the source
+	split := 6 * 1024 * 1024
+	ranges := [][2]int{{split, len(content) - 1}, {0, split - 1}}
+	var expected []byte
+	var parts []types.CompletedPart
+	for i, r := range ranges {
+		output, err := client.UploadPartCopy(ctx, &s3.UploadPartCopyInput{
+			Bucket:          aws.String(bucket),
+			Key:             aws.String(destination),
+			UploadId:        upload.UploadId,
+			PartNumber:      aws.Int32(int32(i + 1)),
+			CopySource:      copySource(bucket, object),
+			CopySourceRange: aws.String(fmt.Sprintf("bytes=%d-%d", r[0], r[1])),
+		})
+		if err != nil {
+			client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+				Bucket:   aws.String(bucket),
+				Key:      aws.String(destination),
+				UploadId: upload.UploadId,
+			})
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("UploadPartCopy of range %d-%d failed", r[0], r[1]), err).Fatal()
+			return
+		}
+		parts = append(parts, types.CompletedPart{
+			ETag:       output.CopyPartResult.ETag,
+			PartNumber: aws.Int32(int32(i + 1)),
+		})
+		expected = append(expected, content[r[0]:r[1]+1]...)
+	}
+
+	_, err = client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(destination),
+		UploadId:        upload.UploadId,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CompleteMultipartUpload failed", err).Fatal()
+		return
+	}
+
+	data, err := getObjectContent(ctx, bucket, destination)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject failed", err).Fatal()
+		return
+	}
+	if !bytes.Equal(data, expected) {
+		mintest.FailureLog(function, args, startTime, "", "UploadPartCopy assembled object has unexpected content", errors.New("content mismatch")).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
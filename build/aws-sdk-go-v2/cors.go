@@ -0,0 +1,197 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"mint.minio.io/mintest"
+)
+
+// preflightRequest sends a CORS preflight request for object with a plain
+// http.Client
+func preflightRequest(bucket, object, origin, method, headers string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodOptions, config.URL()+"/"+bucket+"/"+object, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", method)
+	if headers != "" {
+		req.Header.Set("Access-Control-Request-Headers", headers)
+	}
+	resp, err := config.HTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// headerContains reports whether the comma separated list in header h
+// of resp holds value, ignoring case.
+func headerContains(resp *http.Response, h, value string) bool {
+	for _, v := range strings.Split(resp.Header.Get(h), ",") {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Set a CORS configuration on a bucket and read it back, then check the
+// answers to preflight requests from allowed, wildcard and disallowed
+// origins, and that the configuration is gone once deleted.
+func testBucketCors() {
+	startTime := time.Now()
+	function := "testBucketCors"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	allowedOrigin := "https://allowed.example.com"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Body:   strings.NewReader("cors"),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+
+	rules := []types.CORSRule{
+		{
+			AllowedOrigins: []string{allowedOrigin},
+			AllowedMethods: []string{http.MethodGet, http.MethodPut},
+			AllowedHeaders: []string{"x-amz-meta-custom"},
+			ExposeHeaders:  []string{"ETag"},
+			MaxAgeSeconds:  aws.Int32(3000),
+		},
+		{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{http.MethodHead},
+		},
+	}
+	_, err = client.PutBucketCors(ctx, &s3.PutBucketCorsInput{
+		Bucket:            aws.String(bucket),
+		CORSConfiguration: &types.CORSConfiguration{CORSRules: rules},
+	})
+	if errorCode(err) == "NotImplemented" {
+		mintest.IgnoreLog(function, args, startTime, "PutBucketCors is NotImplemented").Info()
+		return
+	}
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutBucketCors failed", err).Fatal()
+		return
+	}
+
+	output, err := client.GetBucketCors(ctx, &s3.GetBucketCorsInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetBucketCors failed", err).Fatal()
+		return
+	}
+	if !reflect.DeepEqual(output.CORSRules, rules) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetBucketCors expected %+v but got %+v", rules, output.CORSRules), errors.New("CORS configuration mismatch")).Fatal()
+		return
+	}
+
+	// Allowed origin, method and header are echoed
+	resp, err := preflightRequest(bucket, object, allowedOrigin, http.MethodPut, "x-amz-meta-custom")
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CORS preflight request failed", err).Fatal()
+		return
+	}
+	if resp.StatusCode != http.StatusOK ||
+		resp.Header.Get("Access-Control-Allow-Origin") != allowedOrigin ||
+		!headerContains(resp, "Access-Control-Allow-Methods", http.MethodPut) ||
+		!headerContains(resp, "Access-Control-Allow-Headers", "x-amz-meta-custom") {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CORS preflight from an allowed origin expected 200 echoing the request but got %d %v", resp.StatusCode, resp.Header),
+			errors.New("CORS preflight mismatch")).Fatal()
+		return
+	}
+
+	// Any origin is allowed to HEAD
+	otherOrigin := "https://other.example.org"
+	resp, err = preflightRequest(bucket, object, otherOrigin, http.MethodHead, "")
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CORS preflight request failed", err).Fatal()
+		return
+	}
+	if origin := resp.Header.Get("Access-Control-Allow-Origin"); resp.StatusCode != http.StatusOK || (origin != "*" && origin != otherOrigin) ||
+		!headerContains(resp, "Access-Control-Allow-Methods", http.MethodHead) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CORS preflight matching a wildcard origin expected 200 but got %d %v", resp.StatusCode, resp.Header),
+			errors.New("CORS preflight mismatch")).Fatal()
+		return
+	}
+
+	// Other origins may not PUT, and allowed ones may not send other headers
+	for _, preflight := range [][3]string{
+		{otherOrigin, http.MethodPut, ""},
+		{allowedOrigin, http.MethodDelete, ""},
+		{allowedOrigin, http.MethodPut, "x-amz-meta-other"},
+	} {
+		resp, err = preflightRequest(bucket, object, preflight[0], preflight[1], preflight[2])
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "CORS preflight request failed", err).Fatal()
+			return
+		}
+		if resp.StatusCode != http.StatusForbidden || resp.Header.Get("Access-Control-Allow-Origin") != "" {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CORS preflight %v expected to be rejected but got %d %v", preflight, resp.StatusCode, resp.Header),
+				errors.New("CORS preflight mismatch")).Fatal()
+			return
+		}
+	}
+
+	_, err = client.DeleteBucketCors(ctx, &s3.DeleteBucketCorsInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucketCors failed", err).Fatal()
+		return
+	}
+	_, err = client.GetBucketCors(ctx, &s3.GetBucketCorsInput{
+		Bucket: aws.String(bucket),
+	})
+	if errorCode(err) != "NoSuchCORSConfiguration" {
+		mintest.FailureLog(function, args, startTime, "", "GetBucketCors after DeleteBucketCors expected to fail with NoSuchCORSConfiguration", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
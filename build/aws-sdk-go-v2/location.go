@@ -0,0 +1,145 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// otherRegion returns a region different from region
+func otherRegion(region string) string {
+	if region == "eu-west-1" {
+		return "us-west-2"
+	}
+	return "eu-west-1"
+}
+
+// HeadBucket an existing bucket, which must answer 200 along with the
+// region of the bucket in x-amz-bucket-region, then a bucket which does
+// not exist, which must answer 404. GetBucketLocation must return the
+// region of the bucket, which is an empty LocationConstraint for
+// us-east-1.
+func testHeadBucketAndLocation() {
+	startTime := time.Now()
+	function := "testHeadBucketAndLocation"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	region := config.Region
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"region":     region,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	head, err := client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "HeadBucket failed", err).Fatal()
+		return
+	}
+	if got := aws.ToString(head.BucketRegion); got != region {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HeadBucket expected x-amz-bucket-region %s but got %q", region, got), nil).Fatal()
+		return
+	}
+
+	missing := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix+"missing-")
+	_, err = client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(missing),
+	})
+	if statusCode(err) != http.StatusNotFound {
+		mintest.FailureLog(function, args, startTime, "", "HeadBucket of a missing bucket expected to fail with 404", err).Fatal()
+		return
+	}
+
+	location, err := client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetBucketLocation failed", err).Fatal()
+		return
+	}
+	expected := region
+	if region == "us-east-1" {
+		expected = ""
+	}
+	if got := string(location.LocationConstraint); got != expected {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetBucketLocation expected LocationConstraint %q but got %q", expected, got), nil).Fatal()
+		return
+	}
+
+	_, err = client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{
+		Bucket: aws.String(missing),
+	})
+	if errorCode(err) != "NoSuchBucket" {
+		mintest.FailureLog(function, args, startTime, "", "GetBucketLocation of a missing bucket expected to fail with NoSuchBucket", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Send requests signed for a region other than the one of the bucket,
+// which must be rejected, AWS redirecting them and MinIO telling the
+// expected region. Servers accepting any region are skipped.
+func testBucketRegionMismatch() {
+	startTime := time.Now()
+	function := "testBucketRegionMismatch"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	region := config.Region
+	args := map[string]interface{}{
+		"bucketName":   bucket,
+		"region":       region,
+		"clientRegion": otherRegion(region),
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	// Sign the request for another region
+	_, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	}, func(o *s3.Options) {
+		o.Region = otherRegion(region)
+	})
+	if err == nil {
+		mintest.IgnoreLog(function, args, startTime, "Requests signed for any region are accepted").Info()
+		return
+	}
+	if code := errorCode(err); code != "AuthorizationHeaderMalformed" && code != "PermanentRedirect" && code != "IncorrectEndpoint" {
+		mintest.FailureLog(function, args, startTime, "", "ListObjectsV2 signed for another region expected to fail with AuthorizationHeaderMalformed or PermanentRedirect", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,122 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	s3v1 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/smithy-go"
+	"mint.minio.io/mintest"
+)
+
+// Prefix of the names of all the buckets created by this suite
+const bucketPrefix = "aws-sdk-go-v2-test-"
+
+// aws-sdk-go-v2 client under test
+var client *s3.Client
+
+// aws-sdk-go client creating and removing the buckets of the tests, for
+// the janitor to track them
+var s3Client *s3v1.S3
+
+// Server under test
+var config mintest.Config
+
+// makeBucket creates a bucket for a test, to be removed with cleanupBucket
+func makeBucket(bucket string) error {
+	_, err := s3Client.CreateBucket(&s3v1.CreateBucketInput{
+		Bucket: awsv1.String(bucket),
+	})
+	return err
+}
+
+// errorCode returns the code of the S3 error err, empty when it is not one
+func errorCode(err error) string {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+	return ""
+}
+
+// statusCode returns the HTTP status code of the response err came with, 0
+// when there is none
+func statusCode(err error) int {
+	var respErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &respErr) {
+		return respErr.HTTPStatusCode()
+	}
+	return 0
+}
+
+func cleanupBucket(bucket string, function string, args map[string]interface{}, startTime time.Time) {
+	if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucket failed", err).Fatal()
+	}
+}
+
+func main() {
+	runStartTime := time.Now()
+	mintest.Init("aws-sdk-go-v2")
+	config = mintest.LoadConfig()
+
+	client = s3.New(s3.Options{
+		BaseEndpoint: aws.String(config.URL()),
+		Credentials:  credentials.NewStaticCredentialsProvider(config.AccessKey, config.SecretKey, config.SessionToken),
+		HTTPClient:   config.HTTPClient(),
+		Region:       config.Region,
+		UsePathStyle: true,
+	})
+
+	// Create an S3 service object in the default region.
+	s3Client = config.NewS3Client()
+	mintest.TrackBuckets(s3Client)
+	// Remove the buckets left behind, even by a failed test
+	defer mintest.StartJanitor(s3Client)()
+
+	mintest.RunTests([]mintest.Test{
+		{Name: "testListBucketsPaging", Description: "Page through buckets by prefix with MaxBuckets and ContinuationToken, or check the full listing where ignored", Run: testListBucketsPaging},
+		{Name: "testPutObjectExpectContinue", Description: "PUT objects with Expect: 100-continue and read them back, and to a missing bucket", Run: testPutObjectExpectContinue},
+		{Name: "testRequestPayer", Description: "Check requests with RequestPayer set to requester are accepted or the parameter ignored", Run: testRequestPayer},
+		{Name: "testAnonymousAccessBucketPolicy", Description: "Check anonymous access with and without a public-read bucket policy, with a plain HTTP client and with the SDK", Run: testAnonymousAccessBucketPolicy},
+		{Name: "testCopyObjectMetadataDirective", Description: "Copy an object with the COPY and REPLACE metadata directives", Run: testCopyObjectMetadataDirective},
+		{Name: "testCopyObjectTaggingDirective", Description: "Copy a tagged object with the COPY and REPLACE tagging directives", Requires: []string{mintest.RequiresObjectTagging}, Run: testCopyObjectTaggingDirective},
+		{Name: "testCopyObjectConditions", Description: "Copy an object with the x-amz-copy-source-if-* conditions", Run: testCopyObjectConditions},
+		{Name: "testCopyObjectOntoSelf", Description: "Copy an object onto itself replacing its metadata", Run: testCopyObjectOntoSelf},
+		{Name: "testCopyObjectCrossBucket", Description: "Copy an object from one bucket to another", Run: testCopyObjectCrossBucket},
+		{Name: "testUploadPartCopy", Description: "Assemble a multipart object from ranges of another object", Run: testUploadPartCopy},
+		{Name: "testBucketCors", Description: "Set a CORS configuration and check the answers to preflight requests from a plain HTTP client", Run: testBucketCors},
+		{Name: "testGetObjectConditions", Description: "Check the matrix of conditional GETs", Run: testGetObjectConditions},
+		{Name: "testBucketPolicy", Description: "Set, read back and delete a bucket policy, and reject malformed ones", Run: testBucketPolicy},
+		{Name: "testBucketTagging", Description: "Set, read back and delete the tags of a bucket within and over the limits", Run: testBucketTagging},
+		{Name: "testAbortMultipartUpload", Description: "Check an aborted multipart upload is gone", Run: testAbortMultipartUpload},
+		{Name: "testListMultipartUploadsPaging", Description: "Page through many multipart uploads", Run: testListMultipartUploadsPaging},
+		{Name: "testUploadPartOverwrite", Description: "Check uploading a part again replaces it", Run: testUploadPartOverwrite},
+		{Name: "testHeadBucketAndLocation", Description: "HEAD existing and missing buckets and get their location", Run: testHeadBucketAndLocation},
+		{Name: "testBucketRegionMismatch", Description: "Check requests signed for another region are rejected", Run: testBucketRegionMismatch},
+		{Name: "testSessionToken", Description: "Sign bucket, object, multipart and presigned operations with temporary credentials", Run: testSessionToken},
+		{Name: "testSessionTokenInvalid", Description: "Check requests with a garbled, truncated or missing session token are rejected", Run: testSessionTokenInvalid},
+		{Name: "benchmarkPutGetObject", Description: "Time sequential and concurrent PUTs and GETs in benchmark mode", Requires: []string{mintest.RequiresBenchmark}, Run: benchmarkPutGetObject},
+		{Name: "testAdaptiveRetry", Description: "PUT a single key from hundreds of concurrent requests with adaptive retries, which must all succeed however throttled", Run: testAdaptiveRetry},
+	}, mintest.NewCapabilities(config, s3Client).Supports)
+	mintest.TestResourceLeaks(s3Client, bucketPrefix, runStartTime)
+}
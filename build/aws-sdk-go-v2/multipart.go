@@ -0,0 +1,305 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"mint.minio.io/mintest"
+)
+
+// Abort a multipart upload holding a part, then check the upload is gone:
+// ListParts and UploadPart must fail with NoSuchUpload, and
+// ListMultipartUploads must not list it anymore.
+func testAbortMultipartUpload() {
+	startTime := time.Now()
+	function := "testAbortMultipartUpload"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	upload, err := client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateMultipartUpload failed", err).Fatal()
+		return
+	}
+	args["uploadId"] = aws.ToString(upload.UploadId)
+	uploadPart := func() error {
+		_, err := client.UploadPart(ctx, &s3.UploadPartInput{
+			Body:       bytes.NewReader([]byte("fileToUpload")),
+			Bucket:     aws.String(bucket),
+			Key:        aws.String(object),
+			PartNumber: aws.Int32(1),
+			UploadId:   upload.UploadId,
+		})
+		return err
+	}
+	if err = uploadPart(); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "UploadPart failed", err).Fatal()
+		return
+	}
+
+	_, err = client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(object),
+		UploadId: upload.UploadId,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AbortMultipartUpload failed", err).Fatal()
+		return
+	}
+
+	_, err = client.ListParts(ctx, &s3.ListPartsInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(object),
+		UploadId: upload.UploadId,
+	})
+	if errorCode(err) != "NoSuchUpload" {
+		mintest.FailureLog(function, args, startTime, "", "ListParts of an aborted upload expected to fail with NoSuchUpload", err).Fatal()
+		return
+	}
+	if err = uploadPart(); errorCode(err) != "NoSuchUpload" {
+		mintest.FailureLog(function, args, startTime, "", "UploadPart to an aborted upload expected to fail with NoSuchUpload", err).Fatal()
+		return
+	}
+
+	uploads, err := client.ListMultipartUploads(ctx, &s3.ListMultipartUploadsInput{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ListMultipartUploads failed", err).Fatal()
+		return
+	}
+	if len(uploads.Uploads) != 0 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListMultipartUploads expected no upload after abort but got %d", len(uploads.Uploads)), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Create many uploads of a few objects concurrently, then page through
+// them with ListMultipartUploads, a few uploads at a time, following the
+// key and upload id markers. Each upload must be listed exactly once. The
+// object name is passed as prefix, as MinIO only lists the uploads of a
+// single object.
+func testListMultipartUploadsPaging() {
+	startTime := time.Now()
+	function := "testListMultipartUploadsPaging"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	objects := []string{"dir/object1", "dir/object2", "object3"}
+	const uploadsPerObject = 10
+	const maxUploads = 3
+	args := map[string]interface{}{
+		"bucketName":       bucket,
+		"objectNames":      objects,
+		"uploadsPerObject": uploadsPerObject,
+		"maxUploads":       maxUploads,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	uploadIDs := make(map[string]map[string]bool)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	errs := make([]error, len(objects)*uploadsPerObject)
+	for i := range errs {
+		object := objects[i%len(objects)]
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			upload, err := client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+				Bucket: aws.String(bucket),
+				Key:    aws.String(object),
+			})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			if uploadIDs[object] == nil {
+				uploadIDs[object] = make(map[string]bool)
+			}
+			uploadIDs[object][aws.ToString(upload.UploadId)] = true
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "CreateMultipartUpload failed", err).Fatal()
+			return
+		}
+	}
+
+	for _, object := range objects {
+		args["objectName"] = object
+		listed := make(map[string]bool)
+		input := &s3.ListMultipartUploadsInput{
+			Bucket:     aws.String(bucket),
+			Prefix:     aws.String(object),
+			MaxUploads: aws.Int32(maxUploads),
+		}
+		for page := 0; ; page++ {
+			if page > uploadsPerObject {
+				mintest.FailureLog(function, args, startTime, "", "ListMultipartUploads does not stop paging", nil).Fatal()
+				return
+			}
+			output, err := client.ListMultipartUploads(ctx, input)
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", "ListMultipartUploads failed", err).Fatal()
+				return
+			}
+			if len(output.Uploads) > maxUploads {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListMultipartUploads expected at most %d uploads but got %d", maxUploads, len(output.Uploads)), nil).Fatal()
+				return
+			}
+			for _, upload := range output.Uploads {
+				uploadID := aws.ToString(upload.UploadId)
+				if aws.ToString(upload.Key) != object || !uploadIDs[object][uploadID] || listed[uploadID] {
+					mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListMultipartUploads returned unexpected upload %s of %s", uploadID, aws.ToString(upload.Key)), nil).Fatal()
+					return
+				}
+				listed[uploadID] = true
+			}
+			if !aws.ToBool(output.IsTruncated) {
+				break
+			}
+			input.KeyMarker = output.NextKeyMarker
+			input.UploadIdMarker = output.NextUploadIdMarker
+		}
+		if len(listed) != len(uploadIDs[object]) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListMultipartUploads expected %d uploads but got %d", len(uploadIDs[object]), len(listed)), nil).Fatal()
+			return
+		}
+	}
+	delete(args, "objectName")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Upload the same part number twice, and check the second upload replaces
+// the first one, in ListParts and in the completed object.
+func testUploadPartOverwrite() {
+	startTime := time.Now()
+	function := "testUploadPartOverwrite"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	upload, err := client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateMultipartUpload failed", err).Fatal()
+		return
+	}
+	args["uploadId"] = aws.ToString(upload.UploadId)
+
+	first := []byte(mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "first-"))
+	second := []byte(mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "second-") + "-longer")
+	var etag *string
+	for _, data := range [][]byte{first, second} {
+		output, err := client.UploadPart(ctx, &s3.UploadPartInput{
+			Body:       bytes.NewReader(data),
+			Bucket:     aws.String(bucket),
+			Key:        aws.String(object),
+			PartNumber: aws.Int32(1),
+			UploadId:   upload.UploadId,
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "UploadPart failed", err).Fatal()
+			return
+		}
+		etag = output.ETag
+	}
+
+	parts, err := client.ListParts(ctx, &s3.ListPartsInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(object),
+		UploadId: upload.UploadId,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ListParts failed", err).Fatal()
+		return
+	}
+	if len(parts.Parts) != 1 || aws.ToString(parts.Parts[0].ETag) != aws.ToString(etag) || aws.ToInt64(parts.Parts[0].Size) != int64(len(second)) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListParts expected only the part of ETag %s and size %d", aws.ToString(etag), len(second)), nil).Fatal()
+		return
+	}
+
+	_, err = client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+		MultipartUpload: &types.CompletedMultipartUpload{
+			Parts: []types.CompletedPart{{ETag: etag, PartNumber: aws.Int32(1)}},
+		},
+		UploadId: upload.UploadId,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CompleteMultipartUpload failed", err).Fatal()
+		return
+	}
+	got, err := getObjectContent(ctx, bucket, object)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject failed", err).Fatal()
+		return
+	}
+	if !bytes.Equal(got, second) {
+		mintest.FailureLog(function, args, startTime, "", "GetObject expected the content of the last upload of the part", nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,156 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"mint.minio.io/mintest"
+)
+
+// checkCharged returns an error when the RequestCharged of the response to
+// operation is other than requester, or empty for servers ignoring the
+// request payer
+func checkCharged(operation string, charged types.RequestCharged) error {
+	if charged != "" && charged != types.RequestChargedRequester {
+		return fmt.Errorf("%s expected to charge the requester or no one but got %q", operation, charged)
+	}
+	return nil
+}
+
+// Send PUT, HEAD, GET, list and DELETE requests with RequestPayer set to
+// requester. The owner of the bucket pays anyway, so the parameter must be
+// accepted, or ignored, without changing the results: the object reads
+// back with its content and is listed and deleted as without it. The
+// requests charged are logged in the args.
+func testRequestPayer() {
+	startTime := time.Now()
+	function := "testRequestPayer"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "payer-object"
+	content := "requester pays"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+	ctx := context.Background()
+	payer := types.RequestPayerRequester
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	charged := make(map[string]types.RequestCharged)
+	put, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Body:         strings.NewReader(content),
+		Bucket:       aws.String(bucket),
+		Key:          aws.String(object),
+		RequestPayer: payer,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject with RequestPayer failed", err).Fatal()
+		return
+	}
+	charged["PutObject"] = put.RequestCharged
+
+	head, err := client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket:       aws.String(bucket),
+		Key:          aws.String(object),
+		RequestPayer: payer,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "HeadObject with RequestPayer failed", err).Fatal()
+		return
+	}
+	charged["HeadObject"] = head.RequestCharged
+	if aws.ToInt64(head.ContentLength) != int64(len(content)) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HeadObject with RequestPayer expected %d bytes but got %d", len(content), aws.ToInt64(head.ContentLength)),
+			errors.New("size mismatch")).Fatal()
+		return
+	}
+
+	get, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket:       aws.String(bucket),
+		Key:          aws.String(object),
+		RequestPayer: payer,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject with RequestPayer failed", err).Fatal()
+		return
+	}
+	data, err := io.ReadAll(get.Body)
+	get.Body.Close()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Reading the object failed", err).Fatal()
+		return
+	}
+	charged["GetObject"] = get.RequestCharged
+	if string(data) != content {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject with RequestPayer expected %q but got %q", content, data), errors.New("content mismatch")).Fatal()
+		return
+	}
+
+	list, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+		Bucket:       aws.String(bucket),
+		RequestPayer: payer,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ListObjectsV2 with RequestPayer failed", err).Fatal()
+		return
+	}
+	charged["ListObjectsV2"] = list.RequestCharged
+	if len(list.Contents) != 1 || aws.ToString(list.Contents[0].Key) != object {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectsV2 with RequestPayer expected to list %s alone but got %d objects", object, len(list.Contents)),
+			errors.New("listing mismatch")).Fatal()
+		return
+	}
+
+	del, err := client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket:       aws.String(bucket),
+		Key:          aws.String(object),
+		RequestPayer: payer,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteObject with RequestPayer failed", err).Fatal()
+		return
+	}
+	charged["DeleteObject"] = del.RequestCharged
+	if _, err = client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(bucket), Key: aws.String(object)}); err == nil {
+		mintest.FailureLog(function, args, startTime, "", "HeadObject expected to fail once the object is deleted with RequestPayer", nil).Fatal()
+		return
+	}
+
+	args["requestCharged"] = charged
+	for operation, requestCharged := range charged {
+		if err = checkCharged(operation, requestCharged); err != nil {
+			mintest.FailureLog(function, args, startTime, "", err.Error(), err).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
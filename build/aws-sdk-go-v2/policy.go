@@ -0,0 +1,334 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"mint.minio.io/mintest"
+)
+
+const publicReadPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:GetBucketLocation", "s3:ListBucket"],
+      "Resource": ["arn:aws:s3:::%[1]s"]
+    },
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::%[1]s/*"]
+    }
+  ]
+}`
+
+// anonymous makes the client send unsigned requests
+func anonymous(o *s3.Options) {
+	o.Credentials = aws.AnonymousCredentials{}
+}
+
+// anonymousRequest sends an unsigned request to the server with a plain
+// http.Client and returns the response status code and the S3 error code,
+// if any.
+func anonymousRequest(method, path string) (int, string, error) {
+	req, err := http.NewRequest(method, config.URL()+path, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	resp, err := config.HTTPClient().Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, "", err
+	}
+	if resp.StatusCode < 300 || method == http.MethodHead {
+		return resp.StatusCode, "", nil
+	}
+	errResp := mintest.ErrorResponse{}
+	if err = xml.Unmarshal(body, &errResp); err != nil {
+		return resp.StatusCode, "", err
+	}
+	return resp.StatusCode, errResp.Code, nil
+}
+
+// Set a public-read bucket policy and check unauthenticated GET, HEAD and
+// list requests are allowed, both with a plain http.Client and with the
+// SDK sending unsigned requests, while an anonymous DELETE is denied. Then
+// remove the policy and check anonymous access is denied again.
+func testAnonymousAccessBucketPolicy() {
+	startTime := time.Now()
+	function := "testAnonymousAccessBucketPolicy"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "public/testObject"
+	content := "public content"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err := client.PutObject(ctx, &s3.PutObjectInput{
+		Body:   strings.NewReader(content),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+
+	objectPath := "/" + bucket + "/" + object
+	listPath := "/" + bucket + "?list-type=2"
+
+	// Without a policy every anonymous request is denied
+	for _, method := range []string{http.MethodGet, http.MethodHead} {
+		status, code, err := anonymousRequest(method, objectPath)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Anonymous %s request failed", method), err).Fatal()
+			return
+		}
+		if status != http.StatusForbidden || (method == http.MethodGet && code != "AccessDenied") {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Anonymous %s without bucket policy expected AccessDenied but got %d %s", method, status, code), nil).Fatal()
+			return
+		}
+	}
+
+	_, err = client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucket),
+		Policy: aws.String(fmt.Sprintf(publicReadPolicy, bucket)),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutBucketPolicy failed", err).Fatal()
+		return
+	}
+
+	// Raw HTTP path
+	for _, path := range []string{objectPath, listPath} {
+		for _, method := range []string{http.MethodGet, http.MethodHead} {
+			status, code, err := anonymousRequest(method, path)
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Anonymous %s %s request failed", method, path), err).Fatal()
+				return
+			}
+			if status != http.StatusOK {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Anonymous %s %s with public-read policy expected 200 but got %d %s", method, path, status, code), nil).Fatal()
+				return
+			}
+		}
+	}
+
+	// Anonymous writes are not covered by the policy
+	status, code, err := anonymousRequest(http.MethodDelete, objectPath)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Anonymous DELETE request failed", err).Fatal()
+		return
+	}
+	if status != http.StatusForbidden || code != "AccessDenied" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Anonymous DELETE expected AccessDenied but got %d %s", status, code), nil).Fatal()
+		return
+	}
+
+	// SDK path
+	get, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	}, anonymous)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Anonymous GetObject expected to succeed", err).Fatal()
+		return
+	}
+	body, err := io.ReadAll(get.Body)
+	get.Body.Close()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Anonymous GetObject reading body failed", err).Fatal()
+		return
+	}
+	if string(body) != content {
+		mintest.FailureLog(function, args, startTime, "", "Anonymous GetObject returned unexpected content", errors.New("content mismatch")).Fatal()
+		return
+	}
+
+	_, err = client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	}, anonymous)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Anonymous HeadObject expected to succeed", err).Fatal()
+		return
+	}
+
+	list, err := client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	}, anonymous)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Anonymous ListObjectsV2 expected to succeed", err).Fatal()
+		return
+	}
+	if len(list.Contents) != 1 || aws.ToString(list.Contents[0].Key) != object {
+		mintest.FailureLog(function, args, startTime, "", "Anonymous ListObjectsV2 returned unexpected result", errors.New("listing mismatch")).Fatal()
+		return
+	}
+
+	_, err = client.DeleteBucketPolicy(ctx, &s3.DeleteBucketPolicyInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucketPolicy failed", err).Fatal()
+		return
+	}
+
+	// Access is denied again after the policy removal
+	for _, path := range []string{objectPath, listPath} {
+		status, code, err := anonymousRequest(http.MethodGet, path)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Anonymous GET %s request failed", path), err).Fatal()
+			return
+		}
+		if status != http.StatusForbidden || code != "AccessDenied" {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Anonymous GET %s after policy removal expected AccessDenied but got %d %s", path, status, code), nil).Fatal()
+			return
+		}
+	}
+
+	_, err = client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	}, anonymous)
+	if errorCode(err) != "AccessDenied" {
+		mintest.FailureLog(function, args, startTime, "", "Anonymous GetObject after policy removal expected AccessDenied", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Set a bucket policy and read it back, check malformed policies are
+// rejected with MalformedPolicy, then delete the policy and check reading
+// it fails with NoSuchBucketPolicy.
+func testBucketPolicy() {
+	startTime := time.Now()
+	function := "testBucketPolicy"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	policy := fmt.Sprintf(publicReadPolicy, bucket)
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"policy":     policy,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err := client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
+		Bucket: aws.String(bucket),
+	})
+	if errorCode(err) != "NoSuchBucketPolicy" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetBucketPolicy without policy expected NoSuchBucketPolicy but got %v", err), err).Fatal()
+		return
+	}
+
+	_, err = client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucket),
+		Policy: aws.String(policy),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutBucketPolicy failed", err).Fatal()
+		return
+	}
+
+	output, err := client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetBucketPolicy failed", err).Fatal()
+		return
+	}
+	var expected, got interface{}
+	json.Unmarshal([]byte(policy), &expected)
+	if err = json.Unmarshal([]byte(aws.ToString(output.Policy)), &got); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetBucketPolicy returned invalid JSON", err).Fatal()
+		return
+	}
+	if !reflect.DeepEqual(expected, got) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetBucketPolicy expected %s but got %s", policy, aws.ToString(output.Policy)), errors.New("policy mismatch")).Fatal()
+		return
+	}
+
+	malformedPolicies := map[string]string{
+		"invalid JSON":      `{"Version": "2012-10-17", "Statement": [`,
+		"invalid effect":    fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Maybe","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`, bucket),
+		"invalid action":    fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:NoSuchAction"],"Resource":["arn:aws:s3:::%s/*"]}]}`, bucket),
+		"another bucket":    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::another-bucket/*"]}]}`,
+		"missing principal": fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`, bucket),
+	}
+	for name, malformedPolicy := range malformedPolicies {
+		_, err = client.PutBucketPolicy(ctx, &s3.PutBucketPolicyInput{
+			Bucket: aws.String(bucket),
+			Policy: aws.String(malformedPolicy),
+		})
+		if errorCode(err) != "MalformedPolicy" {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PutBucketPolicy with %s expected MalformedPolicy but got %v", name, err), err).Fatal()
+			return
+		}
+	}
+
+	_, err = client.DeleteBucketPolicy(ctx, &s3.DeleteBucketPolicyInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucketPolicy failed", err).Fatal()
+		return
+	}
+
+	_, err = client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{
+		Bucket: aws.String(bucket),
+	})
+	if errorCode(err) != "NoSuchBucketPolicy" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetBucketPolicy after DeleteBucketPolicy expected NoSuchBucketPolicy but got %v", err), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,143 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+	"mint.minio.io/mintest"
+)
+
+const (
+	// Number of concurrent PUTs of the same key to get throttled
+	throttledPuts = 512
+	// Attempts of each PUT with adaptive retries
+	adaptiveMaxAttempts = 10
+)
+
+// Hammer a single key with hundreds of concurrent small PUTs, for the
+// server to answer some of them with 503 SlowDown, from a client with the
+// adaptive retry mode of the SDK. Every PUT must eventually succeed, and
+// the object must hold the content of one of them. The attempts, retries
+// and throttled attempts, as told by the retry middleware, are logged in
+// the args. The test is not applicable when no request was throttled.
+func testAdaptiveRetry() {
+	startTime := time.Now()
+	function := "testAdaptiveRetry"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "throttled-object"
+	args := map[string]interface{}{
+		"bucketName":  bucket,
+		"objectName":  object,
+		"puts":        throttledPuts,
+		"maxAttempts": adaptiveMaxAttempts,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	adaptiveClient := s3.New(client.Options(), func(o *s3.Options) {
+		o.Retryer = retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
+			o.StandardOptions = append(o.StandardOptions, func(o *retry.StandardOptions) {
+				o.MaxAttempts = adaptiveMaxAttempts
+			})
+		})
+	})
+
+	var mu sync.Mutex
+	var attempts, retries, throttled int
+	var firstErr error
+	var wg sync.WaitGroup
+	for i := 0; i < throttledPuts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			output, err := adaptiveClient.PutObject(ctx, &s3.PutObjectInput{
+				Body:   strings.NewReader(fmt.Sprintf("put-%d", i)),
+				Bucket: aws.String(bucket),
+				Key:    aws.String(object),
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			results, _ := retry.GetAttemptResults(output.ResultMetadata)
+			for _, result := range results.Results {
+				attempts++
+				if result.Retried {
+					retries++
+				}
+				var apiErr smithy.APIError
+				if errors.As(result.Err, &apiErr) && apiErr.ErrorCode() == "SlowDown" {
+					throttled++
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	args["attempts"] = attempts
+	args["retries"] = retries
+	args["throttled"] = throttled
+	if firstErr != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject with adaptive retries expected to eventually succeed", firstErr).Fatal()
+		return
+	}
+
+	output, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject failed", err).Fatal()
+		return
+	}
+	content, err := io.ReadAll(output.Body)
+	output.Body.Close()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject failed", err).Fatal()
+		return
+	}
+	var n int
+	if _, err = fmt.Sscanf(string(content), "put-%d", &n); err != nil || n < 0 || n >= throttledPuts {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject expected the content of one of the PUTs but got %q", content), err).Fatal()
+		return
+	}
+
+	if throttled == 0 {
+		mintest.IgnoreLog(function, args, startTime, "No PUT was throttled with SlowDown to retry").Info()
+		return
+	}
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,414 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	sessionv1 "github.com/aws/aws-sdk-go/aws/session"
+	stsv1 "github.com/aws/aws-sdk-go/service/sts"
+	"mint.minio.io/mintest"
+)
+
+// Error codes of the requests signed with an invalid session token. MinIO
+// answers InvalidTokenId where S3 answers InvalidToken.
+var invalidTokenCodes = map[string]bool{
+	"InvalidToken":   true,
+	"InvalidTokenId": true,
+	"ExpiredToken":   true,
+}
+
+// sessionCredentials returns the temporary credentials to test with, those
+// configured along with SESSION_TOKEN or else new ones obtained from the
+// server with AssumeRole. It returns nil without an error when the server
+// does not provide temporary credentials.
+func sessionCredentials() (*aws.Credentials, error) {
+	if config.SessionToken != "" {
+		return &aws.Credentials{
+			AccessKeyID:     config.AccessKey,
+			SecretAccessKey: config.SecretKey,
+			SessionToken:    config.SessionToken,
+		}, nil
+	}
+
+	output, err := stsv1.New(sessionv1.New(), s3Client.Config.Copy()).AssumeRole(&stsv1.AssumeRoleInput{
+		DurationSeconds: awsv1.Int64(900),
+		RoleArn:         awsv1.String("arn:minio:iam:::role/mint"),
+		RoleSessionName: awsv1.String("mint"),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && (aerr.Code() == "NotImplemented" || aerr.Code() == "MethodNotAllowed" || aerr.Code() == "InvalidAction") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if output.Credentials == nil || awsv1.StringValue(output.Credentials.SessionToken) == "" {
+		return nil, fmt.Errorf("AssumeRole returned incomplete credentials %v", output.Credentials)
+	}
+	return &aws.Credentials{
+		AccessKeyID:     awsv1.StringValue(output.Credentials.AccessKeyId),
+		SecretAccessKey: awsv1.StringValue(output.Credentials.SecretAccessKey),
+		SessionToken:    awsv1.StringValue(output.Credentials.SessionToken),
+	}, nil
+}
+
+// newSessionClient returns a client for the same server as client, signing
+// with the temporary credentials and sending sessionToken
+func newSessionClient(creds *aws.Credentials, sessionToken string) *s3.Client {
+	options := client.Options()
+	options.Credentials = credentials.NewStaticCredentialsProvider(creds.AccessKeyID, creds.SecretAccessKey, sessionToken)
+	return s3.New(options)
+}
+
+// doPresigned sends a presigned request with a plain http.Client and
+// returns the response status code, the S3 error code, if any, and the
+// response body.
+func doPresigned(presigned *v4.PresignedHTTPRequest, body string) (int, string, string, error) {
+	req, err := http.NewRequest(presigned.Method, presigned.URL, strings.NewReader(body))
+	if err != nil {
+		return 0, "", "", err
+	}
+	for h, values := range presigned.SignedHeader {
+		if !strings.EqualFold(h, "Host") {
+			req.Header[h] = values
+		}
+	}
+	resp, err := config.HTTPClient().Do(req)
+	if err != nil {
+		return 0, "", "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, "", "", err
+	}
+	errResp := mintest.ErrorResponse{}
+	if resp.StatusCode != http.StatusOK {
+		xml.Unmarshal(data, &errResp)
+	}
+	return resp.StatusCode, errResp.Code, string(data), nil
+}
+
+// Sign the bucket, object, copy, listing, multipart, presigned and delete
+// operations with temporary credentials, sending their session token in
+// the X-Amz-Security-Token header or query parameter.
+func testSessionToken() {
+	startTime := time.Now()
+	function := "testSessionToken"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	copyObject := "testObjectCopy"
+	multipartObject := "testObjectMultipart"
+	content := []byte("signed with a session token")
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+	ctx := context.Background()
+
+	creds, err := sessionCredentials()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Getting temporary credentials failed", err).Fatal()
+		return
+	}
+	if creds == nil {
+		mintest.IgnoreLog(function, args, startTime, "Temporary credentials are not supported").Info()
+		return
+	}
+	sessionClient := newSessionClient(creds, creds.SessionToken)
+
+	_, err = sessionClient.CreateBucket(ctx, &s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket with a session token failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	if _, err = sessionClient.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucket)}); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "HeadBucket with a session token failed", err).Fatal()
+		return
+	}
+	if _, err = sessionClient.ListBuckets(ctx, &s3.ListBucketsInput{}); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ListBuckets with a session token failed", err).Fatal()
+		return
+	}
+
+	_, err = sessionClient.PutObject(ctx, &s3.PutObjectInput{
+		Body:   bytes.NewReader(content),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject with a session token failed", err).Fatal()
+		return
+	}
+	head, err := sessionClient.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "HeadObject with a session token failed", err).Fatal()
+		return
+	}
+	if aws.ToInt64(head.ContentLength) != int64(len(content)) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HeadObject with a session token expected size %d but got %d", len(content), aws.ToInt64(head.ContentLength)), nil).Fatal()
+		return
+	}
+
+	_, err = sessionClient.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(copyObject),
+		CopySource: copySource(bucket, object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CopyObject with a session token failed", err).Fatal()
+		return
+	}
+	for _, key := range []string{object, copyObject} {
+		output, err := sessionClient.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "GetObject with a session token failed", err).Fatal()
+			return
+		}
+		body, err := io.ReadAll(output.Body)
+		output.Body.Close()
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Reading the object failed", err).Fatal()
+			return
+		}
+		if !bytes.Equal(body, content) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject of %s with a session token returned unexpected content %q", key, body), nil).Fatal()
+			return
+		}
+	}
+
+	upload, err := sessionClient.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(multipartObject),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateMultipartUpload with a session token failed", err).Fatal()
+		return
+	}
+	var parts []types.CompletedPart
+	for i, part := range [][]byte{bytes.Repeat([]byte("a"), 5*1024*1024), content} {
+		output, err := sessionClient.UploadPart(ctx, &s3.UploadPartInput{
+			Body:       bytes.NewReader(part),
+			Bucket:     aws.String(bucket),
+			Key:        aws.String(multipartObject),
+			PartNumber: aws.Int32(int32(i + 1)),
+			UploadId:   upload.UploadId,
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "UploadPart with a session token failed", err).Fatal()
+			return
+		}
+		parts = append(parts, types.CompletedPart{ETag: output.ETag, PartNumber: aws.Int32(int32(i + 1))})
+	}
+	_, err = sessionClient.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(multipartObject),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+		UploadId:        upload.UploadId,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CompleteMultipartUpload with a session token failed", err).Fatal()
+		return
+	}
+
+	list, err := sessionClient.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ListObjectsV2 with a session token failed", err).Fatal()
+		return
+	}
+	sizes := map[string]int64{}
+	for _, item := range list.Contents {
+		sizes[aws.ToString(item.Key)] = aws.ToInt64(item.Size)
+	}
+	expected := map[string]int64{
+		object:          int64(len(content)),
+		copyObject:      int64(len(content)),
+		multipartObject: 5*1024*1024 + int64(len(content)),
+	}
+	if fmt.Sprint(sizes) != fmt.Sprint(expected) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectsV2 with a session token expected %v but got %v", expected, sizes), nil).Fatal()
+		return
+	}
+
+	presigned, err := s3.NewPresignClient(sessionClient).PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	}, s3.WithPresignExpires(time.Minute))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Presigning with a session token failed", err).Fatal()
+		return
+	}
+	status, code, body, err := doPresigned(presigned, "")
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Presigned GET with a session token failed", err).Fatal()
+		return
+	}
+	if status != http.StatusOK || body != string(content) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Presigned GET with a session token expected 200 but got %d %s", status, code), nil).Fatal()
+		return
+	}
+
+	deleted, err := sessionClient.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+		Bucket: aws.String(bucket),
+		Delete: &types.Delete{
+			Objects: []types.ObjectIdentifier{
+				{Key: aws.String(object)},
+				{Key: aws.String(copyObject)},
+				{Key: aws.String(multipartObject)},
+			},
+		},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteObjects with a session token failed", err).Fatal()
+		return
+	}
+	if len(deleted.Errors) != 0 || len(deleted.Deleted) != 3 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects with a session token expected 3 deletions but got %d and %d errors", len(deleted.Deleted), len(deleted.Errors)), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Sign requests with temporary credentials along with a garbled, truncated
+// or missing session token, and check the server rejects them, whether
+// presigned or not, without writing anything.
+func testSessionTokenInvalid() {
+	startTime := time.Now()
+	function := "testSessionTokenInvalid"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+	ctx := context.Background()
+
+	creds, err := sessionCredentials()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Getting temporary credentials failed", err).Fatal()
+		return
+	}
+	if creds == nil {
+		mintest.IgnoreLog(function, args, startTime, "Temporary credentials are not supported").Info()
+		return
+	}
+
+	if err = makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	token := creds.SessionToken
+	// Flip the case of a character in the middle, where a JWT has its payload
+	garbled := []byte(token)
+	middle := len(garbled) / 2
+	if garbled[middle] >= 'a' && garbled[middle] <= 'z' || garbled[middle] >= 'A' && garbled[middle] <= 'Z' {
+		garbled[middle] ^= 0x20
+	} else {
+		garbled[middle] = 'x'
+	}
+
+	for _, testCase := range []struct {
+		name  string
+		token string
+		codes map[string]bool
+	}{
+		{"garbled", string(garbled), invalidTokenCodes},
+		{"truncated", token[:len(token)/2], invalidTokenCodes},
+		{"appended", token + "x", invalidTokenCodes},
+		// S3 does not recognize a temporary access key without its token
+		{"missing", "", map[string]bool{"InvalidToken": true, "InvalidTokenId": true, "InvalidAccessKeyId": true}},
+	} {
+		args["sessionToken"] = testCase.name
+		sessionClient := newSessionClient(creds, testCase.token)
+
+		_, err = sessionClient.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket: aws.String(bucket),
+		})
+		if !testCase.codes[errorCode(err)] {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectsV2 with a %s session token expected to fail with one of %v", testCase.name, testCase.codes), err).Fatal()
+			return
+		}
+		_, err = sessionClient.PutObject(ctx, &s3.PutObjectInput{
+			Body:   bytes.NewReader([]byte("content")),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if !testCase.codes[errorCode(err)] {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PutObject with a %s session token expected to fail with one of %v", testCase.name, testCase.codes), err).Fatal()
+			return
+		}
+
+		presigned, err := s3.NewPresignClient(sessionClient).PresignPutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		}, s3.WithPresignExpires(time.Minute))
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Presigning failed", err).Fatal()
+			return
+		}
+		status, code, _, err := doPresigned(presigned, "content")
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Presigned PUT failed", err).Fatal()
+			return
+		}
+		if status == http.StatusOK || !testCase.codes[code] {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Presigned PUT with a %s session token expected to fail with one of %v but got %d %s", testCase.name, testCase.codes, status, code), nil).Fatal()
+			return
+		}
+	}
+	delete(args, "sessionToken")
+
+	_, err = client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if errorCode(err) != "NotFound" {
+		mintest.FailureLog(function, args, startTime, "", "HeadObject expected no object written with an invalid session token", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
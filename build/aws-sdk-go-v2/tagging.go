@@ -0,0 +1,160 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"mint.minio.io/mintest"
+)
+
+// Maximum number of tags of a bucket
+const maxBucketTags = 50
+
+// bucketTagSet returns a tag set of n tags with distinct keys
+func bucketTagSet(n int) []types.Tag {
+	tagSet := make([]types.Tag, 0, n)
+	for i := 0; i < n; i++ {
+		tagSet = append(tagSet, types.Tag{
+			Key:   aws.String(fmt.Sprintf("key%02d", i)),
+			Value: aws.String(fmt.Sprintf("value%02d", i)),
+		})
+	}
+	return tagSet
+}
+
+// Set, read back and delete the tags of a bucket, with a tag set at the
+// limit of 50 tags, then check tag sets over the limits are rejected and
+// that reading the tags of an untagged bucket fails with NoSuchTagSet.
+func testBucketTagging() {
+	startTime := time.Now()
+	function := "testBucketTagging"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	args := map[string]interface{}{
+		"bucketName": bucket,
+	}
+	ctx := context.Background()
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	tagSet := bucketTagSet(maxBucketTags)
+	_, err := client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
+		Bucket:  aws.String(bucket),
+		Tagging: &types.Tagging{TagSet: tagSet},
+	})
+	if errorCode(err) == "NotImplemented" {
+		mintest.IgnoreLog(function, args, startTime, "PutBucketTagging is NotImplemented").Info()
+		return
+	}
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PutBucketTagging of %d tags expected to succeed but got %v", maxBucketTags, err), err).Fatal()
+		return
+	}
+
+	output, err := client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetBucketTagging failed", err).Fatal()
+		return
+	}
+	got := make(map[string]string, len(output.TagSet))
+	for _, tag := range output.TagSet {
+		got[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	expected := make(map[string]string, len(tagSet))
+	for _, tag := range tagSet {
+		expected[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetBucketTagging expected %v but got %v", expected, got), nil).Fatal()
+		return
+	}
+
+	invalidTagSets := map[string][]types.Tag{
+		"more than 50 tags": bucketTagSet(maxBucketTags + 1),
+		"a key of 129 characters": {
+			{Key: aws.String(strings.Repeat("k", 129)), Value: aws.String("value")},
+		},
+		"a value of 257 characters": {
+			{Key: aws.String("key"), Value: aws.String(strings.Repeat("v", 257))},
+		},
+		"duplicate keys": {
+			{Key: aws.String("key"), Value: aws.String("value1")},
+			{Key: aws.String("key"), Value: aws.String("value2")},
+		},
+	}
+	for name, invalidTagSet := range invalidTagSets {
+		_, err = client.PutBucketTagging(ctx, &s3.PutBucketTaggingInput{
+			Bucket:  aws.String(bucket),
+			Tagging: &types.Tagging{TagSet: invalidTagSet},
+		})
+		if err == nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PutBucketTagging with %s expected to fail but succeeded", name), nil).Fatal()
+			return
+		}
+		// Servers disagree on the error code, InvalidTag, BadRequest or
+		// MalformedXML, but all of them are client errors
+		if statusCode(err) != http.StatusBadRequest {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PutBucketTagging with %s expected 400 Bad Request but got %v", name, err), err).Fatal()
+			return
+		}
+	}
+
+	// A rejected tag set leaves the tags in place
+	output, err = client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetBucketTagging failed", err).Fatal()
+		return
+	}
+	if len(output.TagSet) != maxBucketTags {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetBucketTagging after rejected updates expected %d tags but got %d", maxBucketTags, len(output.TagSet)), nil).Fatal()
+		return
+	}
+
+	_, err = client.DeleteBucketTagging(ctx, &s3.DeleteBucketTaggingInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucketTagging failed", err).Fatal()
+		return
+	}
+
+	_, err = client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{
+		Bucket: aws.String(bucket),
+	})
+	if errorCode(err) != "NoSuchTagSet" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetBucketTagging after DeleteBucketTagging expected NoSuchTagSet but got %v", err), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
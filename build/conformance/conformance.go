@@ -0,0 +1,412 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// errorCase is a raw request expected to fail with a standard S3 error
+type errorCase struct {
+	name   string
+	method string
+	path   string // bucket and object of the request, escaped
+	query  url.Values
+	header map[string]string
+	body   []byte
+	// signs the request, with the credentials of the server when nil
+	sign func(req *http.Request, body []byte) error
+
+	status int
+	code   string
+	bucket string // BucketName of the error, checked when set
+	key    string // Key of the error, checked when set
+}
+
+// signWith returns a function signing requests with the given credentials
+func signWith(creds *credentials.Credentials) func(req *http.Request, body []byte) error {
+	return func(req *http.Request, body []byte) error {
+		var reader io.ReadSeeker
+		if len(body) > 0 {
+			reader = bytes.NewReader(body)
+		}
+		_, err := v4.NewSigner(creds).Sign(req, reader, "s3", config.Region, time.Now())
+		return err
+	}
+}
+
+// anonymous leaves requests unsigned
+func anonymous(req *http.Request, body []byte) error {
+	return nil
+}
+
+// contentMD5 returns the Content-MD5 header of body
+func contentMD5(body []byte) string {
+	sum := md5.Sum(body)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// do sends the request of the case and returns its response along with
+// its body
+func (c errorCase) do() (*http.Response, []byte, error) {
+	u := config.URL() + "/" + c.path
+	if len(c.query) > 0 {
+		// Subresources without a value must not be followed by '='
+		u += "?" + strings.ReplaceAll(c.query.Encode(), "=&", "&")
+		u = strings.TrimSuffix(u, "=")
+	}
+	req, err := http.NewRequest(c.method, u, bytes.NewReader(c.body))
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range c.header {
+		req.Header.Set(k, v)
+	}
+	sign := c.sign
+	if sign == nil {
+		sign = signWith(s3Client.Config.Credentials)
+	}
+	if err = sign(req, c.body); err != nil {
+		return nil, nil, err
+	}
+
+	// Same HTTP client as the SDK, for the request to be traced as well
+	resp, err := s3Client.Config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	return resp, data, err
+}
+
+// checkErrorResponse checks the response to the request of c has the
+// expected status, the x-amz-request-id header and an XML body of the
+// expected error, naming the request ID of the header.
+func (c errorCase) checkErrorResponse(resp *http.Response, data []byte) error {
+	if resp.StatusCode != c.status {
+		return fmt.Errorf("expected status %d but got %d: %s", c.status, resp.StatusCode, data)
+	}
+	requestID := resp.Header.Get("X-Amz-Request-Id")
+	if requestID == "" {
+		return errors.New("x-amz-request-id header is missing")
+	}
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/xml" {
+		return fmt.Errorf("expected Content-Type application/xml but got %q", resp.Header.Get("Content-Type"))
+	}
+
+	errResp := mintest.ErrorResponse{}
+	if err = xml.Unmarshal(data, &errResp); err != nil {
+		return fmt.Errorf("expected an Error XML document but got %q: %v", data, err)
+	}
+	switch {
+	case errResp.Code != c.code:
+		return fmt.Errorf("expected Code %s but got %s", c.code, errResp.Code)
+	case errResp.Message == "":
+		return errors.New("Message is empty")
+	case errResp.RequestID != requestID:
+		return fmt.Errorf("expected RequestId %s of the x-amz-request-id header but got %s", requestID, errResp.RequestID)
+	case c.bucket != "" && errResp.BucketName != c.bucket:
+		return fmt.Errorf("expected BucketName %s but got %s", c.bucket, errResp.BucketName)
+	case c.key != "" && errResp.Key != c.key:
+		return fmt.Errorf("expected Key %s but got %s", c.key, errResp.Key)
+	}
+	return nil
+}
+
+// runErrorCases sends the request of each case and checks its error
+// response. It returns false once a case failed.
+func runErrorCases(function string, args map[string]interface{}, startTime time.Time, cases []errorCase) bool {
+	for _, c := range cases {
+		args["case"] = c.name
+		resp, data, err := c.do()
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("%s request failed", c.name), err).Fatal()
+			return false
+		}
+		if err = c.checkErrorResponse(resp, data); err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("%s expected a conforming %s error response", c.name, c.code), err).Fatal()
+			return false
+		}
+	}
+	delete(args, "case")
+	return true
+}
+
+// createBucket creates a bucket holding an object with the given content
+func createBucket(function string, args map[string]interface{}, startTime time.Time, object, content string) (string, bool) {
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	args["bucketName"] = bucket
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return "", false
+	}
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader(content)),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		cleanupBucket(bucket, function, args, startTime)
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return "", false
+	}
+	return bucket, true
+}
+
+// Trigger bucket level errors: a request on a bucket which does not exist,
+// the deletion of a bucket which is not empty, the creation of a bucket
+// with an invalid name and a malformed tagging configuration.
+func testBucketErrors() {
+	startTime := time.Now()
+	function := "testBucketErrors"
+	object := "object"
+	args := map[string]interface{}{
+		"objectName": object,
+	}
+
+	bucket, ok := createBucket(function, args, startTime, object, "content")
+	if !ok {
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+	missingBucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	malformed := []byte("<Tagging><TagSet><Tag>")
+
+	if !runErrorCases(function, args, startTime, []errorCase{
+		{
+			name: "ListObjects on a missing bucket", method: http.MethodGet, path: missingBucket + "/",
+			status: http.StatusNotFound, code: "NoSuchBucket", bucket: missingBucket,
+		},
+		{
+			name: "DeleteBucket on a bucket holding an object", method: http.MethodDelete, path: bucket,
+			status: http.StatusConflict, code: "BucketNotEmpty", bucket: bucket,
+		},
+		{
+			name: "CreateBucket with an invalid name", method: http.MethodPut, path: "ab",
+			status: http.StatusBadRequest, code: "InvalidBucketName",
+		},
+		{
+			name: "PutBucketTagging with malformed XML", method: http.MethodPut, path: bucket,
+			query: url.Values{"tagging": {""}}, header: map[string]string{"Content-MD5": contentMD5(malformed)}, body: malformed,
+			status: http.StatusBadRequest, code: "MalformedXML",
+		},
+	}) {
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Trigger object level errors: a GET of an object which does not exist, a
+// range starting past the end of an object, a PUT not matching its
+// Content-MD5 and a malformed multi-object delete.
+func testObjectErrors() {
+	startTime := time.Now()
+	function := "testObjectErrors"
+	object := "object"
+	content := "0123456789"
+	args := map[string]interface{}{
+		"objectName": object,
+	}
+
+	bucket, ok := createBucket(function, args, startTime, object, content)
+	if !ok {
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+	malformed := []byte("<Delete><Object><Key>")
+
+	if !runErrorCases(function, args, startTime, []errorCase{
+		{
+			name: "GetObject on a missing object", method: http.MethodGet, path: bucket + "/missing-object",
+			status: http.StatusNotFound, code: "NoSuchKey", key: "missing-object",
+		},
+		{
+			name: "GetObject with a range past the end", method: http.MethodGet, path: bucket + "/" + object,
+			header: map[string]string{"Range": fmt.Sprintf("bytes=%d-%d", 10*len(content), 20*len(content))},
+			status: http.StatusRequestedRangeNotSatisfiable, code: "InvalidRange",
+		},
+		{
+			name: "PutObject not matching its Content-MD5", method: http.MethodPut, path: bucket + "/bad-digest",
+			header: map[string]string{"Content-MD5": contentMD5([]byte("other content"))}, body: []byte(content),
+			status: http.StatusBadRequest, code: "BadDigest",
+		},
+		{
+			name: "DeleteObjects with malformed XML", method: http.MethodPost, path: bucket,
+			query: url.Values{"delete": {""}}, header: map[string]string{"Content-MD5": contentMD5(malformed)}, body: malformed,
+			status: http.StatusBadRequest, code: "MalformedXML",
+		},
+	}) {
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// completeBody returns a CompleteMultipartUpload document listing the
+// parts in the given order
+func completeBody(parts ...*s3.CompletedPart) []byte {
+	var body bytes.Buffer
+	body.WriteString("<CompleteMultipartUpload>")
+	for _, part := range parts {
+		fmt.Fprintf(&body, "<Part><PartNumber>%d</PartNumber><ETag>%s</ETag></Part>", aws.Int64Value(part.PartNumber), aws.StringValue(part.ETag))
+	}
+	body.WriteString("</CompleteMultipartUpload>")
+	return body.Bytes()
+}
+
+// Trigger multipart upload errors: listing the parts of an upload which
+// does not exist, and completing an upload of two parts of one byte, with
+// those parts in order, with a wrong ETag, out of order and with malformed
+// XML. The upload is left in place by each failed completion.
+func testMultipartErrors() {
+	startTime := time.Now()
+	function := "testMultipartErrors"
+	object := "object"
+	args := map[string]interface{}{
+		"objectName": object,
+	}
+
+	bucket, ok := createBucket(function, args, startTime, object, "content")
+	if !ok {
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	upload, err := s3Client.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateMultipartUpload failed", err).Fatal()
+		return
+	}
+	args["uploadId"] = aws.StringValue(upload.UploadId)
+	var parts []*s3.CompletedPart
+	for partNumber := int64(1); partNumber <= 2; partNumber++ {
+		output, err := s3Client.UploadPart(&s3.UploadPartInput{
+			Body:       aws.ReadSeekCloser(strings.NewReader("x")),
+			Bucket:     aws.String(bucket),
+			Key:        aws.String(object),
+			PartNumber: aws.Int64(partNumber),
+			UploadId:   upload.UploadId,
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "UploadPart failed", err).Fatal()
+			return
+		}
+		parts = append(parts, &s3.CompletedPart{ETag: output.ETag, PartNumber: aws.Int64(partNumber)})
+	}
+	wrongETag := &s3.CompletedPart{ETag: aws.String(`"00000000000000000000000000000000"`), PartNumber: aws.Int64(1)}
+	uploadQuery := url.Values{"uploadId": {aws.StringValue(upload.UploadId)}}
+	path := bucket + "/" + object
+
+	if !runErrorCases(function, args, startTime, []errorCase{
+		{
+			name: "ListParts of a missing upload", method: http.MethodGet, path: path,
+			query:  url.Values{"uploadId": {base64.RawURLEncoding.EncodeToString([]byte("missing upload"))}},
+			status: http.StatusNotFound, code: "NoSuchUpload",
+		},
+		{
+			name: "CompleteMultipartUpload with a part smaller than 5MiB", method: http.MethodPost, path: path,
+			query: uploadQuery, body: completeBody(parts[0], parts[1]),
+			status: http.StatusBadRequest, code: "EntityTooSmall",
+		},
+		{
+			name: "CompleteMultipartUpload with a wrong ETag", method: http.MethodPost, path: path,
+			query: uploadQuery, body: completeBody(wrongETag, parts[1]),
+			status: http.StatusBadRequest, code: "InvalidPart",
+		},
+		{
+			name: "CompleteMultipartUpload with parts out of order", method: http.MethodPost, path: path,
+			query: uploadQuery, body: completeBody(parts[1], parts[0]),
+			status: http.StatusBadRequest, code: "InvalidPartOrder",
+		},
+		{
+			name: "CompleteMultipartUpload with malformed XML", method: http.MethodPost, path: path,
+			query: uploadQuery, body: []byte("<CompleteMultipartUpload><Part>"),
+			status: http.StatusBadRequest, code: "MalformedXML",
+		},
+	}) {
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Trigger authentication and authorization errors: an anonymous GET of a
+// private object, and GETs signed with an unknown access key and with a
+// wrong secret key.
+func testAuthErrors() {
+	startTime := time.Now()
+	function := "testAuthErrors"
+	object := "object"
+	args := map[string]interface{}{
+		"objectName": object,
+	}
+
+	bucket, ok := createBucket(function, args, startTime, object, "content")
+	if !ok {
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+	path := bucket + "/" + object
+
+	if !runErrorCases(function, args, startTime, []errorCase{
+		{
+			name: "anonymous GetObject of a private object", method: http.MethodGet, path: path, sign: anonymous,
+			status: http.StatusForbidden, code: "AccessDenied",
+		},
+		{
+			name: "GetObject signed with an unknown access key", method: http.MethodGet, path: path,
+			sign:   signWith(credentials.NewStaticCredentials("unknown-access-key", config.SecretKey, "")),
+			status: http.StatusForbidden, code: "InvalidAccessKeyId",
+		},
+		{
+			name: "GetObject signed with a wrong secret key", method: http.MethodGet, path: path,
+			sign:   signWith(credentials.NewStaticCredentials(config.AccessKey, config.SecretKey+"wrong", "")),
+			status: http.StatusForbidden, code: "SignatureDoesNotMatch",
+		},
+	}) {
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,58 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Prefix of the names of all the buckets created by this suite
+const bucketPrefix = "conformance-test-"
+
+// S3 client for testing, setting up what the raw requests fail on
+var s3Client *s3.S3
+
+// Server under test, which the raw requests are sent to
+var config mintest.Config
+
+func cleanupBucket(bucket string, function string, args map[string]interface{}, startTime time.Time) {
+	if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucket failed", err).Fatal()
+	}
+}
+
+func main() {
+	runStartTime := time.Now()
+	mintest.Init("conformance")
+	config = mintest.LoadConfig()
+
+	// Create an S3 service object in the default region.
+	s3Client = config.NewS3Client()
+	mintest.TrackBuckets(s3Client)
+	// Remove the buckets left behind, even by a failed test
+	defer mintest.StartJanitor(s3Client)()
+
+	mintest.RunTests([]mintest.Test{
+		{Name: "testBucketErrors", Description: "Check the error responses of NoSuchBucket, BucketNotEmpty, InvalidBucketName and MalformedXML", Run: testBucketErrors},
+		{Name: "testObjectErrors", Description: "Check the error responses of NoSuchKey, InvalidRange, BadDigest and MalformedXML", Run: testObjectErrors},
+		{Name: "testMultipartErrors", Description: "Check the error responses of NoSuchUpload, EntityTooSmall, InvalidPart, InvalidPartOrder and MalformedXML", Run: testMultipartErrors},
+		{Name: "testAuthErrors", Description: "Check the error responses of AccessDenied, InvalidAccessKeyId and SignatureDoesNotMatch", Run: testAuthErrors},
+	}, nil)
+	mintest.TestResourceLeaks(s3Client, bucketPrefix, runStartTime)
+}
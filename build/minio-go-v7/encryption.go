@@ -0,0 +1,223 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	crand "crypto/rand"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"mint.minio.io/mintest"
+)
+
+// sseHeader is the response header telling how an object is encrypted
+const sseHeader = "X-Amz-Server-Side-Encryption"
+
+// newSSEC returns SSE-C encryption with a random key
+func newSSEC() (encrypt.ServerSide, error) {
+	key := make([]byte, 32)
+	if _, err := crand.Read(key); err != nil {
+		return nil, err
+	}
+	return encrypt.NewSSEC(key)
+}
+
+// Upload objects encrypted with an SSE-C key, which can only be read and
+// stated with that key, then copy one to another key and compose them
+// into an object encrypted with a third key. minio-go v7 has no client-side
+// encryption, the encrypt package only supports server-side encryption.
+func testSSEC() {
+	startTime := time.Now()
+	function := "testSSEC"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	size := int64(minPartSize)
+	seed := mintest.DataSeed()
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"seed":       seed,
+	}
+
+	var keys [3]encrypt.ServerSide
+	for i := range keys {
+		var err error
+		if keys[i], err = newSSEC(); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Creating an SSE-C key failed", err).Fatal()
+			return
+		}
+	}
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err := client.PutObject(context.Background(), bucket, object, mintest.NewDataReader(seed, size), size, minio.PutObjectOptions{
+		ServerSideEncryption: keys[0],
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject with SSE-C failed", err).Fatal()
+		return
+	}
+
+	for _, key := range []struct {
+		name string
+		sse  encrypt.ServerSide
+	}{
+		{"no key", nil},
+		{"another key", keys[1]},
+	} {
+		_, err = client.StatObject(context.Background(), bucket, object, minio.StatObjectOptions{ServerSideEncryption: key.sse})
+		if err == nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("StatObject of an SSE-C object with %s expected to fail", key.name), nil).Fatal()
+			return
+		}
+	}
+	stat, err := client.StatObject(context.Background(), bucket, object, minio.StatObjectOptions{ServerSideEncryption: keys[0]})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "StatObject with the SSE-C key failed", err).Fatal()
+		return
+	}
+	if stat.Size != size {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("StatObject with the SSE-C key expected size %d but got %d", size, stat.Size), nil).Fatal()
+		return
+	}
+	sum, err := objectSHA256(bucket, object, minio.GetObjectOptions{ServerSideEncryption: keys[0]})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject with the SSE-C key failed", err).Fatal()
+		return
+	}
+	if expected := mintest.DataSHA256(seed, size); sum != expected {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject with the SSE-C key expected content with SHA256 %s but got %s", expected, sum), nil).Fatal()
+		return
+	}
+
+	copyObject := "testObjectCopy"
+	_, err = client.CopyObject(context.Background(),
+		minio.CopyDestOptions{Bucket: bucket, Object: copyObject, Encryption: keys[1]},
+		minio.CopySrcOptions{Bucket: bucket, Object: object, Encryption: keys[0]})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CopyObject to another SSE-C key failed", err).Fatal()
+		return
+	}
+	sum, err = objectSHA256(bucket, copyObject, minio.GetObjectOptions{ServerSideEncryption: keys[1]})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject of the copy with its SSE-C key failed", err).Fatal()
+		return
+	}
+	if expected := mintest.DataSHA256(seed, size); sum != expected {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject of the copy expected content with SHA256 %s but got %s", expected, sum), nil).Fatal()
+		return
+	}
+
+	composed := "testObjectComposed"
+	_, err = client.ComposeObject(context.Background(),
+		minio.CopyDestOptions{Bucket: bucket, Object: composed, Encryption: keys[2]},
+		minio.CopySrcOptions{Bucket: bucket, Object: object, Encryption: keys[0]},
+		minio.CopySrcOptions{Bucket: bucket, Object: copyObject, Encryption: keys[1]})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ComposeObject of SSE-C objects failed", err).Fatal()
+		return
+	}
+	stat, err = client.StatObject(context.Background(), bucket, composed, minio.StatObjectOptions{ServerSideEncryption: keys[2]})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "StatObject of the composed object with its SSE-C key failed", err).Fatal()
+		return
+	}
+	if stat.Size != 2*size {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("StatObject of the composed object expected size %d but got %d", 2*size, stat.Size), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Upload an object encrypted with SSE-S3, which must be reported as such
+// and read back transparently, then copy it with and without encryption.
+func testSSES3() {
+	startTime := time.Now()
+	function := "testSSES3"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	size := int64(1024 * 1024)
+	seed := mintest.DataSeed()
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"seed":       seed,
+	}
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err := client.PutObject(context.Background(), bucket, object, mintest.NewDataReader(seed, size), size, minio.PutObjectOptions{
+		ServerSideEncryption: encrypt.NewSSE(),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject with SSE-S3 failed", err).Fatal()
+		return
+	}
+
+	for _, target := range []struct {
+		object     string
+		encryption encrypt.ServerSide
+		expected   string
+	}{
+		{object, nil, "AES256"},
+		{"testObjectEncryptedCopy", encrypt.NewSSE(), "AES256"},
+		{"testObjectPlainCopy", nil, ""},
+	} {
+		args["copyName"] = target.object
+		if target.object != object {
+			_, err = client.CopyObject(context.Background(),
+				minio.CopyDestOptions{Bucket: bucket, Object: target.object, Encryption: target.encryption},
+				minio.CopySrcOptions{Bucket: bucket, Object: object})
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", "CopyObject failed", err).Fatal()
+				return
+			}
+		}
+		stat, err := client.StatObject(context.Background(), bucket, target.object, minio.StatObjectOptions{})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "StatObject failed", err).Fatal()
+			return
+		}
+		if got := stat.Metadata.Get(sseHeader); got != target.expected {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("StatObject expected %s %q but got %q", sseHeader, target.expected, got), nil).Fatal()
+			return
+		}
+		sum, err := objectSHA256(bucket, target.object, minio.GetObjectOptions{})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "GetObject failed", err).Fatal()
+			return
+		}
+		if expected := mintest.DataSHA256(seed, size); sum != expected {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject expected content with SHA256 %s but got %s", expected, sum), nil).Fatal()
+			return
+		}
+	}
+	delete(args, "copyName")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,101 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"mint.minio.io/mintest"
+)
+
+// Prefix of the names of all the buckets created by this suite
+const bucketPrefix = "minio-go-v7-test-"
+
+// minio-go client under test
+var client *minio.Client
+
+// S3 client creating and removing the buckets of the tests, for the
+// janitor to track them
+var s3Client *s3.S3
+
+// Server under test
+var config mintest.Config
+
+// makeBucket creates a bucket for a test, to be removed with cleanupBucket
+func makeBucket(bucket string) error {
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	return err
+}
+
+func cleanupBucket(bucket string, function string, args map[string]interface{}, startTime time.Time) {
+	if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucket failed", err).Fatal()
+	}
+}
+
+func main() {
+	runStartTime := time.Now()
+	mintest.Init("minio-go-v7")
+	config = mintest.LoadConfig()
+
+	tests := []mintest.Test{
+		{Name: "testFPutFGetObject", Description: "Upload a file in parts with FPutObject and download it with FGetObject", Run: testFPutFGetObject},
+		{Name: "testPutObjectProgress", Description: "Report the progress of a multipart PutObject", Run: testPutObjectProgress},
+		{Name: "testComposeObject", Description: "Compose an object out of whole objects and ranges of objects, replacing its metadata", Run: testComposeObject},
+		{Name: "testBucketNotification", Description: "Set, get and remove a notification configuration sending to NOTIFY_ARN", Requires: []string{mintest.RequiresNotification}, Run: testBucketNotification},
+		{Name: "testListenBucketNotification", Description: "Receive the events of a bucket with ListenBucketNotification", Requires: []string{mintest.RequiresMinIO}, Run: testListenBucketNotification},
+		{Name: "testSSEC", Description: "PUT, GET, copy and compose objects encrypted with SSE-C keys", Requires: []string{mintest.RequiresHTTPS}, Run: testSSEC},
+		{Name: "testSSES3", Description: "PUT, GET and copy objects encrypted with SSE-S3", Requires: []string{mintest.RequiresKMS}, Run: testSSES3},
+	}
+	// Listed even without an endpoint to create the minio-go client for
+	mintest.ListTests(tests)
+
+	transport, err := minio.DefaultTransport(config.Secure)
+	var tlsConfig *tls.Config
+	if err == nil {
+		tlsConfig, err = config.TLSConfig()
+	}
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	if err == nil {
+		client, err = minio.New(config.Endpoint, &minio.Options{
+			Creds:     credentials.NewStaticV4(config.AccessKey, config.SecretKey, config.SessionToken),
+			Secure:    config.Secure,
+			Region:    config.Region,
+			Transport: mintest.TraceTransport(transport),
+		})
+	}
+	if err != nil {
+		mintest.FailureLog("main", map[string]interface{}{"endpoint": config.Endpoint}, time.Now(), "", "Unable to create the minio-go client", err).Fatal()
+	}
+
+	// Create an S3 service object in the default region.
+	s3Client = config.NewS3Client()
+	mintest.TrackBuckets(s3Client)
+	// Remove the buckets left behind, even by a failed test
+	defer mintest.StartJanitor(s3Client)()
+
+	mintest.RunTests(tests, mintest.NewCapabilities(config, s3Client).Supports)
+	mintest.TestResourceLeaks(s3Client, bucketPrefix, runStartTime)
+}
@@ -0,0 +1,183 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/notification"
+	"mint.minio.io/mintest"
+)
+
+// Set a notification configuration sending the creation of objects under
+// a prefix and with a suffix to NOTIFY_ARN, get it back, then remove it.
+func testBucketNotification() {
+	startTime := time.Now()
+	function := "testBucketNotification"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"arn":        os.Getenv("NOTIFY_ARN"),
+	}
+
+	arn, err := notification.NewArnFromString(os.Getenv("NOTIFY_ARN"))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Invalid NOTIFY_ARN", err).Fatal()
+		return
+	}
+	if err = makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	target := notification.NewConfig(arn)
+	target.AddEvents(notification.ObjectCreatedAll)
+	target.AddFilterPrefix("photos/")
+	target.AddFilterSuffix(".jpg")
+	config := notification.Configuration{}
+	// The kind of target is told by the service of the ARN
+	switch arn.Service {
+	case "sns":
+		config.AddTopic(target)
+	case "lambda":
+		config.AddLambda(target)
+	default:
+		config.AddQueue(target)
+	}
+	if err = client.SetBucketNotification(context.Background(), bucket, config); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "SetBucketNotification failed", err).Fatal()
+		return
+	}
+
+	got, err := client.GetBucketNotification(context.Background(), bucket)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetBucketNotification failed", err).Fatal()
+		return
+	}
+	var targets []notification.Config
+	for _, c := range got.QueueConfigs {
+		targets = append(targets, c.Config)
+	}
+	for _, c := range got.TopicConfigs {
+		targets = append(targets, c.Config)
+	}
+	for _, c := range got.LambdaConfigs {
+		targets = append(targets, c.Config)
+	}
+	if len(targets) != 1 || !targets[0].Equal(target.Events, "photos/", ".jpg") {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetBucketNotification expected the configuration set but got %+v", got), nil).Fatal()
+		return
+	}
+
+	if err = client.RemoveAllBucketNotification(context.Background(), bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "RemoveAllBucketNotification failed", err).Fatal()
+		return
+	}
+	got, err = client.GetBucketNotification(context.Background(), bucket)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetBucketNotification failed", err).Fatal()
+		return
+	}
+	if len(got.QueueConfigs)+len(got.TopicConfigs)+len(got.LambdaConfigs) != 0 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetBucketNotification expected no configuration once removed but got %+v", got), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Listen to the creation of objects in a bucket with the MinIO listen API,
+// and PUT an object until its event is received. minio-go does not tell
+// when the listening starts, so the object is written again every second.
+func testListenBucketNotification() {
+	startTime := time.Now()
+	function := "testListenBucketNotification"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	content := "listen to me"
+	timeout := 30 * time.Second
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"timeout":    timeout,
+	}
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	events := client.ListenBucketNotification(ctx, bucket, "", "", []string{string(notification.ObjectCreatedAll)})
+
+	put := func() error {
+		_, err := client.PutObject(ctx, bucket, object, strings.NewReader(content), int64(len(content)), minio.PutObjectOptions{})
+		return err
+	}
+	if err := put(); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case info, ok := <-events:
+			if !ok {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListenBucketNotification received no event in %v", timeout), nil).Fatal()
+				return
+			}
+			if info.Err != nil {
+				if code := minio.ToErrorResponse(info.Err).Code; code == "NotImplemented" || code == "MethodNotAllowed" {
+					mintest.IgnoreLog(function, args, startTime, "ListenBucketNotification is not supported").Info()
+					return
+				}
+				mintest.FailureLog(function, args, startTime, "", "ListenBucketNotification failed", info.Err).Fatal()
+				return
+			}
+			for _, record := range info.Records {
+				key, _ := url.QueryUnescape(record.S3.Object.Key)
+				if record.EventName != string(notification.ObjectCreatedPut) || record.S3.Bucket.Name != bucket || key != object {
+					mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListenBucketNotification expected a PUT event of %s but got %+v", object, record), nil).Fatal()
+					return
+				}
+				if record.S3.Object.Size != int64(len(content)) {
+					mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListenBucketNotification expected an object of %d bytes but got %d", len(content), record.S3.Object.Size), nil).Fatal()
+					return
+				}
+			}
+			if len(info.Records) > 0 {
+				mintest.SuccessLogger(function, args, startTime).Info()
+				return
+			}
+		case <-ticker.C:
+			if err := put(); err != nil {
+				mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,339 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"mint.minio.io/mintest"
+)
+
+// Smallest size of the parts of a multipart upload, but the last one
+const minPartSize = 5 * 1024 * 1024
+
+// readerSHA256 returns the hex encoded SHA256 of what is read from r
+func readerSHA256(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// fileSHA256 returns the hex encoded SHA256 of the file at path
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return readerSHA256(f)
+}
+
+// objectSHA256 returns the hex encoded SHA256 of the content of an object
+func objectSHA256(bucket, object string, opts minio.GetObjectOptions) (string, error) {
+	obj, err := client.GetObject(context.Background(), bucket, object, opts)
+	if err != nil {
+		return "", err
+	}
+	defer obj.Close()
+	return readerSHA256(obj)
+}
+
+// progressCounter counts the bytes reported by minio-go as uploaded
+type progressCounter struct {
+	n int64
+}
+
+func (p *progressCounter) Read(b []byte) (int, error) {
+	atomic.AddInt64(&p.n, int64(len(b)))
+	return len(b), nil
+}
+
+// Write a file larger than a part, upload it in parts with FPutObject and
+// a content type, then download it with FGetObject. The downloaded file
+// must match the uploaded one, and StatObject must report the size and
+// content type of the object.
+func testFPutFGetObject() {
+	startTime := time.Now()
+	function := "testFPutFGetObject"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	size := int64(2*minPartSize + 1024*1024)
+	contentType := "application/x-mint"
+	seed := mintest.DataSeed()
+	args := map[string]interface{}{
+		"bucketName":  bucket,
+		"objectName":  object,
+		"size":        size,
+		"contentType": contentType,
+		"seed":        seed,
+	}
+
+	stop := mintest.StartTiming("createBucket")
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	stop()
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	dir, err := os.MkdirTemp("", "mint-minio-go-v7-")
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Creating a temporary directory failed", err).Fatal()
+		return
+	}
+	defer os.RemoveAll(dir)
+	uploadPath := filepath.Join(dir, "upload")
+	downloadPath := filepath.Join(dir, "download")
+
+	f, err := os.Create(uploadPath)
+	if err == nil {
+		_, err = io.Copy(f, mintest.NewDataReader(seed, size))
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Writing the file to upload failed", err).Fatal()
+		return
+	}
+
+	stop = mintest.StartTiming("fPutObject")
+	info, err := client.FPutObject(context.Background(), bucket, object, uploadPath, minio.PutObjectOptions{
+		ContentType: contentType,
+		PartSize:    minPartSize,
+	})
+	stop()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "FPutObject failed", err).Fatal()
+		return
+	}
+	if info.Size != size {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("FPutObject expected to upload %d bytes but uploaded %d", size, info.Size), nil).Fatal()
+		return
+	}
+
+	stop = mintest.StartTiming("statObject")
+	stat, err := client.StatObject(context.Background(), bucket, object, minio.StatObjectOptions{})
+	stop()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "StatObject failed", err).Fatal()
+		return
+	}
+	if stat.Size != size || stat.ContentType != contentType {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("StatObject expected size %d and content type %s but got %d and %s", size, contentType, stat.Size, stat.ContentType), nil).Fatal()
+		return
+	}
+
+	stop = mintest.StartTiming("fGetObject")
+	err = client.FGetObject(context.Background(), bucket, object, downloadPath, minio.GetObjectOptions{})
+	stop()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "FGetObject failed", err).Fatal()
+		return
+	}
+	sum, err := fileSHA256(downloadPath)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Reading the downloaded file failed", err).Fatal()
+		return
+	}
+	if expected := mintest.DataSHA256(seed, size); sum != expected {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("FGetObject expected a file with SHA256 %s but got %s", expected, sum), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Upload objects in a single part and in several parts along with a
+// progress reader, which must be told about every byte of the objects.
+func testPutObjectProgress() {
+	startTime := time.Now()
+	function := "testPutObjectProgress"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	seed := mintest.DataSeed()
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"seed":       seed,
+	}
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	for _, size := range []int64{1024 * 1024, 2*minPartSize + 1024*1024} {
+		object := fmt.Sprintf("object-%d", size)
+		args["objectName"] = object
+		args["size"] = size
+		progress := &progressCounter{}
+		info, err := client.PutObject(context.Background(), bucket, object, mintest.NewDataReader(seed, size), size, minio.PutObjectOptions{
+			PartSize: minPartSize,
+			Progress: progress,
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+			return
+		}
+		if info.Size != size {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PutObject expected to upload %d bytes but uploaded %d", size, info.Size), nil).Fatal()
+			return
+		}
+		if reported := atomic.LoadInt64(&progress.n); reported != size {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PutObject expected to report the progress of %d bytes but reported %d", size, reported), nil).Fatal()
+			return
+		}
+		sum, err := objectSHA256(bucket, object, minio.GetObjectOptions{})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "GetObject failed", err).Fatal()
+			return
+		}
+		if expected := mintest.DataSHA256(seed, size); sum != expected {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject expected content with SHA256 %s but got %s", expected, sum), nil).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// composeSource is a source object of a composition, generated from seed,
+// of which the bytes from start to end are taken when ranged.
+type composeSource struct {
+	object     string
+	seed       uint64
+	size       int64
+	ranged     bool
+	start, end int64
+}
+
+// reader returns the content the source adds to the composed object
+func (s composeSource) reader() io.Reader {
+	r := mintest.NewDataReader(s.seed, s.size)
+	if !s.ranged {
+		return r
+	}
+	r.Seek(s.start, io.SeekStart)
+	return io.LimitReader(r, s.end-s.start+1)
+}
+
+// Compose an object out of a whole object, a range of another one and a
+// last smaller object, replacing the metadata of the sources. The object
+// must hold the concatenation of the parts in order, with the new
+// metadata.
+func testComposeObject() {
+	startTime := time.Now()
+	function := "testComposeObject"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "composed"
+	seed := mintest.DataSeed()
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"seed":       seed,
+	}
+
+	if err := makeBucket(bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	sources := []composeSource{
+		{object: "source-1", seed: seed, size: minPartSize},
+		{object: "source-2", seed: seed + 1, size: minPartSize + 2*1024*1024, ranged: true, start: 1024 * 1024, end: minPartSize + 1024*1024 - 1},
+		{object: "source-3", seed: seed + 2, size: 1024 * 1024},
+	}
+	var srcs []minio.CopySrcOptions
+	var readers []io.Reader
+	var size int64
+	for _, source := range sources {
+		_, err := client.PutObject(context.Background(), bucket, source.object, mintest.NewDataReader(source.seed, source.size), source.size, minio.PutObjectOptions{
+			UserMetadata: map[string]string{"Source": source.object},
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+			return
+		}
+		srcs = append(srcs, minio.CopySrcOptions{
+			Bucket:     bucket,
+			Object:     source.object,
+			MatchRange: source.ranged,
+			Start:      source.start,
+			End:        source.end,
+		})
+		readers = append(readers, source.reader())
+		if source.ranged {
+			size += source.end - source.start + 1
+		} else {
+			size += source.size
+		}
+	}
+
+	info, err := client.ComposeObject(context.Background(), minio.CopyDestOptions{
+		Bucket:          bucket,
+		Object:          object,
+		UserMetadata:    map[string]string{"Composed": "true"},
+		ReplaceMetadata: true,
+	}, srcs...)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ComposeObject failed", err).Fatal()
+		return
+	}
+	if info.Size != size {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ComposeObject expected an object of %d bytes but got %d", size, info.Size), nil).Fatal()
+		return
+	}
+
+	stat, err := client.StatObject(context.Background(), bucket, object, minio.StatObjectOptions{})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "StatObject failed", err).Fatal()
+		return
+	}
+	if stat.Size != size || stat.UserMetadata["Composed"] != "true" || stat.UserMetadata["Source"] != "" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("StatObject expected size %d and only the composed object metadata but got %d and %v", size, stat.Size, stat.UserMetadata), nil).Fatal()
+		return
+	}
+
+	expected, err := readerSHA256(io.MultiReader(readers...))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Generating the expected content failed", err).Fatal()
+		return
+	}
+	sum, err := objectSHA256(bucket, object, minio.GetObjectOptions{})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject failed", err).Fatal()
+		return
+	}
+	if sum != expected {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject of the composed object expected content with SHA256 %s but got %s", expected, sum), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
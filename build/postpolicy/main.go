@@ -0,0 +1,61 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Prefix of the names of all the buckets created by this suite
+const bucketPrefix = "postpolicy-test-"
+
+// S3 client for testing
+var s3Client *s3.S3
+
+// Server under test, whose credentials and region sign POST policies
+var config mintest.Config
+
+func cleanupBucket(bucket string, function string, args map[string]interface{}, startTime time.Time) {
+	if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucket failed", err).Fatal()
+	}
+}
+
+func main() {
+	runStartTime := time.Now()
+	mintest.Init("postpolicy")
+	config = mintest.LoadConfig()
+
+	// Create an S3 service object in the default region.
+	s3Client = config.NewS3Client()
+	mintest.TrackBuckets(s3Client)
+	// Remove the buckets left behind, even by a failed test
+	defer mintest.StartJanitor(s3Client)()
+
+	mintest.RunTests([]mintest.Test{
+		{Name: "testPostPolicyUpload", Description: "Upload an object with a POST policy and read it back", Run: testPostPolicyUpload},
+		{Name: "testPostPolicySuccessActionStatus", Description: "Check the response of every success_action_status value", Run: testPostPolicySuccessActionStatus},
+		{Name: "testPostPolicySuccessActionRedirect", Description: "Check success_action_redirect redirects with the bucket, key and ETag", Run: testPostPolicySuccessActionRedirect},
+		{Name: "testPostPolicyContentLengthRange", Description: "Check content-length-range is enforced on both ends", Run: testPostPolicyContentLengthRange},
+		{Name: "testPostPolicyExpired", Description: "Check an expired policy is rejected", Run: testPostPolicyExpired},
+		{Name: "testPostPolicyInvalidSignature", Description: "Check a policy with a tampered signature is rejected", Run: testPostPolicyInvalidSignature},
+		{Name: "testPostPolicyConditionMismatch", Description: "Check a form field not satisfying a policy condition is rejected", Run: testPostPolicyConditionMismatch},
+	}, nil)
+	mintest.TestResourceLeaks(s3Client, bucketPrefix, runStartTime)
+}
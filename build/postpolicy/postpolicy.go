@@ -0,0 +1,589 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+const (
+	signV4Algorithm  = "AWS4-HMAC-SHA256"
+	iso8601Format    = "20060102T150405Z"
+	yyyymmdd         = "20060102"
+	expirationFormat = "2006-01-02T15:04:05.000Z"
+)
+
+// postPolicy is a browser based upload form, signed with AWS
+// Signature Version 4 as described in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-HTTPPOSTConstructPolicy.html
+type postPolicy struct {
+	bucket     string
+	key        string
+	expiration time.Time
+	// Additional policy conditions, e.g. content-length-range
+	conditions []interface{}
+	// Additional form fields, each one is also added
+	// to the policy as an exact match condition.
+	fields map[string]string
+}
+
+func newPostPolicy(bucket, key string) *postPolicy {
+	return &postPolicy{
+		bucket:     bucket,
+		key:        key,
+		expiration: time.Now().UTC().Add(time.Hour),
+		fields:     make(map[string]string),
+	}
+}
+
+// hmacSHA256 computes the HMAC-SHA256 of data with key
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// formFields returns all the form fields of the policy including
+// the base64 encoded policy document and its signature.
+func (p *postPolicy) formFields(signTime time.Time) (map[string]string, error) {
+	signTime = signTime.UTC()
+	scope := strings.Join([]string{signTime.Format(yyyymmdd), config.Region, "s3", "aws4_request"}, "/")
+	credential := config.AccessKey + "/" + scope
+	amzDate := signTime.Format(iso8601Format)
+
+	fields := map[string]string{
+		"key":              p.key,
+		"x-amz-algorithm":  signV4Algorithm,
+		"x-amz-credential": credential,
+		"x-amz-date":       amzDate,
+	}
+	// Temporary credentials are only valid along with their session token
+	if config.SessionToken != "" {
+		fields["x-amz-security-token"] = config.SessionToken
+	}
+	for k, v := range p.fields {
+		fields[k] = v
+	}
+
+	conditions := []interface{}{
+		[]string{"eq", "$bucket", p.bucket},
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		conditions = append(conditions, []string{"eq", "$" + k, fields[k]})
+	}
+	conditions = append(conditions, p.conditions...)
+
+	policy, err := json.Marshal(map[string]interface{}{
+		"expiration": p.expiration.UTC().Format(expirationFormat),
+		"conditions": conditions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	encodedPolicy := base64.StdEncoding.EncodeToString(policy)
+
+	signingKey := hmacSHA256([]byte("AWS4"+config.SecretKey), signTime.Format(yyyymmdd))
+	signingKey = hmacSHA256(signingKey, config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	fields["policy"] = encodedPolicy
+	fields["x-amz-signature"] = hex.EncodeToString(hmacSHA256(signingKey, encodedPolicy))
+	return fields, nil
+}
+
+// postObject uploads content to bucket through a multipart/form-data
+// POST request built out of fields. The file field is always the last one.
+func postObject(client *http.Client, bucket string, fields map[string]string, content []byte) (*http.Response, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := w.WriteField(k, fields[k]); err != nil {
+			return nil, err
+		}
+	}
+	fw, err := w.CreateFormFile("file", "upload")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = fw.Write(content); err != nil {
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, config.URL()+"/"+bucket, &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return client.Do(req)
+}
+
+// checkPostError verifies that the response of a POST request carries
+// the expected HTTP status code and S3 error code
+func checkPostError(resp *http.Response, statusCode int, code string) error {
+	if resp.StatusCode != statusCode {
+		return fmt.Errorf("expected status %d, got %s", statusCode, resp.Status)
+	}
+	errResp := mintest.ErrorResponse{}
+	if err := xml.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		return err
+	}
+	if errResp.Code != code {
+		return fmt.Errorf("expected error code %s, got %s", code, errResp.Code)
+	}
+	return nil
+}
+
+// checkObjectContent reads back the uploaded object and compares it with content
+func checkObjectContent(bucket, object string, content []byte) error {
+	result, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		return err
+	}
+	defer result.Body.Close()
+	body, err := ioutil.ReadAll(result.Body)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(body, content) {
+		return errors.New("unexpected object content")
+	}
+	return nil
+}
+
+// Upload an object with a POST policy and read it back
+func testPostPolicyUpload() {
+	startTime := time.Now()
+	function := "testPostPolicyUpload"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	content := []byte("my post policy content")
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	fields, err := newPostPolicy(bucket, object).formFields(time.Now())
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Building POST policy failed", err).Fatal()
+		return
+	}
+	resp, err := postObject(s3Client.Config.HTTPClient, bucket, fields, content)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "POST object request failed", err).Fatal()
+		return
+	}
+	defer resp.Body.Close()
+
+	// Without success_action_status the server answers with 204 No Content
+	if resp.StatusCode != http.StatusNoContent {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("POST object expected to return 204 but got %s", resp.Status), nil).Fatal()
+		return
+	}
+
+	if err = checkObjectContent(bucket, object, content); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject after POST object failed", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Check responses for every allowed success_action_status value
+func testPostPolicySuccessActionStatus() {
+	startTime := time.Now()
+	function := "testPostPolicySuccessActionStatus"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	type postResponse struct {
+		XMLName  xml.Name `xml:"PostResponse"`
+		Location string
+		Bucket   string
+		Key      string
+		ETag     string
+	}
+
+	for i, status := range []int{http.StatusOK, http.StatusCreated, http.StatusNoContent} {
+		policy := newPostPolicy(bucket, object)
+		policy.fields["success_action_status"] = fmt.Sprint(status)
+		fields, err := policy.formFields(time.Now())
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Building POST policy failed", err).Fatal()
+			return
+		}
+		resp, err := postObject(s3Client.Config.HTTPClient, bucket, fields, []byte("content"))
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("POST object (%d) request failed", i+1), err).Fatal()
+			return
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("POST object (%d) reading response failed", i+1), err).Fatal()
+			return
+		}
+		if resp.StatusCode != status {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("POST object (%d) expected status %d but got %s", i+1, status, resp.Status), nil).Fatal()
+			return
+		}
+		if status != http.StatusCreated {
+			continue
+		}
+
+		// 201 Created carries an XML document describing the new object
+		var result postResponse
+		if err = xml.Unmarshal(body, &result); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "POST object returned an invalid PostResponse", err).Fatal()
+			return
+		}
+		if result.Bucket != bucket || result.Key != object || result.ETag == "" || result.Location == "" {
+			mintest.FailureLog(function, args, startTime, "", "POST object returned unexpected PostResponse", fmt.Errorf("got %+v", result)).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Check that success_action_redirect redirects the client with
+// the bucket, key and etag of the uploaded object
+func testPostPolicySuccessActionRedirect() {
+	startTime := time.Now()
+	function := "testPostPolicySuccessActionRedirect"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	redirect := "http://localhost/uploaded"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"redirect":   redirect,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	policy := newPostPolicy(bucket, object)
+	policy.fields["success_action_redirect"] = redirect
+	fields, err := policy.formFields(time.Now())
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Building POST policy failed", err).Fatal()
+		return
+	}
+
+	// Do not follow the redirect, it is the response under test
+	client := &http.Client{
+		Transport: s3Client.Config.HTTPClient.Transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := postObject(client, bucket, fields, []byte("content"))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "POST object request failed", err).Fatal()
+		return
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusSeeOther {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("POST object expected to return 303 but got %s", resp.Status), nil).Fatal()
+		return
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "POST object returned an invalid Location header", err).Fatal()
+		return
+	}
+	query := location.Query()
+	if !strings.HasPrefix(location.String(), redirect) || query.Get("bucket") != bucket ||
+		query.Get("key") != object || query.Get("etag") == "" {
+		mintest.FailureLog(function, args, startTime, "", "POST object returned unexpected Location header", fmt.Errorf("got %s", location)).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Check content-length-range enforcement on both ends of the range
+func testPostPolicyContentLengthRange() {
+	startTime := time.Now()
+	function := "testPostPolicyContentLengthRange"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"minSize":    5,
+		"maxSize":    10,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	testCases := []struct {
+		content    string
+		statusCode int
+		errCode    string
+	}{
+		{"tiny", http.StatusBadRequest, "EntityTooSmall"},
+		{"this content is too large", http.StatusBadRequest, "EntityTooLarge"},
+		{"in range", http.StatusNoContent, ""},
+	}
+
+	for i, testCase := range testCases {
+		policy := newPostPolicy(bucket, object)
+		policy.conditions = append(policy.conditions, []interface{}{"content-length-range", 5, 10})
+		fields, err := policy.formFields(time.Now())
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Building POST policy failed", err).Fatal()
+			return
+		}
+		resp, err := postObject(s3Client.Config.HTTPClient, bucket, fields, []byte(testCase.content))
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("POST object (%d) request failed", i+1), err).Fatal()
+			return
+		}
+		if testCase.errCode != "" {
+			err = checkPostError(resp, testCase.statusCode, testCase.errCode)
+		} else if resp.StatusCode != testCase.statusCode {
+			err = fmt.Errorf("expected status %d, got %s", testCase.statusCode, resp.Status)
+		}
+		resp.Body.Close()
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("POST object (%d) returned unexpected response", i+1), err).Fatal()
+			return
+		}
+	}
+
+	if err = checkObjectContent(bucket, object, []byte("in range")); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject after POST object failed", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// An expired policy must be rejected
+func testPostPolicyExpired() {
+	startTime := time.Now()
+	function := "testPostPolicyExpired"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	policy := newPostPolicy(bucket, object)
+	policy.expiration = time.Now().UTC().Add(-time.Minute)
+	fields, err := policy.formFields(time.Now())
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Building POST policy failed", err).Fatal()
+		return
+	}
+	resp, err := postObject(s3Client.Config.HTTPClient, bucket, fields, []byte("content"))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "POST object request failed", err).Fatal()
+		return
+	}
+	defer resp.Body.Close()
+
+	if err = checkPostError(resp, http.StatusForbidden, "AccessDenied"); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "POST object with expired policy returned unexpected response", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// A policy with a tampered signature must be rejected
+func testPostPolicyInvalidSignature() {
+	startTime := time.Now()
+	function := "testPostPolicyInvalidSignature"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	fields, err := newPostPolicy(bucket, object).formFields(time.Now())
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Building POST policy failed", err).Fatal()
+		return
+	}
+	// Flip the first hex digit of the signature
+	signature := []byte(fields["x-amz-signature"])
+	if signature[0] == '0' {
+		signature[0] = '1'
+	} else {
+		signature[0] = '0'
+	}
+	fields["x-amz-signature"] = string(signature)
+
+	resp, err := postObject(s3Client.Config.HTTPClient, bucket, fields, []byte("content"))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "POST object request failed", err).Fatal()
+		return
+	}
+	defer resp.Body.Close()
+
+	if err = checkPostError(resp, http.StatusForbidden, "SignatureDoesNotMatch"); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "POST object with invalid signature returned unexpected response", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// A form field which does not satisfy a policy condition must be rejected
+func testPostPolicyConditionMismatch() {
+	startTime := time.Now()
+	function := "testPostPolicyConditionMismatch"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "other/testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	policy := newPostPolicy(bucket, "uploads/testObject")
+	fields, err := policy.formFields(time.Now())
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Building POST policy failed", err).Fatal()
+		return
+	}
+	// The signed policy only allows uploads/testObject
+	fields["key"] = object
+
+	resp, err := postObject(s3Client.Config.HTTPClient, bucket, fields, []byte("content"))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "POST object request failed", err).Fatal()
+		return
+	}
+	defer resp.Body.Close()
+
+	if err = checkPostError(resp, http.StatusForbidden, "AccessDenied"); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "POST object violating policy conditions returned unexpected response", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
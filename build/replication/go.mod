@@ -0,0 +1,16 @@
+module mint.minio.io/replication/tests
+
+go 1.19
+
+require (
+	github.com/aws/aws-sdk-go v1.44.257
+	mint.minio.io/mintest v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/jmespath/go-jmespath v0.4.0 // indirect
+	github.com/sirupsen/logrus v1.9.0 // indirect
+	golang.org/x/sys v0.5.0 // indirect
+)
+
+replace mint.minio.io/mintest => ../../pkg/mintest
@@ -0,0 +1,114 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Prefix of the names of all the buckets created by this suite
+const bucketPrefix = "replication-test-"
+
+// Time given to the changes to reach the second site when
+// MINT_REPLICATION_TIMEOUT is not set
+const defaultReplicationTimeout = 5 * time.Minute
+
+// Interval between two checks of the second site
+const pollInterval = 250 * time.Millisecond
+
+// S3 clients of the site the tests write to, and of the site the changes
+// are checked on
+var s3Client, site2Client *s3.S3
+
+// Time given to the changes to reach the second site
+var replicationTimeout time.Duration
+
+var errReplicationTimeout = errors.New("change not replicated before the timeout")
+
+func cleanupBucket(bucket string, function string, args map[string]interface{}, startTime time.Time) {
+	if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucket failed", err).Fatal()
+	}
+}
+
+// waitReplicated polls the second site with replicated until it reports
+// the change is there, and returns the time it took, the replication lag.
+func waitReplicated(replicated func() (bool, error)) (time.Duration, error) {
+	start := time.Now()
+	for {
+		ok, err := replicated()
+		if err != nil {
+			return time.Since(start), err
+		}
+		if ok {
+			return time.Since(start), nil
+		}
+		if time.Since(start) > replicationTimeout {
+			return time.Since(start), errReplicationTimeout
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+func main() {
+	runStartTime := time.Now()
+	mintest.Init("replication")
+	config := mintest.LoadConfig()
+
+	tests := []mintest.Test{
+		{Name: "testReplicateBucket", Description: "Check the creation and deletion of a bucket reach the second site", Requires: []string{mintest.RequiresSecondSite}, Run: testReplicateBucket},
+		{Name: "testReplicateObject", Description: "Check the upload, overwrite and deletion of an object reach the second site", Requires: []string{mintest.RequiresSecondSite}, Run: testReplicateObject},
+		{Name: "testReplicateBucketPolicy", Description: "Check setting and deleting a bucket policy reach the second site", Requires: []string{mintest.RequiresSecondSite}, Run: testReplicateBucketPolicy},
+		{Name: "testReplicateTagging", Description: "Check the tags of a bucket and of an object reach the second site", Requires: []string{mintest.RequiresSecondSite}, Run: testReplicateTagging},
+		{Name: "testReplicateDeleteMarker", Description: "Check delete markers reach the second site with DeleteMarkerReplication enabled only", Requires: []string{mintest.RequiresSecondSite, mintest.RequiresReplication}, Run: testReplicateDeleteMarker},
+		{Name: "testReplicationStatus", Description: "Check the replication status moves from PENDING to COMPLETED and replicas are REPLICA", Requires: []string{mintest.RequiresSecondSite, mintest.RequiresReplication}, Run: testReplicationStatus},
+		{Name: "testReplicaModifications", Description: "Check tags set on a replica sync back with ReplicaModifications enabled only", Requires: []string{mintest.RequiresSecondSite, mintest.RequiresReplication}, Run: testReplicaModifications},
+	}
+	// Listed even without a second site to run them on
+	mintest.ListTests(tests)
+
+	if config.Endpoint2 == "" {
+		mintest.SkipLogger("replication", map[string]interface{}{}, runStartTime, "SERVER_ENDPOINT_2 is not set").Info()
+		return
+	}
+	replicationTimeout = defaultReplicationTimeout
+	if value := os.Getenv("MINT_REPLICATION_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			mintest.FailureLog("replication", map[string]interface{}{}, runStartTime, "", "Invalid MINT_REPLICATION_TIMEOUT", err).Fatal()
+			return
+		}
+		replicationTimeout = timeout
+	}
+
+	// Create an S3 service object in the default region.
+	s3Client = config.NewS3Client()
+	mintest.TrackBuckets(s3Client)
+	// Remove the buckets left behind, even by a failed test
+	defer mintest.StartJanitor(s3Client)()
+	// Both sites share their credentials
+	site2 := config
+	site2.Endpoint = config.Endpoint2
+	site2Client = site2.NewS3Client()
+
+	mintest.RunTests(tests, mintest.NewCapabilities(config, s3Client).Supports)
+	mintest.TestResourceLeaks(s3Client, bucketPrefix, runStartTime)
+}
@@ -0,0 +1,427 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Time a change which is not to be replicated is given to reach the
+// second site anyway, before checking it did not
+const settleTime = 10 * time.Second
+
+// replicationConfig returns the replication configuration of bucket on the
+// site of client, nil when it has none
+func replicationConfig(client *s3.S3, bucket string) (*s3.ReplicationConfiguration, error) {
+	output, err := client.GetBucketReplication(&s3.GetBucketReplicationInput{
+		Bucket: aws.String(bucket),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "ReplicationConfigurationNotFoundError" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return output.ReplicationConfiguration, nil
+}
+
+// ruleStatus returns the status of a setting of the rules of config, the
+// one of the first rule setting it, Disabled when none does
+func ruleStatus(config *s3.ReplicationConfiguration, setting func(rule *s3.ReplicationRule) *string) string {
+	if config != nil {
+		for _, rule := range config.Rules {
+			if status := aws.StringValue(setting(rule)); status != "" {
+				return status
+			}
+		}
+	}
+	return s3.DeleteMarkerReplicationStatusDisabled
+}
+
+// deleteMarkerReplicated reports whether the delete marker versionID of
+// object exists on the second site
+func deleteMarkerReplicated(bucket, object, versionID string) func() (bool, error) {
+	return func() (bool, error) {
+		output, err := site2Client.ListObjectVersions(&s3.ListObjectVersionsInput{
+			Bucket: aws.String(bucket),
+			Prefix: aws.String(object),
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, marker := range output.DeleteMarkers {
+			if aws.StringValue(marker.Key) == object && aws.StringValue(marker.VersionId) == versionID {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// versionReplicatedBucket waits for bucket to reach the second site and
+// enables versioning on it, returning the replication lag
+func versionReplicatedBucket(bucket string) (time.Duration, error) {
+	lag, err := waitReplicated(bucketReplicated(bucket, true))
+	if err != nil {
+		return lag, err
+	}
+	// Site replication versions the buckets already
+	_, err = s3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket:                  aws.String(bucket),
+		VersioningConfiguration: &s3.VersioningConfiguration{Status: aws.String(s3.BucketVersioningStatusEnabled)},
+	})
+	return lag, err
+}
+
+// testReplicateDeleteMarker deletes objects without a version ID on the
+// first site, and checks the delete markers reach the second site when
+// the replication rules of the bucket have DeleteMarkerReplication
+// enabled, and do not when it is disabled. Both statuses are checked when
+// the rules can be changed, which site replication denies; the current one
+// is checked otherwise.
+func testReplicateDeleteMarker() {
+	startTime := time.Now()
+	function := "testReplicateDeleteMarker"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	lags := map[string]int64{}
+	args := map[string]interface{}{
+		"bucketName":       bucket,
+		"replicationLagMs": lags,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+	lag, err := versionReplicatedBucket(bucket)
+	lags["createBucket"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Versioned bucket not replicated", err).Fatal()
+		return
+	}
+
+	config, err := replicationConfig(s3Client, bucket)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetBucketReplication failed", err).Fatal()
+		return
+	}
+	if config == nil {
+		mintest.IgnoreLog(function, args, startTime, "The bucket has no replication rules").Info()
+		return
+	}
+	deleteMarkers := func(rule *s3.ReplicationRule) *string {
+		if rule.DeleteMarkerReplication == nil {
+			return nil
+		}
+		return rule.DeleteMarkerReplication.Status
+	}
+	statuses := []string{ruleStatus(config, deleteMarkers)}
+	// Replicating existing objects takes a resync, which is outside the S3 API
+	args["existingObjectReplication"] = ruleStatus(config, func(rule *s3.ReplicationRule) *string {
+		if rule.ExistingObjectReplication == nil {
+			return nil
+		}
+		return rule.ExistingObjectReplication.Status
+	})
+
+	// Toggle DeleteMarkerReplication in a copy of the rules
+	toggled := statuses[0]
+	if toggled == s3.DeleteMarkerReplicationStatusEnabled {
+		toggled = s3.DeleteMarkerReplicationStatusDisabled
+	} else {
+		toggled = s3.DeleteMarkerReplicationStatusEnabled
+	}
+	toggledConfig := &s3.ReplicationConfiguration{Role: config.Role}
+	for _, rule := range config.Rules {
+		toggledRule := *rule
+		toggledRule.DeleteMarkerReplication = &s3.DeleteMarkerReplication{Status: aws.String(toggled)}
+		toggledConfig.Rules = append(toggledConfig.Rules, &toggledRule)
+	}
+	setRules := func(config *s3.ReplicationConfiguration) error {
+		_, err := s3Client.PutBucketReplication(&s3.PutBucketReplicationInput{
+			Bucket:                   aws.String(bucket),
+			ReplicationConfiguration: config,
+		})
+		return err
+	}
+
+	for i := 0; i < 2; i++ {
+		if i == 1 {
+			if err = setRules(toggledConfig); err != nil {
+				// Site replication manages the rules
+				args["toggle"] = err.Error()
+				break
+			}
+			defer setRules(config)
+			statuses = append(statuses, toggled)
+		}
+		status := statuses[i]
+		object := "object-" + status
+		args["objectName"] = object
+
+		output, err := s3Client.PutObject(&s3.PutObjectInput{
+			Body:   bytes.NewReader([]byte("deleteMarker")),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+			return
+		}
+		lag, err = waitReplicated(objectReplicated(bucket, object, aws.StringValue(output.ETag)))
+		lags["putObject"+status] = lag.Milliseconds()
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "PutObject not replicated", err).Fatal()
+			return
+		}
+
+		deleteOutput, err := s3Client.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "DeleteObject failed", err).Fatal()
+			return
+		}
+		replicated := deleteMarkerReplicated(bucket, object, aws.StringValue(deleteOutput.VersionId))
+		if status == s3.DeleteMarkerReplicationStatusEnabled {
+			lag, err = waitReplicated(replicated)
+			lags["deleteMarker"+status] = lag.Milliseconds()
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", "Delete marker not replicated with DeleteMarkerReplication Enabled", err).Fatal()
+				return
+			}
+			continue
+		}
+		time.Sleep(settleTime)
+		if ok, err := replicated(); ok || err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Delete marker replicated with DeleteMarkerReplication Disabled", err).Fatal()
+			return
+		}
+	}
+	delete(args, "objectName")
+	args["deleteMarkerReplication"] = statuses
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// testReplicationStatus uploads an object on the first site and checks its
+// replication status only moves from PENDING to COMPLETED there, while the
+// copy on the second site is a REPLICA
+func testReplicationStatus() {
+	startTime := time.Now()
+	function := "testReplicationStatus"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	lags := map[string]int64{}
+	args := map[string]interface{}{
+		"bucketName":       bucket,
+		"objectName":       object,
+		"replicationLagMs": lags,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+	lag, err := versionReplicatedBucket(bucket)
+	lags["createBucket"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Versioned bucket not replicated", err).Fatal()
+		return
+	}
+
+	output, err := s3Client.PutObject(&s3.PutObjectInput{
+		Body:   bytes.NewReader([]byte("replicationStatus")),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+
+	// Statuses seen on the first site, each once in order
+	var statuses []string
+	lag, err = waitReplicated(func() (bool, error) {
+		head, err := s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			VersionId: output.VersionId,
+		})
+		if err != nil {
+			return false, err
+		}
+		status := aws.StringValue(head.ReplicationStatus)
+		if len(statuses) == 0 || statuses[len(statuses)-1] != status {
+			statuses = append(statuses, status)
+		}
+		switch status {
+		case s3.ReplicationStatusComplete:
+			return true, nil
+		case s3.ReplicationStatusPending:
+			return false, nil
+		}
+		return false, fmt.Errorf("unexpected replication status %q", status)
+	})
+	lags["completed"] = lag.Milliseconds()
+	args["statuses"] = statuses
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Replication status not COMPLETED on the first site", err).Fatal()
+		return
+	}
+	if !reflect.DeepEqual(statuses, []string{s3.ReplicationStatusComplete}) &&
+		!reflect.DeepEqual(statuses, []string{s3.ReplicationStatusPending, s3.ReplicationStatusComplete}) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Replication status expected to move from PENDING to COMPLETED but went through %v", statuses), nil).Fatal()
+		return
+	}
+
+	head, err := site2Client.HeadObject(&s3.HeadObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(object),
+		VersionId: output.VersionId,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "HeadObject on the second site failed", err).Fatal()
+		return
+	}
+	if status := aws.StringValue(head.ReplicationStatus); status != s3.ReplicationStatusReplica {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Replication status on the second site expected REPLICA but got %q", status), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// testReplicaModifications changes the tags of a replica on the second
+// site, and checks the change is synced back to the first site when the
+// rules of the second site have ReplicaModifications enabled, and is not
+// when they are disabled.
+func testReplicaModifications() {
+	startTime := time.Now()
+	function := "testReplicaModifications"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	lags := map[string]int64{}
+	args := map[string]interface{}{
+		"bucketName":       bucket,
+		"objectName":       object,
+		"replicationLagMs": lags,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+	lag, err := versionReplicatedBucket(bucket)
+	lags["createBucket"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Versioned bucket not replicated", err).Fatal()
+		return
+	}
+
+	config, err := replicationConfig(site2Client, bucket)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetBucketReplication on the second site failed", err).Fatal()
+		return
+	}
+	if config == nil {
+		mintest.IgnoreLog(function, args, startTime, "The bucket has no replication rules on the second site to sync replicas back").Info()
+		return
+	}
+	status := ruleStatus(config, func(rule *s3.ReplicationRule) *string {
+		if rule.SourceSelectionCriteria == nil || rule.SourceSelectionCriteria.ReplicaModifications == nil {
+			return nil
+		}
+		return rule.SourceSelectionCriteria.ReplicaModifications.Status
+	})
+	args["replicaModifications"] = status
+
+	output, err := s3Client.PutObject(&s3.PutObjectInput{
+		Body:   bytes.NewReader([]byte("replicaModifications")),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+	lag, err = waitReplicated(objectReplicated(bucket, object, aws.StringValue(output.ETag)))
+	lags["putObject"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject not replicated", err).Fatal()
+		return
+	}
+
+	_, err = site2Client.PutObjectTagging(&s3.PutObjectTaggingInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(object),
+		VersionId: output.VersionId,
+		Tagging:   &s3.Tagging{TagSet: []*s3.Tag{{Key: aws.String("modified"), Value: aws.String("replica")}}},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObjectTagging of the replica failed", err).Fatal()
+		return
+	}
+	synced := func() (bool, error) {
+		tagging, err := s3Client.GetObjectTagging(&s3.GetObjectTaggingInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			VersionId: output.VersionId,
+		})
+		if err != nil {
+			return false, err
+		}
+		return len(tagging.TagSet) == 1 && aws.StringValue(tagging.TagSet[0].Value) == "replica", nil
+	}
+	if status == s3.ReplicaModificationsStatusEnabled {
+		lag, err = waitReplicated(synced)
+		lags["replicaModification"] = lag.Milliseconds()
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Tags of the replica not synced back with ReplicaModifications Enabled", err).Fatal()
+			return
+		}
+	} else {
+		time.Sleep(settleTime)
+		if ok, err := synced(); ok || err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Tags of the replica synced back with ReplicaModifications Disabled", err).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
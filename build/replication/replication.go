@@ -0,0 +1,386 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+const publicReadPolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`
+
+// isNotFound reports whether err is a 404 response, or an S3 error of one
+// of the given codes
+func isNotFound(err error, codes ...string) bool {
+	if rerr, ok := err.(awserr.RequestFailure); ok && rerr.StatusCode() == http.StatusNotFound {
+		return true
+	}
+	if aerr, ok := err.(awserr.Error); ok {
+		for _, code := range codes {
+			if aerr.Code() == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bucketReplicated reports whether bucket exists on the second site, or no
+// longer exists when exists is false
+func bucketReplicated(bucket string, exists bool) func() (bool, error) {
+	return func() (bool, error) {
+		_, err := site2Client.HeadBucket(&s3.HeadBucketInput{
+			Bucket: aws.String(bucket),
+		})
+		if isNotFound(err) {
+			return !exists, nil
+		}
+		return exists, err
+	}
+}
+
+// objectReplicated reports whether object exists on the second site with
+// the given ETag, or no longer exists when etag is empty
+func objectReplicated(bucket, object, etag string) func() (bool, error) {
+	return func() (bool, error) {
+		output, err := site2Client.HeadObject(&s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if isNotFound(err) {
+			return etag == "", nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return aws.StringValue(output.ETag) == etag, nil
+	}
+}
+
+// testReplicateBucket creates a bucket on the first site, then deletes it,
+// and checks both changes reach the second site
+func testReplicateBucket() {
+	startTime := time.Now()
+	function := "testReplicateBucket"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	lags := map[string]int64{}
+	args := map[string]interface{}{
+		"bucketName":       bucket,
+		"replicationLagMs": lags,
+	}
+
+	// The bucket is deleted by the test itself, the janitor removes it if
+	// the test fails first
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	lag, err := waitReplicated(bucketReplicated(bucket, true))
+	lags["createBucket"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket not replicated", err).Fatal()
+		return
+	}
+
+	_, err = s3Client.DeleteBucket(&s3.DeleteBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucket failed", err).Fatal()
+		return
+	}
+	lag, err = waitReplicated(bucketReplicated(bucket, false))
+	lags["deleteBucket"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucket not replicated", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// testReplicateObject uploads an object on the first site, overwrites it,
+// then deletes it, and checks each change reaches the second site, where
+// the object must have the same content.
+func testReplicateObject() {
+	startTime := time.Now()
+	function := "testReplicateObject"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	lags := map[string]int64{}
+	args := map[string]interface{}{
+		"bucketName":       bucket,
+		"objectName":       object,
+		"replicationLagMs": lags,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+	lag, err := waitReplicated(bucketReplicated(bucket, true))
+	lags["createBucket"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket not replicated", err).Fatal()
+		return
+	}
+
+	seed := mintest.DataSeed()
+	args["seed"] = seed
+	for _, operation := range []string{"putObject", "overwriteObject"} {
+		content, _ := ioutil.ReadAll(mintest.NewDataReader(seed+uint64(len(lags)), 1<<20))
+		output, err := s3Client.PutObject(&s3.PutObjectInput{
+			Body:   bytes.NewReader(content),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+			return
+		}
+		lag, err = waitReplicated(objectReplicated(bucket, object, aws.StringValue(output.ETag)))
+		lags[operation] = lag.Milliseconds()
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("%s not replicated", operation), err).Fatal()
+			return
+		}
+
+		getOutput, err := site2Client.GetObject(&s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "GetObject on the second site failed", err).Fatal()
+			return
+		}
+		replicated, err := ioutil.ReadAll(getOutput.Body)
+		getOutput.Body.Close()
+		if err != nil || !bytes.Equal(replicated, content) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject on the second site returned other content after %s", operation), err).Fatal()
+			return
+		}
+	}
+
+	_, err = s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteObject failed", err).Fatal()
+		return
+	}
+	lag, err = waitReplicated(objectReplicated(bucket, object, ""))
+	lags["deleteObject"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteObject not replicated", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// testReplicateBucketPolicy sets a bucket policy on the first site, then
+// deletes it, and checks both changes reach the second site
+func testReplicateBucketPolicy() {
+	startTime := time.Now()
+	function := "testReplicateBucketPolicy"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	lags := map[string]int64{}
+	args := map[string]interface{}{
+		"bucketName":       bucket,
+		"replicationLagMs": lags,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+	lag, err := waitReplicated(bucketReplicated(bucket, true))
+	lags["createBucket"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket not replicated", err).Fatal()
+		return
+	}
+
+	policyReplicated := func(exists bool) func() (bool, error) {
+		return func() (bool, error) {
+			_, err := site2Client.GetBucketPolicy(&s3.GetBucketPolicyInput{
+				Bucket: aws.String(bucket),
+			})
+			if isNotFound(err, "NoSuchBucketPolicy") {
+				return !exists, nil
+			}
+			return exists, err
+		}
+	}
+
+	_, err = s3Client.PutBucketPolicy(&s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucket),
+		Policy: aws.String(fmt.Sprintf(publicReadPolicy, bucket)),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutBucketPolicy failed", err).Fatal()
+		return
+	}
+	lag, err = waitReplicated(policyReplicated(true))
+	lags["putBucketPolicy"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutBucketPolicy not replicated", err).Fatal()
+		return
+	}
+
+	_, err = s3Client.DeleteBucketPolicy(&s3.DeleteBucketPolicyInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucketPolicy failed", err).Fatal()
+		return
+	}
+	lag, err = waitReplicated(policyReplicated(false))
+	lags["deleteBucketPolicy"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucketPolicy not replicated", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// testReplicateTagging sets the tags of a bucket and of an object on the
+// first site, and checks the second site ends up with the same tags
+func testReplicateTagging() {
+	startTime := time.Now()
+	function := "testReplicateTagging"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	lags := map[string]int64{}
+	args := map[string]interface{}{
+		"bucketName":       bucket,
+		"objectName":       object,
+		"replicationLagMs": lags,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+	lag, err := waitReplicated(bucketReplicated(bucket, true))
+	lags["createBucket"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket not replicated", err).Fatal()
+		return
+	}
+
+	tagSet := []*s3.Tag{
+		{Key: aws.String("site"), Value: aws.String("1")},
+		{Key: aws.String("test"), Value: aws.String(function)},
+	}
+	sameTags := func(got []*s3.Tag) bool {
+		tags := make(map[string]string, len(got))
+		for _, tag := range got {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		return reflect.DeepEqual(tags, map[string]string{"site": "1", "test": function})
+	}
+
+	_, err = s3Client.PutBucketTagging(&s3.PutBucketTaggingInput{
+		Bucket:  aws.String(bucket),
+		Tagging: &s3.Tagging{TagSet: tagSet},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutBucketTagging failed", err).Fatal()
+		return
+	}
+	lag, err = waitReplicated(func() (bool, error) {
+		output, err := site2Client.GetBucketTagging(&s3.GetBucketTaggingInput{
+			Bucket: aws.String(bucket),
+		})
+		if isNotFound(err, "NoSuchTagSet") {
+			return false, nil
+		}
+		return err == nil && sameTags(output.TagSet), err
+	})
+	lags["putBucketTagging"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutBucketTagging not replicated", err).Fatal()
+		return
+	}
+
+	output, err := s3Client.PutObject(&s3.PutObjectInput{
+		Body:   bytes.NewReader([]byte("fileToUpload")),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+		return
+	}
+	lag, err = waitReplicated(objectReplicated(bucket, object, aws.StringValue(output.ETag)))
+	lags["putObject"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject not replicated", err).Fatal()
+		return
+	}
+
+	_, err = s3Client.PutObjectTagging(&s3.PutObjectTaggingInput{
+		Bucket:  aws.String(bucket),
+		Key:     aws.String(object),
+		Tagging: &s3.Tagging{TagSet: tagSet},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObjectTagging failed", err).Fatal()
+		return
+	}
+	lag, err = waitReplicated(func() (bool, error) {
+		output, err := site2Client.GetObjectTagging(&s3.GetObjectTaggingInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		return err == nil && sameTags(output.TagSet), err
+	})
+	lags["putObjectTagging"] = lag.Milliseconds()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObjectTagging not replicated", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,68 @@
+//  Mint, (C) 2026 Minio, Inc.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package main
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"mint.minio.io/mintest"
+)
+
+// Prefix of the names of all the buckets created by this suite
+const bucketPrefix = "sts-test-"
+
+// S3 client for testing
+var s3Client *s3.S3
+
+// STS client for testing, signed with the same credentials
+var stsClient *sts.STS
+
+// Server under test, from which clients using temporary credentials are
+// derived
+var config mintest.Config
+
+func cleanupBucket(bucket string, function string, args map[string]interface{}, startTime time.Time) {
+	if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucket failed", err).Fatal()
+	}
+}
+
+func main() {
+	runStartTime := time.Now()
+	mintest.Init("sts")
+	config = mintest.LoadConfig()
+
+	// Create an S3 service object in the default region.
+	s3Client = config.NewS3Client()
+	mintest.TrackBuckets(s3Client)
+	// Remove the buckets left behind, even by a failed test
+	defer mintest.StartJanitor(s3Client)()
+	// STS is served on the same endpoint as S3
+	stsClient = sts.New(session.New(), config.S3Config())
+
+	mintest.RunTests([]mintest.Test{
+		{Name: "testAssumeRole", Description: "Use temporary credentials of AssumeRole to create a bucket and PUT, GET and DELETE an object", Requires: []string{mintest.RequiresMinIO}, Run: testAssumeRole},
+		{Name: "testAssumeRoleScopedPolicy", Description: "Check a session policy restricts temporary credentials to reading a bucket", Requires: []string{mintest.RequiresMinIO}, Run: testAssumeRoleScopedPolicy},
+		{Name: "testAssumeRoleInvalidCredentials", Description: "Check requests with a tampered session token or secret key are rejected", Requires: []string{mintest.RequiresMinIO}, Run: testAssumeRoleInvalidCredentials},
+		{Name: "testAssumeRoleExpiry", Description: "Check temporary credentials are rejected once expired, in full mode", Requires: []string{mintest.RequiresMinIO, mintest.RequiresFull}, Run: testAssumeRoleExpiry},
+		{Name: "testAssumeRoleWithWebIdentity", Description: "Exchange WEB_IDENTITY_TOKEN or OIDC_TOKEN for temporary credentials of ROLE_ARN and list buckets", Requires: []string{mintest.RequiresMinIO, mintest.RequiresWebIdentity}, Run: testAssumeRoleWithWebIdentity},
+		{Name: "testWebIdentityObjectOperations", Description: "PUT, HEAD, GET, list and DELETE an object with the credentials of ROLE_ARN assumed with the OIDC token", Requires: []string{mintest.RequiresMinIO, mintest.RequiresWebIdentity}, Run: testWebIdentityObjectOperations},
+	}, mintest.NewCapabilities(config, s3Client).Supports)
+	mintest.TestResourceLeaks(s3Client, bucketPrefix, runStartTime)
+}
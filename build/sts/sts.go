@@ -0,0 +1,469 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"mint.minio.io/mintest"
+)
+
+// Shortest validity accepted for temporary credentials
+const minDuration = 900 * time.Second
+
+// assumeRole returns temporary credentials valid for duration, restricted
+// by policy when it is not empty.
+func assumeRole(policy string, duration time.Duration) (*sts.Credentials, error) {
+	input := &sts.AssumeRoleInput{
+		DurationSeconds: aws.Int64(int64(duration / time.Second)),
+		RoleArn:         aws.String("arn:minio:iam:::role/mint"),
+		RoleSessionName: aws.String("mint"),
+	}
+	if policy != "" {
+		input.Policy = aws.String(policy)
+	}
+	output, err := stsClient.AssumeRole(input)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkCredentials(output.Credentials, duration, true); err != nil {
+		return nil, err
+	}
+	return output.Credentials, nil
+}
+
+// checkCredentials checks all the fields of temporary credentials are set
+// and that they expire after duration, or at most after it when the
+// validity may be shortened by the server.
+func checkCredentials(creds *sts.Credentials, duration time.Duration, exact bool) error {
+	if creds == nil || aws.StringValue(creds.AccessKeyId) == "" || aws.StringValue(creds.SecretAccessKey) == "" || aws.StringValue(creds.SessionToken) == "" {
+		return errors.New("temporary credentials are incomplete")
+	}
+	// Allow for the clock skew between the server and mint
+	expiration := aws.TimeValue(creds.Expiration)
+	earliest := time.Now().Add(duration - time.Minute)
+	if !exact {
+		earliest = time.Now()
+	}
+	if expected := time.Now().Add(duration); expiration.Before(earliest) || expiration.After(expected.Add(time.Minute)) {
+		return fmt.Errorf("temporary credentials expire at %v, expected around %v", expiration, expected)
+	}
+	return nil
+}
+
+// newS3Client returns an S3 client signing with temporary credentials
+func newS3Client(accessKeyID, secretAccessKey, sessionToken string) *s3.S3 {
+	s3Config := config.S3Config()
+	s3Config.Credentials = credentials.NewStaticCredentials(accessKeyID, secretAccessKey, sessionToken)
+	client := s3.New(session.New(), s3Config)
+	mintest.TrackBuckets(client)
+	return client
+}
+
+// Get temporary credentials with AssumeRole and use them to create a
+// bucket, then PUT, GET and DELETE an object in it.
+func testAssumeRole() {
+	startTime := time.Now()
+	function := "testAssumeRole"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	content := []byte("temporary credentials")
+	args := map[string]interface{}{
+		"bucketName":      bucket,
+		"objectName":      object,
+		"durationSeconds": int64(minDuration / time.Second),
+	}
+
+	creds, err := assumeRole("", minDuration)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AssumeRole failed", err).Fatal()
+		return
+	}
+	client := newS3Client(aws.StringValue(creds.AccessKeyId), aws.StringValue(creds.SecretAccessKey), aws.StringValue(creds.SessionToken))
+
+	_, err = client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket with temporary credentials failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err = client.PutObject(&s3.PutObjectInput{
+		Body:   bytes.NewReader(content),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject with temporary credentials failed", err).Fatal()
+		return
+	}
+
+	output, err := client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject with temporary credentials failed", err).Fatal()
+		return
+	}
+	body, err := ioutil.ReadAll(output.Body)
+	output.Body.Close()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject with temporary credentials reading body failed", err).Fatal()
+		return
+	}
+	if !bytes.Equal(body, content) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject with temporary credentials expected %q but got %q", content, body), errors.New("content mismatch")).Fatal()
+		return
+	}
+
+	_, err = client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteObject with temporary credentials failed", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Get temporary credentials restricted by a session policy to reading the
+// objects of one bucket. Reading must succeed, while writing, listing and
+// reading from another bucket must be denied.
+func testAssumeRoleScopedPolicy() {
+	startTime := time.Now()
+	function := "testAssumeRoleScopedPolicy"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	otherBucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()+1), bucketPrefix)
+	object := "testObject"
+	policy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`, bucket)
+	args := map[string]interface{}{
+		"bucketName":      bucket,
+		"otherBucketName": otherBucket,
+		"objectName":      object,
+		"policy":          policy,
+	}
+
+	for _, b := range []string{bucket, otherBucket} {
+		_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+			Bucket: aws.String(b),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+			return
+		}
+		defer cleanupBucket(b, function, args, startTime)
+
+		_, err = s3Client.PutObject(&s3.PutObjectInput{
+			Body:   bytes.NewReader([]byte("scoped")),
+			Bucket: aws.String(b),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "PutObject failed", err).Fatal()
+			return
+		}
+	}
+
+	creds, err := assumeRole(policy, minDuration)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AssumeRole with a session policy failed", err).Fatal()
+		return
+	}
+	client := newS3Client(aws.StringValue(creds.AccessKeyId), aws.StringValue(creds.SecretAccessKey), aws.StringValue(creds.SessionToken))
+
+	output, err := client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject allowed by the session policy failed", err).Fatal()
+		return
+	}
+	output.Body.Close()
+
+	denied := map[string]func() error{
+		"PutObject": func() error {
+			_, err := client.PutObject(&s3.PutObjectInput{
+				Body:   bytes.NewReader([]byte("denied")),
+				Bucket: aws.String(bucket),
+				Key:    aws.String("deniedObject"),
+			})
+			return err
+		},
+		"ListObjectsV2": func() error {
+			_, err := client.ListObjectsV2(&s3.ListObjectsV2Input{
+				Bucket: aws.String(bucket),
+			})
+			return err
+		},
+		"GetObject from another bucket": func() error {
+			output, err := client.GetObject(&s3.GetObjectInput{
+				Bucket: aws.String(otherBucket),
+				Key:    aws.String(object),
+			})
+			if err == nil {
+				output.Body.Close()
+			}
+			return err
+		},
+	}
+	for op, call := range denied {
+		err = call()
+		if err == nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("%s outside the session policy expected to fail but succeeded", op), nil).Fatal()
+			return
+		}
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "AccessDenied" {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("%s outside the session policy expected to fail with AccessDenied", op), err).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Check that requests signed with a tampered session token or secret key
+// of temporary credentials are rejected.
+func testAssumeRoleInvalidCredentials() {
+	startTime := time.Now()
+	function := "testAssumeRoleInvalidCredentials"
+	args := map[string]interface{}{}
+
+	creds, err := assumeRole("", minDuration)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AssumeRole failed", err).Fatal()
+		return
+	}
+	accessKeyID := aws.StringValue(creds.AccessKeyId)
+	secretAccessKey := aws.StringValue(creds.SecretAccessKey)
+	sessionToken := aws.StringValue(creds.SessionToken)
+
+	invalid := map[string]*s3.S3{
+		"tampered session token": newS3Client(accessKeyID, secretAccessKey, sessionToken[:len(sessionToken)-4]+"AAAA"),
+		"missing session token":  newS3Client(accessKeyID, secretAccessKey, ""),
+		"tampered secret key":    newS3Client(accessKeyID, secretAccessKey[:len(secretAccessKey)-4]+"AAAA", sessionToken),
+	}
+	for name, client := range invalid {
+		_, err = client.ListBuckets(&s3.ListBucketsInput{})
+		if err == nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListBuckets with a %s expected to fail but succeeded", name), nil).Fatal()
+			return
+		}
+		if rerr, ok := err.(awserr.RequestFailure); !ok || rerr.StatusCode() < 400 || rerr.StatusCode() >= 500 {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListBuckets with a %s expected to fail with a client error", name), err).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Get temporary credentials valid for the shortest duration, check they
+// work, then wait for them to expire and check they are rejected. The wait
+// lasts the whole duration, so it only runs in full mode.
+func testAssumeRoleExpiry() {
+	startTime := time.Now()
+	function := "testAssumeRoleExpiry"
+	args := map[string]interface{}{
+		"durationSeconds": int64(minDuration / time.Second),
+	}
+
+	creds, err := assumeRole("", minDuration)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AssumeRole failed", err).Fatal()
+		return
+	}
+	client := newS3Client(aws.StringValue(creds.AccessKeyId), aws.StringValue(creds.SecretAccessKey), aws.StringValue(creds.SessionToken))
+
+	if _, err = client.ListBuckets(&s3.ListBucketsInput{}); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ListBuckets with temporary credentials failed", err).Fatal()
+		return
+	}
+
+	time.Sleep(time.Until(aws.TimeValue(creds.Expiration)) + 5*time.Second)
+
+	_, err = client.ListBuckets(&s3.ListBucketsInput{})
+	if err == nil {
+		mintest.FailureLog(function, args, startTime, "", "ListBuckets with expired temporary credentials expected to fail but succeeded", nil).Fatal()
+		return
+	}
+	// Expired credentials may already be purged from the server
+	if aerr, ok := err.(awserr.Error); !ok || (aerr.Code() != "ExpiredToken" && aerr.Code() != "InvalidAccessKeyId" && aerr.Code() != "InvalidTokenId") {
+		mintest.FailureLog(function, args, startTime, "", "ListBuckets with expired temporary credentials expected to fail with ExpiredToken", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Exchange the OpenID Connect token set in WEB_IDENTITY_TOKEN or OIDC_TOKEN
+// for temporary credentials of ROLE_ARN with AssumeRoleWithWebIdentity,
+// and use them to list buckets.
+func testAssumeRoleWithWebIdentity() {
+	startTime := time.Now()
+	function := "testAssumeRoleWithWebIdentity"
+	args := map[string]interface{}{
+		"durationSeconds": int64(minDuration / time.Second),
+		"roleArn":         config.RoleARN,
+	}
+
+	output, err := stsClient.AssumeRoleWithWebIdentity(&sts.AssumeRoleWithWebIdentityInput{
+		DurationSeconds:  aws.Int64(int64(minDuration / time.Second)),
+		RoleArn:          aws.String(config.RoleARN),
+		RoleSessionName:  aws.String("mint"),
+		WebIdentityToken: aws.String(config.WebIdentityToken),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AssumeRoleWithWebIdentity failed", err).Fatal()
+		return
+	}
+	if err = checkCredentials(output.Credentials, minDuration, false); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AssumeRoleWithWebIdentity returned invalid credentials", err).Fatal()
+		return
+	}
+
+	creds := output.Credentials
+	client := newS3Client(aws.StringValue(creds.AccessKeyId), aws.StringValue(creds.SecretAccessKey), aws.StringValue(creds.SessionToken))
+	if _, err = client.ListBuckets(&s3.ListBucketsInput{}); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ListBuckets with web identity credentials failed", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Sign requests with the credentials the harness gets by assuming ROLE_ARN
+// with the OpenID Connect token, as applications federated through OIDC
+// do, and PUT, HEAD, GET, list and DELETE objects of a bucket created
+// beforehand with the configured credentials.
+func testWebIdentityObjectOperations() {
+	startTime := time.Now()
+	function := "testWebIdentityObjectOperations"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	content := []byte("web identity credentials")
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"roleArn":    config.RoleARN,
+	}
+
+	client := config.NewWebIdentityS3Client()
+	creds, err := client.Config.Credentials.Get()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AssumeRoleWithWebIdentity failed", err).Fatal()
+		return
+	}
+	if creds.ProviderName != stscreds.WebIdentityProviderName || creds.SessionToken == "" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Credentials of %s expected from the web identity provider with a session token", creds.ProviderName), errors.New("credentials mismatch")).Fatal()
+		return
+	}
+
+	_, err = s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err = client.PutObject(&s3.PutObjectInput{
+		Body:   bytes.NewReader(content),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObject with web identity credentials failed, check the policy of the role allows object operations", err).Fatal()
+		return
+	}
+
+	head, err := client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "HeadObject with web identity credentials failed", err).Fatal()
+		return
+	}
+	if aws.Int64Value(head.ContentLength) != int64(len(content)) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HeadObject with web identity credentials expected %d bytes but got %d", len(content), aws.Int64Value(head.ContentLength)), errors.New("content length mismatch")).Fatal()
+		return
+	}
+
+	output, err := client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject with web identity credentials failed", err).Fatal()
+		return
+	}
+	got, err := ioutil.ReadAll(output.Body)
+	output.Body.Close()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObject with web identity credentials reading body failed", err).Fatal()
+		return
+	}
+	if !bytes.Equal(got, content) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject with web identity credentials expected %q but got %q", content, got), errors.New("content mismatch")).Fatal()
+		return
+	}
+
+	list, err := client.ListObjectsV2(&s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "ListObjectsV2 with web identity credentials failed", err).Fatal()
+		return
+	}
+	if len(list.Contents) != 1 || aws.StringValue(list.Contents[0].Key) != object {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectsV2 with web identity credentials expected %s but got %v", object, list.Contents), errors.New("listing mismatch")).Fatal()
+		return
+	}
+
+	_, err = client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteObject with web identity credentials failed", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -21,12 +21,16 @@ package main
 
 import (
 	"errors"
+	"fmt"
 	"math/rand"
+	"reflect"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
 )
 
 // Tests bucket versioned bucket and get its versioning configuration to check
@@ -36,7 +40,7 @@ func testMakeBucket() {
 	// initialize logging params
 	startTime := time.Now()
 	function := "testCreateVersioningBucket"
-	bucketName := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucketName := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	args := map[string]interface{}{
 		"bucketName": bucketName,
 	}
@@ -44,7 +48,7 @@ func testMakeBucket() {
 		Bucket: aws.String(bucketName),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "Versioning CreateBucket Failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Versioning CreateBucket Failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucketName, function, args, startTime)
@@ -63,7 +67,7 @@ func testMakeBucket() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
 		return
 	}
 
@@ -73,13 +77,363 @@ func testMakeBucket() {
 
 	result, err := s3Client.GetBucketVersioning(getVersioningInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", "Get Versioning failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Get Versioning failed", err).Fatal()
 		return
 	}
 
 	if *result.Status != "Enabled" {
-		failureLog(function, args, startTime, "", "Get Versioning status failed", errors.New("unexpected versioning status")).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Get Versioning status failed", errors.New("unexpected versioning status")).Fatal()
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Create a bucket with object locking, which must turn versioning on, and
+// set default retention rules on it, in days then in years. Objects
+// uploaded without retention must get the default one. Object locking
+// cannot be turned on for an existing bucket created without it.
+func testObjectLockBucket() {
+	startTime := time.Now()
+	function := "testObjectLockBucket"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	plainBucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()+1), "versioning-test-")
+	args := map[string]interface{}{
+		"bucketName":      bucket,
+		"plainBucketName": plainBucket,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket:                     aws.String(bucket),
+		ObjectLockEnabledForBucket: aws.Bool(true),
+	})
+	if err != nil {
+		if mintest.IsNotImplemented(err) {
+			ignoreLog(function, args, startTime, "Object locking is not implemented").Info()
+			return
+		}
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket with object locking failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	lockConfig, err := s3Client.GetObjectLockConfiguration(&s3.GetObjectLockConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "GetObjectLockConfiguration failed", err).Fatal()
+		return
+	}
+	if lockConfig.ObjectLockConfiguration == nil || aws.StringValue(lockConfig.ObjectLockConfiguration.ObjectLockEnabled) != s3.ObjectLockEnabledEnabled {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObjectLockConfiguration expected object locking Enabled but got %v", lockConfig.ObjectLockConfiguration), nil).Fatal()
+		return
+	}
+	versioning, err := s3Client.GetBucketVersioning(&s3.GetBucketVersioningInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Get Versioning failed", err).Fatal()
+		return
+	}
+	if status := aws.StringValue(versioning.Status); status != s3.BucketVersioningStatusEnabled {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Get Versioning of a bucket with object locking expected Enabled but got %q", status), nil).Fatal()
+		return
+	}
+	_, err = s3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket:                  aws.String(bucket),
+		VersioningConfiguration: &s3.VersioningConfiguration{Status: aws.String(s3.BucketVersioningStatusSuspended)},
+	})
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "InvalidBucketState" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Suspending versioning of a bucket with object locking expected to fail with InvalidBucketState but got %v", err), err).Fatal()
+		return
+	}
+
+	// Governance retention, for the objects to be removable at cleanup
+	for _, retention := range []struct {
+		name   string
+		rule   *s3.DefaultRetention
+		period time.Duration
+	}{
+		{"days", &s3.DefaultRetention{Mode: aws.String(s3.ObjectLockRetentionModeGovernance), Days: aws.Int64(2)}, 2 * 24 * time.Hour},
+		{"years", &s3.DefaultRetention{Mode: aws.String(s3.ObjectLockRetentionModeGovernance), Years: aws.Int64(1)}, 365 * 24 * time.Hour},
+	} {
+		args["defaultRetention"] = retention.name
+		_, err = s3Client.PutObjectLockConfiguration(&s3.PutObjectLockConfigurationInput{
+			Bucket: aws.String(bucket),
+			ObjectLockConfiguration: &s3.ObjectLockConfiguration{
+				ObjectLockEnabled: aws.String(s3.ObjectLockEnabledEnabled),
+				Rule:              &s3.ObjectLockRule{DefaultRetention: retention.rule},
+			},
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "PutObjectLockConfiguration failed", err).Fatal()
+			return
+		}
+		lockConfig, err = s3Client.GetObjectLockConfiguration(&s3.GetObjectLockConfigurationInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "GetObjectLockConfiguration failed", err).Fatal()
+			return
+		}
+		if rule := lockConfig.ObjectLockConfiguration.Rule; rule == nil || !reflect.DeepEqual(rule.DefaultRetention, retention.rule) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObjectLockConfiguration expected default retention %v but got %v", retention.rule, rule), nil).Fatal()
+			return
+		}
+
+		object := "object-" + retention.name
+		putTime := time.Now()
+		output, err := s3Client.PutObject(&s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader("content")),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+		head, err := s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			VersionId: output.VersionId,
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HEAD expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+		// A year may be counted as 365 days or up to the same date next year
+		expected := putTime.Add(retention.period)
+		retainUntil := aws.TimeValue(head.ObjectLockRetainUntilDate)
+		if aws.StringValue(head.ObjectLockMode) != s3.ObjectLockModeGovernance ||
+			retainUntil.Before(expected.Add(-time.Minute)) || retainUntil.After(expected.Add(24*time.Hour+time.Minute)) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HEAD expected the default %s retention GOVERNANCE until about %v but got %s until %v",
+				retention.name, expected, aws.StringValue(head.ObjectLockMode), retainUntil), nil).Fatal()
+			return
+		}
+	}
+	delete(args, "defaultRetention")
+
+	_, err = s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(plainBucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(plainBucket, function, args, startTime)
+	_, err = s3Client.PutObjectLockConfiguration(&s3.PutObjectLockConfigurationInput{
+		Bucket: aws.String(plainBucket),
+		ObjectLockConfiguration: &s3.ObjectLockConfiguration{
+			ObjectLockEnabled: aws.String(s3.ObjectLockEnabledEnabled),
+		},
+	})
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "InvalidBucketState" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Enabling object locking on a bucket created without it expected to fail with InvalidBucketState but got %v", err), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Check the versioning status of a bucket through its transitions: a
+// bucket that was never versioned reports no status, and versioning can
+// be enabled, suspended and enabled again. Versioning of a bucket with
+// object locking cannot be suspended, and requests enabling MFA delete,
+// which is not supported, must be rejected.
+func testBucketVersioningStatus() {
+	startTime := time.Now()
+	function := "testBucketVersioningStatus"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	lockBucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()+1), "versioning-test-")
+	args := map[string]interface{}{
+		"bucketName":     bucket,
+		"lockBucketName": lockBucket,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	output, err := s3Client.GetBucketVersioning(&s3.GetBucketVersioningInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Get Versioning failed", err).Fatal()
+		return
+	}
+	if output.Status != nil || output.MFADelete != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Get Versioning of a never versioned bucket expected no status but got %v", output), nil).Fatal()
+		return
+	}
+
+	for _, status := range []string{
+		s3.BucketVersioningStatusEnabled,
+		s3.BucketVersioningStatusSuspended,
+		s3.BucketVersioningStatusEnabled,
+	} {
+		args["status"] = status
+		_, err = s3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+			Bucket:                  aws.String(bucket),
+			VersioningConfiguration: &s3.VersioningConfiguration{Status: aws.String(status)},
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+			return
+		}
+		output, err = s3Client.GetBucketVersioning(&s3.GetBucketVersioningInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Get Versioning failed", err).Fatal()
+			return
+		}
+		if got := aws.StringValue(output.Status); got != status {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Get Versioning expected status %q but got %q", status, got), nil).Fatal()
+			return
+		}
+	}
+	delete(args, "status")
+
+	// MinIO does not support MFA delete, and S3 requires the x-amz-mfa
+	// header which is not sent here, so the request must fail either way
+	_, err = s3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket: aws.String(bucket),
+		VersioningConfiguration: &s3.VersioningConfiguration{
+			MFADelete: aws.String(s3.MFADeleteEnabled),
+			Status:    aws.String(s3.BucketVersioningStatusEnabled),
+		},
+	})
+	if _, ok := err.(awserr.Error); !ok {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Put versioning with MFA delete enabled expected to fail but got %v", err), err).Fatal()
+		return
+	}
+	output, err = s3Client.GetBucketVersioning(&s3.GetBucketVersioningInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Get Versioning failed", err).Fatal()
+		return
+	}
+	if aws.StringValue(output.Status) != s3.BucketVersioningStatusEnabled || aws.StringValue(output.MFADelete) == s3.MFADeleteStatusEnabled {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Get Versioning after a rejected MFA delete request expected status Enabled without MFA delete but got %v", output), nil).Fatal()
+		return
+	}
+
+	_, err = s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket:                     aws.String(lockBucket),
+		ObjectLockEnabledForBucket: aws.Bool(true),
+	})
+	if err != nil {
+		if mintest.IsNotImplemented(err) {
+			ignoreLog(function, args, startTime, "Object locking is not implemented").Info()
+			return
+		}
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket with object locking failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(lockBucket, function, args, startTime)
+	_, err = s3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket:                  aws.String(lockBucket),
+		VersioningConfiguration: &s3.VersioningConfiguration{Status: aws.String(s3.BucketVersioningStatusSuspended)},
+	})
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "InvalidBucketState" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Suspending versioning of a bucket with object locking expected to fail with InvalidBucketState but got %v", err), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Delete a versioned bucket whose object is hidden by a delete marker,
+// then holding only the delete marker, which must fail with BucketNotEmpty
+// both times. The bucket can be deleted once the delete marker is removed.
+func testDeleteBucketVersioned() {
+	startTime := time.Now()
+	function := "testDeleteBucketVersioned"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	deleted := false
+	defer func() {
+		if !deleted {
+			cleanupBucket(bucket, function, args, startTime)
+		}
+	}()
+
+	_, err = s3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket:                  aws.String(bucket),
+		VersioningConfiguration: &s3.VersioningConfiguration{Status: aws.String(s3.BucketVersioningStatusEnabled)},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		return
+	}
+	putOutput, err := s3Client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader("content")),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	deleteOutput, err := s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+
+	for _, step := range []struct {
+		contents  string
+		versionID *string
+	}{
+		{"a version hidden by a delete marker", putOutput.VersionId},
+		{"only a delete marker", deleteOutput.VersionId},
+	} {
+		_, err = s3Client.DeleteBucket(&s3.DeleteBucketInput{
+			Bucket: aws.String(bucket),
+		})
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "BucketNotEmpty" {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteBucket of a bucket holding %s expected BucketNotEmpty but got %v", step.contents, err), err).Fatal()
+			return
+		}
+		_, err = s3Client.DeleteObject(&s3.DeleteObjectInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			VersionId: step.versionID,
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE of a version expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+	}
+
+	_, err = s3Client.DeleteBucket(&s3.DeleteBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "DeleteBucket of an emptied versioned bucket failed", err).Fatal()
+		return
+	}
+	deleted = true
+
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
@@ -0,0 +1,179 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// getContent returns the content of the latest version of object
+func getContent(bucket, object string) (string, error) {
+	output, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		return "", err
+	}
+	defer output.Body.Close()
+	content, err := ioutil.ReadAll(output.Body)
+	return string(content), err
+}
+
+// testCopyObjectVersion copies an older version of an object by its
+// version ID, and checks the copy has the content of that version rather
+// than the one of the latest, and that the version copied is echoed in
+// x-amz-copy-source-version-id, as is the latest one when no version is
+// given. Copying a delete marker by its version ID must be rejected, and
+// so must copying the object once a delete marker is its latest version,
+// while its older versions can still be copied.
+func testCopyObjectVersion() {
+	startTime := time.Now()
+	function := "testCopyObjectVersion"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), bucketPrefix)
+	object := "testObject"
+	copied := "copiedObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err = s3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket:                  aws.String(bucket),
+		VersioningConfiguration: &s3.VersioningConfiguration{Status: aws.String(s3.BucketVersioningStatusEnabled)},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		return
+	}
+
+	contents := []string{"old version", "latest version"}
+	var versionIDs []string
+	for _, content := range contents {
+		output, err := s3Client.PutObject(&s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader(content)),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+		versionIDs = append(versionIDs, aws.StringValue(output.VersionId))
+	}
+	args["versionIds"] = versionIDs
+
+	// copyVersion copies the version of object, the latest without an ID
+	copyVersion := func(versionID string) (*s3.CopyObjectOutput, error) {
+		source := bucket + "/" + object
+		if versionID != "" {
+			source += "?versionId=" + versionID
+		}
+		return s3Client.CopyObject(&s3.CopyObjectInput{
+			Bucket:     aws.String(bucket),
+			Key:        aws.String(copied),
+			CopySource: aws.String(source),
+		})
+	}
+
+	for _, testCase := range []struct {
+		versionID string
+		content   string
+		echoed    string
+	}{
+		{versionIDs[0], contents[0], versionIDs[0]},
+		{"", contents[1], versionIDs[1]},
+	} {
+		output, err := copyVersion(testCase.versionID)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CopyObject of version %q expected to succeed but got %v", testCase.versionID, err), err).Fatal()
+			return
+		}
+		if echoed := aws.StringValue(output.CopySourceVersionId); echoed != testCase.echoed {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CopyObject of version %q expected x-amz-copy-source-version-id %s but got %q", testCase.versionID, testCase.echoed, echoed), nil).Fatal()
+			return
+		}
+		if aws.StringValue(output.VersionId) == "" {
+			mintest.FailureLog(function, args, startTime, "", "CopyObject expected to return the version ID of the copy", nil).Fatal()
+			return
+		}
+		content, err := getContent(bucket, copied)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+		if content != testCase.content {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Copy of version %q expected content %q but got %q", testCase.versionID, testCase.content, content), nil).Fatal()
+			return
+		}
+	}
+
+	deleteOutput, err := s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Delete expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	deleteMarker := aws.StringValue(deleteOutput.VersionId)
+	args["deleteMarker"] = deleteMarker
+
+	// AWS answers 400 InvalidRequest, MinIO 405 as it does to GETs
+	_, err = copyVersion(deleteMarker)
+	if aerr, ok := err.(awserr.Error); !ok || (aerr.Code() != "InvalidRequest" && aerr.Code() != "MethodNotAllowed") {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CopyObject of a delete marker expected InvalidRequest or MethodNotAllowed but got %v", err), err).Fatal()
+		return
+	}
+	_, err = copyVersion("")
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "NoSuchKey" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CopyObject of an object deleted expected NoSuchKey but got %v", err), err).Fatal()
+		return
+	}
+	output, err := copyVersion(versionIDs[0])
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CopyObject of a version behind a delete marker expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	if echoed := aws.StringValue(output.CopySourceVersionId); echoed != versionIDs[0] {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CopyObject expected x-amz-copy-source-version-id %s but got %q", versionIDs[0], echoed), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
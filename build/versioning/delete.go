@@ -24,17 +24,19 @@ import (
 	"io/ioutil"
 	"math/rand"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
 )
 
 func testDeleteObject() {
 	startTime := time.Now()
 	function := "testDeleteObject"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	objectContent := "my object content"
 	expiry := 1 * time.Minute
@@ -48,7 +50,7 @@ func testDeleteObject() {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucket, function, args, startTime)
@@ -66,7 +68,7 @@ func testDeleteObject() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
 		return
 	}
 
@@ -78,7 +80,7 @@ func testDeleteObject() {
 
 	putOutput, err := s3Client.PutObject(putInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
@@ -89,7 +91,7 @@ func testDeleteObject() {
 	}
 	delOutput, err := s3Client.DeleteObject(deleteInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("Delete expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Delete expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
@@ -102,17 +104,17 @@ func testDeleteObject() {
 
 	result, err := s3Client.GetObject(getInput)
 	if err == nil {
-		failureLog(function, args, startTime, "", "GetObject expected to fail but succeeded", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "GetObject expected to fail but succeeded", nil).Fatal()
 		return
 	}
 	if err != nil {
 		aerr, ok := err.(awserr.Error)
 		if !ok {
-			failureLog(function, args, startTime, "", "GetObject unexpected error with delete marker", err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "GetObject unexpected error with delete marker", err).Fatal()
 			return
 		}
 		if aerr.Code() != "MethodNotAllowed" {
-			failureLog(function, args, startTime, "", "GetObject unexpected error with delete marker", err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "GetObject unexpected error with delete marker", err).Fatal()
 			return
 		}
 	}
@@ -126,19 +128,19 @@ func testDeleteObject() {
 
 	result, err = s3Client.GetObject(getInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("GetObject expected to succeed but failed with %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject expected to succeed but failed with %v", err), err).Fatal()
 		return
 	}
 
 	body, err := ioutil.ReadAll(result.Body)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("GetObject expected to return data but failed with %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject expected to return data but failed with %v", err), err).Fatal()
 		return
 	}
 	result.Body.Close()
 
 	if string(body) != objectContent {
-		failureLog(function, args, startTime, "", "GetObject unexpected body content", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "GetObject unexpected body content", nil).Fatal()
 		return
 	}
 
@@ -150,7 +152,7 @@ func testDeleteObject() {
 		}
 		_, err := s3Client.DeleteObject(delInput)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("DeleteObject (%d) expected to succeed but failed", i+1), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObject (%d) expected to succeed but failed", i+1), err).Fatal()
 			return
 		}
 	}
@@ -161,23 +163,23 @@ func testDeleteObject() {
 
 	listOutput, err := s3Client.ListObjectVersions(listInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
 	if len(listOutput.DeleteMarkers) != 0 || len(listOutput.CommonPrefixes) != 0 || len(listOutput.Versions) != 0 {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned some entries but expected to return nothing", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned some entries but expected to return nothing", nil).Fatal()
 		return
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 // Test deletion using multi delete API
 func testDeleteObjects() {
 	startTime := time.Now()
 	function := "testDeleteObjects"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	objectContent := "my object content"
 	expiry := 1 * time.Minute
@@ -191,7 +193,7 @@ func testDeleteObjects() {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucket, function, args, startTime)
@@ -209,7 +211,7 @@ func testDeleteObjects() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
 		return
 	}
 
@@ -221,7 +223,7 @@ func testDeleteObjects() {
 
 	_, err = s3Client.PutObject(putInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
@@ -240,7 +242,7 @@ func testDeleteObjects() {
 
 	_, err = s3Client.DeleteObjects(deleteInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("Delete expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Delete expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
@@ -250,11 +252,11 @@ func testDeleteObjects() {
 	}
 	listOutput, err := s3Client.ListObjectVersions(listInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 	if len(listOutput.DeleteMarkers) != 1 || len(listOutput.Versions) != 1 {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected result", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected result", nil).Fatal()
 		return
 	}
 
@@ -277,7 +279,7 @@ func testDeleteObjects() {
 	}
 	_, err = s3Client.DeleteObjects(deleteInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("Delete expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Delete expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
@@ -287,13 +289,518 @@ func testDeleteObjects() {
 	}
 	listOutput, err = s3Client.ListObjectVersions(listInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 	if len(listOutput.DeleteMarkers) != 0 || len(listOutput.Versions) != 0 {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned non empty result", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned non empty result", nil).Fatal()
 		return
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Batch delete 1000 keys, the most a single request accepts, half of them
+// reporting each deleted key and the other half in quiet mode, which must
+// only report errors. A batch of more keys must be rejected.
+func testDeleteObjectsBatch() {
+	startTime := time.Now()
+	function := "testDeleteObjectsBatch"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	numObjects := 1000
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"numObjects": numObjects,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	objects := make([]*s3.ObjectIdentifier, numObjects)
+	errs := make([]error, numObjects)
+	var wg sync.WaitGroup
+	for w := 0; w < 16; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < numObjects; i += 16 {
+				objects[i] = &s3.ObjectIdentifier{Key: aws.String(fmt.Sprintf("prefix/object-%04d", i))}
+				_, errs[i] = s3Client.PutObject(&s3.PutObjectInput{
+					Body:   aws.ReadSeekCloser(strings.NewReader("content")),
+					Bucket: aws.String(bucket),
+					Key:    objects[i].Key,
+				})
+			}
+		}(w)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+	}
+
+	// One key too many
+	tooMany := append(objects[:numObjects:numObjects], &s3.ObjectIdentifier{Key: aws.String("prefix/one-too-many")})
+	_, err = s3Client.DeleteObjects(&s3.DeleteObjectsInput{
+		Bucket: aws.String(bucket),
+		Delete: &s3.Delete{Objects: tooMany},
+	})
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "MalformedXML" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects of %d keys expected to fail with MalformedXML", len(tooMany)), err).Fatal()
+		return
+	}
+
+	half := numObjects / 2
+	output, err := s3Client.DeleteObjects(&s3.DeleteObjectsInput{
+		Bucket: aws.String(bucket),
+		Delete: &s3.Delete{Objects: objects[:half]},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	if len(output.Deleted) != half || len(output.Errors) != 0 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects expected %d deleted keys and no errors but got %d and %d", half, len(output.Deleted), len(output.Errors)), nil).Fatal()
+		return
+	}
+	deleted := make(map[string]bool)
+	for _, d := range output.Deleted {
+		deleted[aws.StringValue(d.Key)] = true
+	}
+	for _, o := range objects[:half] {
+		if !deleted[aws.StringValue(o.Key)] {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects did not report %s as deleted", aws.StringValue(o.Key)), nil).Fatal()
+			return
+		}
+	}
+
+	output, err = s3Client.DeleteObjects(&s3.DeleteObjectsInput{
+		Bucket: aws.String(bucket),
+		Delete: &s3.Delete{Objects: objects[half:], Quiet: aws.Bool(true)},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects in quiet mode expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	if len(output.Deleted) != 0 || len(output.Errors) != 0 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects in quiet mode expected no entries but got %d deleted keys and %d errors", len(output.Deleted), len(output.Errors)), nil).Fatal()
+		return
+	}
+
+	listOutput, err := s3Client.ListObjectsV2(&s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectsV2 expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	if len(listOutput.Contents) != 0 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectsV2 expected no objects after DeleteObjects but got %d", len(listOutput.Contents)), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Batch delete versions of which one is under governance retention. The
+// locked one must be reported as an error, in quiet mode as well, while the
+// others are deleted, and it can only be deleted by bypassing retention.
+func testDeleteObjectsLocked() {
+	startTime := time.Now()
+	function := "testDeleteObjectsLocked"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	lockedObject := "lockedObject"
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName":   bucket,
+		"objectName":   object,
+		"lockedObject": lockedObject,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket:                     aws.String(bucket),
+		ObjectLockEnabledForBucket: aws.Bool(true),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "NotImplemented: A header you provided implies functionality that is not implemented") {
+			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
+			return
+		}
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	lockedOutput, err := s3Client.PutObject(&s3.PutObjectInput{
+		Body:                      aws.ReadSeekCloser(strings.NewReader("locked content")),
+		Bucket:                    aws.String(bucket),
+		Key:                       aws.String(lockedObject),
+		ObjectLockMode:            aws.String(s3.ObjectLockModeGovernance),
+		ObjectLockRetainUntilDate: aws.Time(time.Now().UTC().Add(time.Hour)),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	lockedVersion := &s3.ObjectIdentifier{Key: aws.String(lockedObject), VersionId: lockedOutput.VersionId}
+
+	for _, quiet := range []bool{false, true} {
+		putOutput, err := s3Client.PutObject(&s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader("content")),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+
+		output, err := s3Client.DeleteObjects(&s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &s3.Delete{
+				Objects: []*s3.ObjectIdentifier{
+					{Key: aws.String(object), VersionId: putOutput.VersionId},
+					lockedVersion,
+				},
+				Quiet: aws.Bool(quiet),
+			},
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+		expectedDeleted := 1
+		if quiet {
+			expectedDeleted = 0
+		}
+		if len(output.Deleted) != expectedDeleted || len(output.Errors) != 1 {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects with quiet %v expected %d deleted keys and 1 error but got %d and %d",
+				quiet, expectedDeleted, len(output.Deleted), len(output.Errors)), nil).Fatal()
+			return
+		}
+		if !quiet && (aws.StringValue(output.Deleted[0].Key) != object || aws.StringValue(output.Deleted[0].VersionId) != aws.StringValue(putOutput.VersionId)) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects expected to delete version %s of %s but got %v",
+				aws.StringValue(putOutput.VersionId), object, output.Deleted[0]), nil).Fatal()
+			return
+		}
+		// AWS reports AccessDenied, MinIO InvalidRequest
+		deleteError := output.Errors[0]
+		if code := aws.StringValue(deleteError.Code); aws.StringValue(deleteError.Key) != lockedObject ||
+			aws.StringValue(deleteError.VersionId) != aws.StringValue(lockedOutput.VersionId) || (code != "AccessDenied" && code != "InvalidRequest") {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects expected an error for locked version %s of %s but got %v",
+				aws.StringValue(lockedOutput.VersionId), lockedObject, deleteError), nil).Fatal()
+			return
+		}
+	}
+
+	output, err := s3Client.DeleteObjects(&s3.DeleteObjectsInput{
+		Bucket:                    aws.String(bucket),
+		BypassGovernanceRetention: aws.Bool(true),
+		Delete:                    &s3.Delete{Objects: []*s3.ObjectIdentifier{lockedVersion}},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects bypassing governance retention expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	if len(output.Deleted) != 1 || len(output.Errors) != 0 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects bypassing governance retention expected 1 deleted key and no errors but got %d and %d",
+			len(output.Deleted), len(output.Errors)), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Batch delete a version under governance retention and one under
+// compliance retention, without and with x-amz-bypass-governance-retention.
+// Without bypass both must be reported as errors, with bypass only the one
+// under compliance retention, which nothing can delete before it expires.
+// Each error must carry the key, the version ID, a code and a message.
+func testDeleteObjectsRetentionModes() {
+	startTime := time.Now()
+	function := "testDeleteObjectsRetentionModes"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	governanceObject := "governanceObject"
+	complianceObject := "complianceObject"
+	args := map[string]interface{}{
+		"bucketName":       bucket,
+		"governanceObject": governanceObject,
+		"complianceObject": complianceObject,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket:                     aws.String(bucket),
+		ObjectLockEnabledForBucket: aws.Bool(true),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "NotImplemented: A header you provided implies functionality that is not implemented") {
+			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
+			return
+		}
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	versions := map[string]*s3.ObjectIdentifier{}
+	for object, mode := range map[string]string{
+		governanceObject: s3.ObjectLockModeGovernance,
+		complianceObject: s3.ObjectLockModeCompliance,
+	} {
+		output, err := s3Client.PutObject(&s3.PutObjectInput{
+			Body:                      aws.ReadSeekCloser(strings.NewReader("locked content")),
+			Bucket:                    aws.String(bucket),
+			Key:                       aws.String(object),
+			ObjectLockMode:            aws.String(mode),
+			ObjectLockRetainUntilDate: aws.Time(time.Now().UTC().Add(time.Minute)),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+		versions[object] = &s3.ObjectIdentifier{Key: aws.String(object), VersionId: output.VersionId}
+	}
+	args["versionIds"] = map[string]string{
+		governanceObject: aws.StringValue(versions[governanceObject].VersionId),
+		complianceObject: aws.StringValue(versions[complianceObject].VersionId),
+	}
+
+	for _, bypass := range []bool{false, true} {
+		output, err := s3Client.DeleteObjects(&s3.DeleteObjectsInput{
+			Bucket:                    aws.String(bucket),
+			BypassGovernanceRetention: aws.Bool(bypass),
+			Delete: &s3.Delete{
+				Objects: []*s3.ObjectIdentifier{versions[governanceObject], versions[complianceObject]},
+			},
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects with bypass %v expected to succeed but got %v", bypass, err), err).Fatal()
+			return
+		}
+
+		lockedObjects := []string{governanceObject, complianceObject}
+		if bypass {
+			lockedObjects = []string{complianceObject}
+		}
+		if len(output.Deleted) != 2-len(lockedObjects) || len(output.Errors) != len(lockedObjects) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects with bypass %v expected %d deleted keys and %d errors but got %d and %d",
+				bypass, 2-len(lockedObjects), len(lockedObjects), len(output.Deleted), len(output.Errors)), nil).Fatal()
+			return
+		}
+		if bypass && (aws.StringValue(output.Deleted[0].Key) != governanceObject ||
+			aws.StringValue(output.Deleted[0].VersionId) != aws.StringValue(versions[governanceObject].VersionId)) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects bypassing governance retention expected to delete %s but got %v",
+				governanceObject, output.Deleted[0]), nil).Fatal()
+			return
+		}
+
+		reported := map[string]bool{}
+		for _, deleteError := range output.Errors {
+			key := aws.StringValue(deleteError.Key)
+			version, ok := versions[key]
+			// AWS reports AccessDenied, MinIO InvalidRequest
+			if code := aws.StringValue(deleteError.Code); !ok || reported[key] ||
+				aws.StringValue(deleteError.VersionId) != aws.StringValue(version.VersionId) ||
+				(code != "AccessDenied" && code != "InvalidRequest") || aws.StringValue(deleteError.Message) == "" {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects with bypass %v got an unexpected error %v", bypass, deleteError), nil).Fatal()
+				return
+			}
+			reported[key] = true
+		}
+		for _, object := range lockedObjects {
+			if !reported[object] {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects with bypass %v expected an error for %s but got %v",
+					bypass, object, output.Errors), nil).Fatal()
+				return
+			}
+		}
+	}
+
+	// The version under compliance retention must have survived both
+	_, err = s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(complianceObject),
+		VersionId: versions[complianceObject].VersionId,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HEAD of the version under compliance retention expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Batch delete in a versioned bucket: keys without a version ID must get
+// a delete marker, while versions and delete markers given by ID must be
+// removed for good.
+func testDeleteObjectsVersions() {
+	startTime := time.Now()
+	function := "testDeleteObjectsVersions"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	objects := []string{"testObject1", "testObject2"}
+	args := map[string]interface{}{
+		"bucketName":  bucket,
+		"objectNames": objects,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err = s3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket: aws.String(bucket),
+		VersioningConfiguration: &s3.VersioningConfiguration{
+			Status: aws.String("Enabled"),
+		},
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "NotImplemented: A header you provided implies functionality that is not implemented") {
+			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
+			return
+		}
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		return
+	}
+
+	// Two versions of each object
+	versions := make(map[string][]string)
+	for _, object := range objects {
+		for i := 0; i < 2; i++ {
+			output, err := s3Client.PutObject(&s3.PutObjectInput{
+				Body:   aws.ReadSeekCloser(strings.NewReader(fmt.Sprintf("content %d", i))),
+				Bucket: aws.String(bucket),
+				Key:    aws.String(object),
+			})
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+				return
+			}
+			versions[object] = append(versions[object], aws.StringValue(output.VersionId))
+		}
+	}
+
+	// Without version IDs, delete markers are created
+	var identifiers []*s3.ObjectIdentifier
+	for _, object := range objects {
+		identifiers = append(identifiers, &s3.ObjectIdentifier{Key: aws.String(object)})
+	}
+	output, err := s3Client.DeleteObjects(&s3.DeleteObjectsInput{
+		Bucket: aws.String(bucket),
+		Delete: &s3.Delete{Objects: identifiers},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	if len(output.Deleted) != len(objects) || len(output.Errors) != 0 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects expected %d deleted keys and no errors but got %d and %d", len(objects), len(output.Deleted), len(output.Errors)), nil).Fatal()
+		return
+	}
+	markers := make(map[string]string)
+	for _, d := range output.Deleted {
+		if !aws.BoolValue(d.DeleteMarker) || aws.StringValue(d.DeleteMarkerVersionId) == "" || aws.StringValue(d.VersionId) != "" {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects without version ID expected to create a delete marker but got %v", d), nil).Fatal()
+			return
+		}
+		markers[aws.StringValue(d.Key)] = aws.StringValue(d.DeleteMarkerVersionId)
+	}
+
+	listOutput, err := s3Client.ListObjectVersions(&s3.ListObjectVersionsInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	if len(listOutput.DeleteMarkers) != len(objects) || len(listOutput.Versions) != 2*len(objects) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected %d delete markers and %d versions but got %d and %d",
+			len(objects), 2*len(objects), len(listOutput.DeleteMarkers), len(listOutput.Versions)), nil).Fatal()
+		return
+	}
+	for _, m := range listOutput.DeleteMarkers {
+		if !aws.BoolValue(m.IsLatest) || markers[aws.StringValue(m.Key)] != aws.StringValue(m.VersionId) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions returned unexpected delete marker %v", m), nil).Fatal()
+			return
+		}
+	}
+
+	// With version IDs, the first version of the first object and the
+	// delete marker of the second one are removed
+	output, err = s3Client.DeleteObjects(&s3.DeleteObjectsInput{
+		Bucket: aws.String(bucket),
+		Delete: &s3.Delete{
+			Objects: []*s3.ObjectIdentifier{
+				{Key: aws.String(objects[0]), VersionId: aws.String(versions[objects[0]][0])},
+				{Key: aws.String(objects[1]), VersionId: aws.String(markers[objects[1]])},
+			},
+		},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	if len(output.Deleted) != 2 || len(output.Errors) != 0 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects expected 2 deleted keys and no errors but got %d and %d", len(output.Deleted), len(output.Errors)), nil).Fatal()
+		return
+	}
+	for _, d := range output.Deleted {
+		switch aws.StringValue(d.Key) {
+		case objects[0]:
+			if aws.BoolValue(d.DeleteMarker) || aws.StringValue(d.VersionId) != versions[objects[0]][0] {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects expected to remove version %s but got %v", versions[objects[0]][0], d), nil).Fatal()
+				return
+			}
+		case objects[1]:
+			// Not all servers flag the removed version as a delete
+			// marker, the listing below checks it is gone.
+			if aws.StringValue(d.VersionId) != markers[objects[1]] {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects expected to remove delete marker %s but got %v", markers[objects[1]], d), nil).Fatal()
+				return
+			}
+		}
+	}
+
+	// The second object is visible again, from its latest version
+	getOutput, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(objects[1]),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET after removing the delete marker expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	getOutput.Body.Close()
+	if aws.StringValue(getOutput.VersionId) != versions[objects[1]][1] {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET after removing the delete marker expected version %s but got %s",
+			versions[objects[1]][1], aws.StringValue(getOutput.VersionId)), nil).Fatal()
+		return
+	}
+
+	listOutput, err = s3Client.ListObjectVersions(&s3.ListObjectVersionsInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	if len(listOutput.DeleteMarkers) != 1 || len(listOutput.Versions) != 3 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected 1 delete marker and 3 versions but got %d and %d",
+			len(listOutput.DeleteMarkers), len(listOutput.Versions)), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
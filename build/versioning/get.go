@@ -23,12 +23,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math/rand"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
 )
 
 // testGetObject tests all get object features - picking a particular
@@ -36,7 +38,7 @@ import (
 func testGetObject() {
 	startTime := time.Now()
 	function := "testGetObject"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	expiry := 1 * time.Minute
 	args := map[string]interface{}{
@@ -49,7 +51,7 @@ func testGetObject() {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucket, function, args, startTime)
@@ -67,7 +69,7 @@ func testGetObject() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
 		return
 	}
 
@@ -78,7 +80,7 @@ func testGetObject() {
 	}
 	_, err = s3Client.PutObject(putInput1)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 	putInput2 := &s3.PutObjectInput{
@@ -88,7 +90,7 @@ func testGetObject() {
 	}
 	_, err = s3Client.PutObject(putInput2)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
@@ -99,7 +101,7 @@ func testGetObject() {
 
 	_, err = s3Client.DeleteObject(deleteInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("Delete expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Delete expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
@@ -109,7 +111,7 @@ func testGetObject() {
 
 	result, err := s3Client.ListObjectVersions(input)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
@@ -132,23 +134,23 @@ func testGetObject() {
 
 		result, err := s3Client.GetObject(getInput)
 		if testCase.deleteMarker && err == nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("GetObject(%d) expected to fail but succeeded", i+1), nil).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject(%d) expected to fail but succeeded", i+1), nil).Fatal()
 			return
 		}
 
 		if !testCase.deleteMarker && err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("GetObject(%d) expected to succeed but failed", i+1), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject(%d) expected to succeed but failed", i+1), err).Fatal()
 			return
 		}
 
 		if testCase.deleteMarker {
 			aerr, ok := err.(awserr.Error)
 			if !ok {
-				failureLog(function, args, startTime, "", fmt.Sprintf("GetObject(%d) unexpected error with delete marker", i+1), err).Fatal()
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject(%d) unexpected error with delete marker", i+1), err).Fatal()
 				return
 			}
 			if aerr.Code() != "MethodNotAllowed" {
-				failureLog(function, args, startTime, "", fmt.Sprintf("GetObject(%d) unexpected error with delete marker", i+1), err).Fatal()
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject(%d) unexpected error with delete marker", i+1), err).Fatal()
 				return
 			}
 			continue
@@ -156,16 +158,178 @@ func testGetObject() {
 
 		body, err := ioutil.ReadAll(result.Body)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("GetObject(%d) expected to return data but failed", i+1), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject(%d) expected to return data but failed", i+1), err).Fatal()
 			return
 		}
 		result.Body.Close()
 
 		if string(body) != testCase.content {
-			failureLog(function, args, startTime, "", fmt.Sprintf("GetObject(%d) unexpected body content", i+1), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject(%d) unexpected body content", i+1), err).Fatal()
 			return
 		}
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// testGetObjectVersions uploads several versions of an object, then GETs
+// and HEADs each of them by version id checking their own content is
+// returned. Reading a delete marker version must fail with
+// MethodNotAllowed and reading an unknown version with NoSuchVersion.
+func testGetObjectVersions() {
+	startTime := time.Now()
+	function := "testGetObjectVersions"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err = s3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket: aws.String(bucket),
+		VersioningConfiguration: &s3.VersioningConfiguration{
+			Status: aws.String("Enabled"),
+		},
+	})
+	if err != nil {
+		if mintest.IsNotImplemented(err) {
+			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
+			return
+		}
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		return
+	}
+
+	// Versions of growing sizes, so that lengths differ as well
+	contents := make(map[string]string)
+	for i := 1; i <= 5; i++ {
+		content := strings.Repeat(fmt.Sprintf("content of version %d\n", i), i)
+		output, err := s3Client.PutObject(&s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader(content)),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+		if _, ok := contents[aws.StringValue(output.VersionId)]; ok || aws.StringValue(output.VersionId) == "" {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT returned an empty or reused version id %q", aws.StringValue(output.VersionId)), nil).Fatal()
+			return
+		}
+		contents[*output.VersionId] = content
+	}
+
+	deleteOutput, err := s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Delete expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	deleteMarker := aws.StringValue(deleteOutput.VersionId)
+
+	for versionID, content := range contents {
+		getOutput, err := s3Client.GetObject(&s3.GetObjectInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			VersionId: aws.String(versionID),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject of version %s expected to succeed but got %v", versionID, err), err).Fatal()
+			return
+		}
+		body, err := ioutil.ReadAll(getOutput.Body)
+		getOutput.Body.Close()
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject of version %s expected to return data but failed", versionID), err).Fatal()
+			return
+		}
+		if string(body) != content || aws.StringValue(getOutput.VersionId) != versionID {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject of version %s returned version %s with unexpected content", versionID, aws.StringValue(getOutput.VersionId)), nil).Fatal()
+			return
+		}
+
+		headOutput, err := s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			VersionId: aws.String(versionID),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HeadObject of version %s expected to succeed but got %v", versionID, err), err).Fatal()
+			return
+		}
+		if aws.StringValue(headOutput.VersionId) != versionID || aws.Int64Value(headOutput.ContentLength) != int64(len(content)) ||
+			aws.StringValue(headOutput.ETag) != aws.StringValue(getOutput.ETag) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HeadObject of version %s returned version %s of %d bytes with ETag %s, expected %d bytes with ETag %s",
+				versionID, aws.StringValue(headOutput.VersionId), aws.Int64Value(headOutput.ContentLength), aws.StringValue(headOutput.ETag),
+				len(content), aws.StringValue(getOutput.ETag)), nil).Fatal()
+			return
+		}
+	}
+
+	_, err = s3Client.GetObject(&s3.GetObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(object),
+		VersionId: aws.String(deleteMarker),
+	})
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "MethodNotAllowed" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject of a delete marker expected MethodNotAllowed but got %v", err), err).Fatal()
+		return
+	}
+	// HEAD responses have no body, so only their status is checked
+	_, err = s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(object),
+		VersionId: aws.String(deleteMarker),
+	})
+	if rerr, ok := err.(awserr.RequestFailure); !ok || rerr.StatusCode() != http.StatusMethodNotAllowed {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HeadObject of a delete marker expected 405 Method Not Allowed but got %v", err), err).Fatal()
+		return
+	}
+
+	// A well formed version id which was never created
+	unknownVersion := "00000000-0000-0000-0000-000000000000"
+	_, err = s3Client.GetObject(&s3.GetObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(object),
+		VersionId: aws.String(unknownVersion),
+	})
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "NoSuchVersion" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject of an unknown version expected NoSuchVersion but got %v", err), err).Fatal()
+		return
+	}
+	_, err = s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(object),
+		VersionId: aws.String(unknownVersion),
+	})
+	if rerr, ok := err.(awserr.RequestFailure); !ok || rerr.StatusCode() != http.StatusNotFound {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HeadObject of an unknown version expected 404 Not Found but got %v", err), err).Fatal()
+		return
+	}
+
+	// A malformed version id, which AWS rejects as an invalid argument
+	_, err = s3Client.GetObject(&s3.GetObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(object),
+		VersionId: aws.String("not-a-version-id"),
+	})
+	if aerr, ok := err.(awserr.Error); !ok || (aerr.Code() != "NoSuchVersion" && aerr.Code() != "InvalidArgument") {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject of an invalid version expected NoSuchVersion or InvalidArgument but got %v", err), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
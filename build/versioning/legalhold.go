@@ -26,16 +26,18 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
 )
 
 // Test locking for different versions
 func testLockingLegalhold() {
 	startTime := time.Now()
 	function := "testLockingLegalhold"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	expiry := 1 * time.Minute
 	args := map[string]interface{}{
@@ -53,7 +55,7 @@ func testLockingLegalhold() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucket, function, args, startTime)
@@ -80,7 +82,7 @@ func testLockingLegalhold() {
 		}
 		output, err := s3Client.PutObject(putInput)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 		uploads[i].versionId = *output.VersionId
@@ -94,7 +96,7 @@ func testLockingLegalhold() {
 	}
 	deleteOutput, err := s3Client.DeleteObject(deleteInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
@@ -112,11 +114,11 @@ func testLockingLegalhold() {
 		}
 		_, err = s3Client.DeleteObject(deleteInput)
 		if err == nil && uploads[i].legalhold == "ON" {
-			failureLog(function, args, startTime, "", "DELETE expected to fail but succeed instead", nil).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "DELETE expected to fail but succeed instead", nil).Fatal()
 			return
 		}
 		if err != nil && uploads[i].legalhold == "OFF" {
-			failureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 	}
@@ -132,7 +134,7 @@ func testLockingLegalhold() {
 		}
 		_, err := s3Client.GetObjectLegalHold(input)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("GetObjectLegalHold expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObjectLegalHold expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 	}
@@ -149,7 +151,7 @@ func testLockingLegalhold() {
 		}
 		_, err := s3Client.PutObjectLegalHold(input)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("Turning off legalhold failed with %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Turning off legalhold failed with %v", err), err).Fatal()
 			return
 		}
 	}
@@ -168,7 +170,7 @@ func testLockingLegalhold() {
 			// legalhold = ""    => The specified method is not allowed against this resource.
 			_, err := s3Client.GetObjectLegalHold(input)
 			if err == nil {
-				failureLog(function, args, startTime, "", fmt.Sprintf("GetObjectLegalHold expected to fail but got %v", err), err).Fatal()
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObjectLegalHold expected to fail but got %v", err), err).Fatal()
 				return
 			}
 		}
@@ -178,7 +180,7 @@ func testLockingLegalhold() {
 	creds := credentials.NewStaticCredentials("test", "test", "")
 	newSession, err := session.NewSession()
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("NewSession expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("NewSession expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 	s3Config := s3Client.Config
@@ -193,7 +195,7 @@ func testLockingLegalhold() {
 	// The Access Key Id you provided does not exist in our records.
 	_, err = s3ClientTest.GetObjectLegalHold(input)
 	if err == nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("GetObjectLegalHold expected to fail but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObjectLegalHold expected to fail but got %v", err), err).Fatal()
 		return
 	}
 
@@ -204,7 +206,7 @@ func testLockingLegalhold() {
 		ObjectLockEnabledForBucket: aws.Bool(false),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucketWithoutLock, function, args, startTime)
@@ -216,7 +218,7 @@ func testLockingLegalhold() {
 	// Bucket is missing ObjectLockConfiguration
 	_, err = s3Client.GetObjectLegalHold(input)
 	if err == nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("GetObjectLegalHold expected to fail but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObjectLegalHold expected to fail but got %v", err), err).Fatal()
 		return
 	}
 
@@ -232,7 +234,7 @@ func testLockingLegalhold() {
 		// The Access Key Id you provided does not exist in our records.
 		_, err := s3ClientTest.PutObjectLegalHold(input)
 		if err == nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("Turning off legalhold expected to fail but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Turning off legalhold expected to fail but got %v", err), err).Fatal()
 			return
 		}
 	}
@@ -249,7 +251,7 @@ func testLockingLegalhold() {
 		// Bucket is missing ObjectLockConfiguration
 		_, err := s3Client.PutObjectLegalHold(input)
 		if err == nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("Turning off legalhold expected to fail but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Turning off legalhold expected to fail but got %v", err), err).Fatal()
 			return
 		}
 	}
@@ -263,7 +265,7 @@ func testLockingLegalhold() {
 	}
 	output, err := s3Client.PutObject(putInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 	uploads[0].versionId = *output.VersionId
@@ -276,9 +278,204 @@ func testLockingLegalhold() {
 	// We encountered an internal error, please try again.: cause(EOF)
 	_, err = s3Client.PutObjectLegalHold(polhInput)
 	if err == nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("PutObjectLegalHold expected to fail but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PutObjectLegalHold expected to fail but got %v", err), err).Fatal()
 		return
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Turn the legal hold of a version ON and OFF again. While it is ON the
+// version can neither be deleted by DeleteObject nor by DeleteObjects,
+// once it is OFF both must succeed. A bucket without object lock has no
+// legal hold to get or set.
+func testLegalHoldToggle() {
+	startTime := time.Now()
+	function := "testLegalHoldToggle"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket:                     aws.String(bucket),
+		ObjectLockEnabledForBucket: aws.Bool(true),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "NotImplemented: A header you provided implies functionality that is not implemented") {
+			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
+			return
+		}
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	checkLegalHold := func(versionID, status string) bool {
+		output, err := s3Client.GetObjectLegalHold(&s3.GetObjectLegalHoldInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			VersionId: aws.String(versionID),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObjectLegalHold expected to succeed but got %v", err), err).Fatal()
+			return false
+		}
+		if output.LegalHold == nil || aws.StringValue(output.LegalHold.Status) != status {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObjectLegalHold expected status %s but got %v", status, output.LegalHold), nil).Fatal()
+			return false
+		}
+		return true
+	}
+	setLegalHold := func(versionID, status string) bool {
+		_, err := s3Client.PutObjectLegalHold(&s3.PutObjectLegalHoldInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			LegalHold: &s3.ObjectLockLegalHold{Status: aws.String(status)},
+			VersionId: aws.String(versionID),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Turning %s legalhold failed with %v", status, err), err).Fatal()
+			return false
+		}
+		return checkLegalHold(versionID, status)
+	}
+
+	// Two versions, deleted one by one with each delete API
+	var versionIDs []string
+	for i := 0; i < 2; i++ {
+		output, err := s3Client.PutObject(&s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader(fmt.Sprintf("content %d", i))),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+		versionIDs = append(versionIDs, aws.StringValue(output.VersionId))
+	}
+	args["versionIds"] = versionIDs
+
+	for _, versionID := range versionIDs {
+		if !setLegalHold(versionID, s3.ObjectLockLegalHoldStatusOn) {
+			return
+		}
+	}
+
+	// A delete without version ID only adds a delete marker
+	_, err = s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+
+	_, err = s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(object),
+		VersionId: aws.String(versionIDs[0]),
+	})
+	if err == nil {
+		mintest.FailureLog(function, args, startTime, "", "DELETE of a version under legal hold expected to fail but succeed instead", nil).Fatal()
+		return
+	}
+	deleteOutput, err := s3Client.DeleteObjects(&s3.DeleteObjectsInput{
+		Bucket: aws.String(bucket),
+		Delete: &s3.Delete{Objects: []*s3.ObjectIdentifier{{Key: aws.String(object), VersionId: aws.String(versionIDs[1])}}},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	if len(deleteOutput.Deleted) != 0 || len(deleteOutput.Errors) != 1 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects of a version under legal hold expected no deleted keys and 1 error but got %d and %d",
+			len(deleteOutput.Deleted), len(deleteOutput.Errors)), nil).Fatal()
+		return
+	}
+
+	for _, versionID := range versionIDs {
+		if !setLegalHold(versionID, s3.ObjectLockLegalHoldStatusOff) {
+			return
+		}
+	}
+
+	_, err = s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(object),
+		VersionId: aws.String(versionIDs[0]),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	deleteOutput, err = s3Client.DeleteObjects(&s3.DeleteObjectsInput{
+		Bucket: aws.String(bucket),
+		Delete: &s3.Delete{Objects: []*s3.ObjectIdentifier{{Key: aws.String(object), VersionId: aws.String(versionIDs[1])}}},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	if len(deleteOutput.Deleted) != 1 || len(deleteOutput.Errors) != 0 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DeleteObjects expected 1 deleted key and no errors but got %d and %d",
+			len(deleteOutput.Deleted), len(deleteOutput.Errors)), nil).Fatal()
+		return
+	}
+
+	for _, versionID := range versionIDs {
+		_, err = s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			VersionId: aws.String(versionID),
+		})
+		if err == nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HEAD of deleted version %s expected to fail but succeed instead", versionID), nil).Fatal()
+			return
+		}
+	}
+
+	// Without object lock, legal hold is rejected with InvalidRequest
+	bucketWithoutLock := bucket + "-without-lock"
+	_, err = s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucketWithoutLock),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucketWithoutLock, function, args, startTime)
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader("content")),
+		Bucket: aws.String(bucketWithoutLock),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	_, err = s3Client.GetObjectLegalHold(&s3.GetObjectLegalHoldInput{
+		Bucket: aws.String(bucketWithoutLock),
+		Key:    aws.String(object),
+	})
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "InvalidRequest" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObjectLegalHold without object lock expected to fail with InvalidRequest but got %v", err), err).Fatal()
+		return
+	}
+	_, err = s3Client.PutObjectLegalHold(&s3.PutObjectLegalHoldInput{
+		Bucket:    aws.String(bucketWithoutLock),
+		Key:       aws.String(object),
+		LegalHold: &s3.ObjectLockLegalHold{Status: aws.String(s3.ObjectLockLegalHoldStatusOn)},
+	})
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "InvalidRequest" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PutObjectLegalHold without object lock expected to fail with InvalidRequest but got %v", err), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
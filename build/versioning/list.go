@@ -23,7 +23,9 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"net/url"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
 )
 
 // Test regular listing result with simple use cases:
@@ -39,7 +42,7 @@ import (
 func testListObjectVersionsSimple() {
 	startTime := time.Now()
 	function := "testListObjectVersionsSimple"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	expiry := 1 * time.Minute
 	args := map[string]interface{}{
@@ -52,7 +55,7 @@ func testListObjectVersionsSimple() {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucket, function, args, startTime)
@@ -70,7 +73,7 @@ func testListObjectVersionsSimple() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
 		return
 	}
 
@@ -82,7 +85,7 @@ func testListObjectVersionsSimple() {
 		}
 		_, err = s3Client.PutObject(putInput1)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 	}
@@ -97,7 +100,7 @@ func testListObjectVersionsSimple() {
 	}
 	_, err = s3Client.DeleteObject(deleteInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("Delete expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Delete expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
@@ -106,68 +109,68 @@ func testListObjectVersionsSimple() {
 
 	result, err := s3Client.ListObjectVersions(input)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
 	// Check the delete marker entries
 	if len(result.DeleteMarkers) != 1 {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected result", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected result", nil).Fatal()
 		return
 	}
 	dm := *result.DeleteMarkers[0]
 	if !*dm.IsLatest {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected result", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected result", nil).Fatal()
 		return
 	}
 	if *dm.Key != object {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected result", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected result", nil).Fatal()
 		return
 	}
 	if time.Since(*dm.LastModified) > time.Hour {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected result", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected result", nil).Fatal()
 		return
 	}
 	if *dm.VersionId == "" {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected result", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected result", nil).Fatal()
 		return
 	}
 	versionIDs[*dm.VersionId] = struct{}{}
 
 	// Check versions entries
 	if len(result.Versions) != 10 {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected result", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected result", nil).Fatal()
 		return
 	}
 
 	for _, version := range result.Versions {
 		v := *version
 		if *v.IsLatest {
-			failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected IsLatest field", nil).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected IsLatest field", nil).Fatal()
 			return
 		}
 		if *v.Key != object {
-			failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected Key field", nil).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected Key field", nil).Fatal()
 			return
 		}
 		if time.Since(*v.LastModified) > time.Hour {
-			failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected LastModified field", nil).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected LastModified field", nil).Fatal()
 			return
 		}
 		if *v.VersionId == "" {
-			failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected VersionId field", nil).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected VersionId field", nil).Fatal()
 			return
 		}
 		if !etagRegex.MatchString(*v.ETag) {
-			failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected ETag field", nil).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected ETag field", nil).Fatal()
 			return
 		}
 		if *v.Size != 12 {
-			failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected Size field", nil).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected Size field", nil).Fatal()
 			return
 		}
 		if *v.StorageClass != "STANDARD" {
-			failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected StorageClass field", nil).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected StorageClass field", nil).Fatal()
 			return
 		}
 
@@ -176,7 +179,7 @@ func testListObjectVersionsSimple() {
 
 	// Ensure that we have 11 distinct versions IDs
 	if len(versionIDs) != 11 {
-		failureLog(function, args, startTime, "", "ListObjectVersions didn't return 11 different version IDs", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions didn't return 11 different version IDs", nil).Fatal()
 		return
 	}
 
@@ -189,7 +192,7 @@ func testListObjectVersionsSimple() {
 	}
 	result, err = s3Client.ListObjectVersions(lovInput)
 	if err == nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to fail but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to fail but got %v", err), err).Fatal()
 		return
 	}
 
@@ -197,7 +200,7 @@ func testListObjectVersionsSimple() {
 	lovInput.EncodingType = aws.String("test")
 	result, err = s3Client.ListObjectVersions(lovInput)
 	if err == nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to fail but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to fail but got %v", err), err).Fatal()
 		return
 	}
 
@@ -205,7 +208,7 @@ func testListObjectVersionsSimple() {
 	creds := credentials.NewStaticCredentials("test", "test", "")
 	newSession, err := session.NewSession()
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("NewSession expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("NewSession expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 	s3Config := s3Client.Config
@@ -215,17 +218,17 @@ func testListObjectVersionsSimple() {
 	// Check with a second client: bucket-listobjects-handlers.go > ListObjectVersionsHandler > checkRequestAuthType
 	result, err = s3ClientTest.ListObjectVersions(lovInput)
 	if err == nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to fail but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to fail but got %v", err), err).Fatal()
 		return
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 func testListObjectVersionsWithPrefixAndDelimiter() {
 	startTime := time.Now()
 	function := "testListObjectVersionsWithPrefixAndDelimiter"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	expiry := 1 * time.Minute
 	args := map[string]interface{}{
@@ -238,7 +241,7 @@ func testListObjectVersionsWithPrefixAndDelimiter() {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucket, function, args, startTime)
@@ -256,7 +259,7 @@ func testListObjectVersionsWithPrefixAndDelimiter() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
 		return
 	}
 
@@ -268,7 +271,7 @@ func testListObjectVersionsWithPrefixAndDelimiter() {
 		}
 		_, err = s3Client.PutObject(putInput)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 	}
@@ -298,7 +301,7 @@ func testListObjectVersionsWithPrefixAndDelimiter() {
 	}
 	result, err := s3Client.ListObjectVersions(input)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 	gotResult := simplifyListingResult(result)
@@ -310,7 +313,7 @@ func testListObjectVersionsWithPrefixAndDelimiter() {
 		},
 	}
 	if !reflect.DeepEqual(gotResult, expectedResult) {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", nil).Fatal()
 		return
 	}
 
@@ -321,7 +324,7 @@ func testListObjectVersionsWithPrefixAndDelimiter() {
 	}
 	result, err = s3Client.ListObjectVersions(input)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 	gotResult = simplifyListingResult(result)
@@ -332,7 +335,7 @@ func testListObjectVersionsWithPrefixAndDelimiter() {
 		commonPrefixes: []string{"dir/"},
 	}
 	if !reflect.DeepEqual(gotResult, expectedResult) {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", nil).Fatal()
 		return
 	}
 
@@ -344,7 +347,7 @@ func testListObjectVersionsWithPrefixAndDelimiter() {
 	}
 	result, err = s3Client.ListObjectVersions(input)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 	gotResult = simplifyListingResult(result)
@@ -355,18 +358,18 @@ func testListObjectVersionsWithPrefixAndDelimiter() {
 		commonPrefixes: []string{"dir/dir/"},
 	}
 	if !reflect.DeepEqual(gotResult, expectedResult) {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", nil).Fatal()
 		return
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 // Test if key marker continuation works in listing works well
 func testListObjectVersionsKeysContinuation() {
 	startTime := time.Now()
 	function := "testListObjectKeysContinuation"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	expiry := 1 * time.Minute
 	args := map[string]interface{}{
@@ -379,7 +382,7 @@ func testListObjectVersionsKeysContinuation() {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucket, function, args, startTime)
@@ -397,7 +400,7 @@ func testListObjectVersionsKeysContinuation() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
 		return
 	}
 
@@ -409,7 +412,7 @@ func testListObjectVersionsKeysContinuation() {
 		}
 		_, err = s3Client.PutObject(putInput1)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 	}
@@ -446,12 +449,12 @@ func testListObjectVersionsKeysContinuation() {
 		})
 
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
 	if numPages != 2 {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected number of pages", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected number of pages", nil).Fatal()
 		return
 	}
 
@@ -461,18 +464,18 @@ func testListObjectVersionsKeysContinuation() {
 	}
 
 	if !reflect.DeepEqual(expectedResult, gotResult) {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", fmt.Errorf("want %+v, got %+v", expectedResult, gotResult)).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", fmt.Errorf("want %+v, got %+v", expectedResult, gotResult)).Fatal()
 		return
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 // Test if version id marker continuation works in listing works well
 func testListObjectVersionsVersionIDContinuation() {
 	startTime := time.Now()
 	function := "testListObjectVersionIDContinuation"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	expiry := 1 * time.Minute
 	args := map[string]interface{}{
@@ -485,7 +488,7 @@ func testListObjectVersionsVersionIDContinuation() {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucket, function, args, startTime)
@@ -503,7 +506,7 @@ func testListObjectVersionsVersionIDContinuation() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
 		return
 	}
 
@@ -515,7 +518,7 @@ func testListObjectVersionsVersionIDContinuation() {
 		}
 		_, err = s3Client.PutObject(putInput1)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 	}
@@ -555,12 +558,12 @@ func testListObjectVersionsVersionIDContinuation() {
 		})
 
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
 	if numPages != 2 {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected number of pages", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected number of pages", nil).Fatal()
 		return
 	}
 
@@ -570,18 +573,18 @@ func testListObjectVersionsVersionIDContinuation() {
 	}
 
 	if !reflect.DeepEqual(expectedResult, gotResult) {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", nil).Fatal()
 		return
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 // Test listing object when there is some empty directory object
 func testListObjectsVersionsWithEmptyDirObject() {
 	startTime := time.Now()
 	function := "testListObjectsVersionsWithEmptyDirObject"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	expiry := 1 * time.Minute
 	args := map[string]interface{}{
@@ -594,7 +597,7 @@ func testListObjectsVersionsWithEmptyDirObject() {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucket, function, args, startTime)
@@ -612,7 +615,7 @@ func testListObjectsVersionsWithEmptyDirObject() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
 		return
 	}
 
@@ -624,7 +627,7 @@ func testListObjectsVersionsWithEmptyDirObject() {
 		}
 		_, err = s3Client.PutObject(putInput)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 	}
@@ -660,12 +663,12 @@ func testListObjectsVersionsWithEmptyDirObject() {
 	}
 	result, err := s3Client.ListObjectVersions(input)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 	gotResult, err := simplifyListingResult(result)
 	if err != nil {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", err).Fatal()
 		return
 	}
 	expectedResult := listResult{
@@ -675,7 +678,7 @@ func testListObjectsVersionsWithEmptyDirObject() {
 		},
 	}
 	if !reflect.DeepEqual(gotResult, expectedResult) {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", fmt.Errorf("want %+v, got %+v", expectedResult, gotResult)).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", fmt.Errorf("want %+v, got %+v", expectedResult, gotResult)).Fatal()
 		return
 	}
 
@@ -686,18 +689,18 @@ func testListObjectsVersionsWithEmptyDirObject() {
 	}
 	result, err = s3Client.ListObjectVersions(input)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 	gotResult, err = simplifyListingResult(result)
 	if err != nil {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", err).Fatal()
 	}
 	expectedResult = listResult{
 		commonPrefixes: []string{"dir/"},
 	}
 	if !reflect.DeepEqual(gotResult, expectedResult) {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", nil).Fatal()
 		return
 	}
 
@@ -709,12 +712,12 @@ func testListObjectsVersionsWithEmptyDirObject() {
 	}
 	result, err = s3Client.ListObjectVersions(input)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 	gotResult, err = simplifyListingResult(result)
 	if err != nil {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", err).Fatal()
 		return
 	}
 	expectedResult = listResult{
@@ -724,9 +727,151 @@ func testListObjectsVersionsWithEmptyDirObject() {
 		},
 	}
 	if !reflect.DeepEqual(gotResult, expectedResult) {
-		failureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned unexpected listing result", nil).Fatal()
 		return
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Put two versions of keys which need to be URL encoded, including
+// unicode ones, then list them with encoding-type=url in pages of 3
+// versions. Key, KeyMarker and NextKeyMarker must come URL encoded, and
+// decode back to the keys. Paging on with the decoded NextKeyMarker must
+// list every version exactly once, in order.
+func testListObjectVersionsURLEncoding() {
+	startTime := time.Now()
+	function := "testListObjectVersionsURLEncoding"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	keys := []string{"plain", "with space", "with+plus", "with%20percent", "with&ampersand=equals", "with?query#fragment", "été/accent", "日本語/キー", "emoji-😀", "tilde~star*"}
+	args := map[string]interface{}{
+		"bucketName":   bucket,
+		"objectNames":  keys,
+		"encodingType": s3.EncodingTypeUrl,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err = s3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket: aws.String(bucket),
+		VersioningConfiguration: &s3.VersioningConfiguration{
+			Status: aws.String("Enabled"),
+		},
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "NotImplemented: A header you provided implies functionality that is not implemented") {
+			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
+			return
+		}
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		return
+	}
+
+	// Keys are listed in UTF-8 byte order, the latest version first
+	versionIDs := make(map[string][]string)
+	for i := 0; i < 2; i++ {
+		for _, key := range keys {
+			output, err := s3Client.PutObject(&s3.PutObjectInput{
+				Body:   aws.ReadSeekCloser(strings.NewReader(fmt.Sprintf("content %d", i))),
+				Bucket: aws.String(bucket),
+				Key:    aws.String(key),
+			})
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT of %q expected to succeed but got %v", key, err), err).Fatal()
+				return
+			}
+			versionIDs[key] = append([]string{aws.StringValue(output.VersionId)}, versionIDs[key]...)
+		}
+	}
+	sortedKeys := append([]string{}, keys...)
+	sort.Strings(sortedKeys)
+	var expected []string
+	for _, key := range sortedKeys {
+		for _, versionID := range versionIDs[key] {
+			expected = append(expected, key+" "+versionID)
+		}
+	}
+
+	// decode returns the field of a listing URL decoded, checking that it
+	// was encoded: what is left is plain ASCII without spaces
+	decode := func(name, value string) (string, error) {
+		for _, c := range value {
+			if c <= ' ' || c > '~' {
+				return "", fmt.Errorf("%s %q is not URL encoded", name, value)
+			}
+		}
+		decoded, err := url.QueryUnescape(value)
+		if err != nil {
+			return "", fmt.Errorf("%s %q is not URL encoded: %v", name, value, err)
+		}
+		return decoded, nil
+	}
+
+	var got []string
+	var keyMarker, versionIDMarker string
+	for pages := 0; ; pages++ {
+		if pages > len(expected) {
+			mintest.FailureLog(function, args, startTime, "", "ListObjectVersions did not end after a page per version", errors.New("listing does not end")).Fatal()
+			return
+		}
+		input := &s3.ListObjectVersionsInput{
+			Bucket:       aws.String(bucket),
+			EncodingType: aws.String(s3.EncodingTypeUrl),
+			MaxKeys:      aws.Int64(3),
+		}
+		if keyMarker != "" {
+			input.KeyMarker = aws.String(keyMarker)
+			input.VersionIdMarker = aws.String(versionIDMarker)
+		}
+		output, err := s3Client.ListObjectVersions(input)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions after key marker %q expected to succeed but got %v", keyMarker, err), err).Fatal()
+			return
+		}
+		if aws.StringValue(output.EncodingType) != s3.EncodingTypeUrl {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions expected encoding type %s but got %q", s3.EncodingTypeUrl, aws.StringValue(output.EncodingType)), errors.New("encoding type mismatch")).Fatal()
+			return
+		}
+		echoed, err := decode("KeyMarker", aws.StringValue(output.KeyMarker))
+		if err == nil && echoed != keyMarker {
+			err = fmt.Errorf("KeyMarker %q decodes to %q, expected %q", aws.StringValue(output.KeyMarker), echoed, keyMarker)
+		}
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned an invalid key marker", err).Fatal()
+			return
+		}
+		for _, version := range output.Versions {
+			key, err := decode("Key", aws.StringValue(version.Key))
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned an invalid key", err).Fatal()
+				return
+			}
+			got = append(got, key+" "+aws.StringValue(version.VersionId))
+		}
+		if !aws.BoolValue(output.IsTruncated) {
+			break
+		}
+		if keyMarker, err = decode("NextKeyMarker", aws.StringValue(output.NextKeyMarker)); err == nil && keyMarker == "" {
+			err = errors.New("NextKeyMarker is missing from a truncated listing")
+		}
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "ListObjectVersions returned an invalid next key marker", err).Fatal()
+			return
+		}
+		versionIDMarker = aws.StringValue(output.NextVersionIdMarker)
+	}
+
+	if !reflect.DeepEqual(expected, got) {
+		mintest.FailureLog(function, args, startTime, "", "ListObjectVersions with URL encoding returned unexpected listing result", fmt.Errorf("want %q, got %q", expected, got)).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
@@ -16,117 +16,86 @@
 package main
 
 import (
-	"os"
+	"strings"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	log "github.com/sirupsen/logrus"
+	"mint.minio.io/mintest"
 )
 
+// Prefix of the names of all the buckets created by this suite
+const bucketPrefix = "versioning-test-"
+
 // S3 client for testing
 var s3Client *s3.S3
 
 func cleanupBucket(bucket string, function string, args map[string]interface{}, startTime time.Time) {
 	start := time.Now()
 
-	input := &s3.ListObjectVersionsInput{
-		Bucket: aws.String(bucket),
-	}
-
+	// Objects under compliance retention can only be removed once it expires
 	for time.Since(start) < 30*time.Minute {
-		err := s3Client.ListObjectVersionsPages(input,
-			func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
-				for _, v := range page.Versions {
-					input := &s3.DeleteObjectInput{
-						Bucket:                    &bucket,
-						Key:                       v.Key,
-						VersionId:                 v.VersionId,
-						BypassGovernanceRetention: aws.Bool(true),
-					}
-					_, err := s3Client.DeleteObject(input)
-					if err != nil {
-						return true
-					}
-				}
-				for _, v := range page.DeleteMarkers {
-					input := &s3.DeleteObjectInput{
-						Bucket:                    &bucket,
-						Key:                       v.Key,
-						VersionId:                 v.VersionId,
-						BypassGovernanceRetention: aws.Bool(true),
-					}
-					_, err := s3Client.DeleteObject(input)
-					if err != nil {
-						return true
-					}
-				}
-				return true
-			})
-
-		_, err = s3Client.DeleteBucket(&s3.DeleteBucketInput{
-			Bucket: aws.String(bucket),
-		})
-		if err != nil {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
 			time.Sleep(30 * time.Second)
 			continue
 		}
 		return
 	}
 
-	failureLog(function, args, startTime, "", "Unable to cleanup bucket after compliance tests", nil).Fatal()
+	mintest.FailureLog(function, args, startTime, "", "Unable to cleanup bucket after compliance tests", nil).Fatal()
 	return
 }
 
-func main() {
-	endpoint := os.Getenv("SERVER_ENDPOINT")
-	accessKey := os.Getenv("ACCESS_KEY")
-	secretKey := os.Getenv("SECRET_KEY")
-	secure := os.Getenv("ENABLE_HTTPS")
-	sdkEndpoint := "http://" + endpoint
-	if secure == "1" {
-		sdkEndpoint = "https://" + endpoint
-	}
+// ignoreLog logs the test as not applicable, its alert naming the feature
+// missing as "<Feature> is NotImplemented" like the suite always reported
+func ignoreLog(function string, args map[string]interface{}, startTime time.Time, alert string) *log.Entry {
+	return mintest.IgnoreLog(function, args, startTime, strings.Split(alert, " ")[0]+" is NotImplemented")
+}
 
-	creds := credentials.NewStaticCredentials(accessKey, secretKey, "")
-	newSession := session.New()
-	s3Config := &aws.Config{
-		Credentials:      creds,
-		Endpoint:         aws.String(sdkEndpoint),
-		Region:           aws.String("us-east-1"),
-		S3ForcePathStyle: aws.Bool(true),
-	}
+func main() {
+	runStartTime := time.Now()
+	mintest.Init("versioning")
+	config := mintest.LoadConfig()
 
 	// Create an S3 service object in the default region.
-	s3Client = s3.New(newSession, s3Config)
-
-	// Output to stdout instead of the default stderr
-	log.SetOutput(os.Stdout)
-	// create custom formatter
-	mintFormatter := mintJSONFormatter{}
-	// set custom formatter
-	log.SetFormatter(&mintFormatter)
-	// log Info or above -- success cases are Info level, failures are Fatal level
-	log.SetLevel(log.InfoLevel)
+	s3Client = config.NewS3Client()
+	mintest.TrackBuckets(s3Client)
+	// Remove the buckets left behind, even by a failed test
+	defer mintest.StartJanitor(s3Client)()
 
-	testMakeBucket()
-	testPutObject()
-	testPutObjectWithTaggingAndMetadata()
-	testGetObject()
-	testStatObject()
-	testDeleteObject()
-	testDeleteObjects()
-	testListObjectVersionsSimple()
-	testListObjectVersionsWithPrefixAndDelimiter()
-	testListObjectVersionsKeysContinuation()
-	testListObjectVersionsVersionIDContinuation()
-	testListObjectsVersionsWithEmptyDirObject()
-	testTagging()
-	testLockingLegalhold()
-	testPutGetRetentionCompliance()
-	testPutGetDeleteRetentionGovernance()
-	testLockingRetentionGovernance()
-	testLockingRetentionCompliance()
+	mintest.RunTests([]mintest.Test{
+		{Name: "testMakeBucket", Description: "Enable versioning on a bucket and get its versioning configuration", Requires: []string{mintest.RequiresVersioning}, Run: testMakeBucket},
+		{Name: "testObjectLockBucket", Description: "Create a bucket with object locking and apply its default retention rules", Requires: []string{mintest.RequiresVersioning, mintest.RequiresObjectLock}, Run: testObjectLockBucket},
+		{Name: "testBucketVersioningStatus", Description: "Move versioning between Enabled and Suspended, and reject suspension with object locking and MFA delete", Requires: []string{mintest.RequiresVersioning}, Run: testBucketVersioningStatus},
+		{Name: "testDeleteBucketVersioned", Description: "Check deleting a bucket holding versions or only a delete marker fails with BucketNotEmpty", Requires: []string{mintest.RequiresVersioning}, Run: testDeleteBucketVersioned},
+		{Name: "testPutObject", Description: "Put two versions of an object with different contents", Requires: []string{mintest.RequiresVersioning}, Run: testPutObject},
+		{Name: "testPutObjectWithTaggingAndMetadata", Description: "Put object versions with tagging and metadata and check them", Requires: []string{mintest.RequiresVersioning}, Run: testPutObjectWithTaggingAndMetadata},
+		{Name: "testGetObject", Description: "Get a version of an object by version ID with its content and metadata", Requires: []string{mintest.RequiresVersioning}, Run: testGetObject},
+		{Name: "testGetObjectVersions", Description: "GET and HEAD each version of an object, a delete marker and an unknown version", Requires: []string{mintest.RequiresVersioning}, Run: testGetObjectVersions},
+		{Name: "testCopyObjectVersion", Description: "Copy an older version by version ID, the latest one and a delete marker, checking the source version echoed", Requires: []string{mintest.RequiresVersioning}, Run: testCopyObjectVersion},
+		{Name: "testVersioningSuspended", Description: "Write and delete an object under the null version once versioning is suspended", Requires: []string{mintest.RequiresVersioning}, Run: testVersioningSuspended},
+		{Name: "testStatObject", Description: "HEAD the versions of an object", Requires: []string{mintest.RequiresVersioning}, Run: testStatObject},
+		{Name: "testDeleteObject", Description: "Delete an object and its versions", Requires: []string{mintest.RequiresVersioning}, Run: testDeleteObject},
+		{Name: "testDeleteObjects", Description: "Delete versions with the multi delete API", Requires: []string{mintest.RequiresVersioning}, Run: testDeleteObjects},
+		{Name: "testDeleteObjectsBatch", Description: "Delete 1000 keys in a batch, reporting them or in quiet mode", Requires: []string{mintest.RequiresVersioning}, Run: testDeleteObjectsBatch},
+		{Name: "testDeleteObjectsLocked", Description: "Delete a batch of versions of which one is under governance retention", Requires: []string{mintest.RequiresVersioning, mintest.RequiresObjectLock}, Run: testDeleteObjectsLocked},
+		{Name: "testDeleteObjectsRetentionModes", Description: "Delete a batch of versions under governance and compliance retention, with and without bypass", Requires: []string{mintest.RequiresVersioning, mintest.RequiresObjectLock}, Run: testDeleteObjectsRetentionModes},
+		{Name: "testDeleteObjectsVersions", Description: "Delete a batch of keys, versions and delete markers", Requires: []string{mintest.RequiresVersioning}, Run: testDeleteObjectsVersions},
+		{Name: "testListObjectVersionsSimple", Description: "List the versions and the delete marker of an object", Requires: []string{mintest.RequiresVersioning}, Run: testListObjectVersionsSimple},
+		{Name: "testListObjectVersionsWithPrefixAndDelimiter", Description: "List object versions by prefix and delimiter", Requires: []string{mintest.RequiresVersioning}, Run: testListObjectVersionsWithPrefixAndDelimiter},
+		{Name: "testListObjectVersionsKeysContinuation", Description: "List object versions in pages continued by key marker", Requires: []string{mintest.RequiresVersioning}, Run: testListObjectVersionsKeysContinuation},
+		{Name: "testListObjectVersionsVersionIDContinuation", Description: "List object versions in pages continued by version ID marker", Requires: []string{mintest.RequiresVersioning}, Run: testListObjectVersionsVersionIDContinuation},
+		{Name: "testListObjectVersionsURLEncoding", Description: "List versions of keys needing URL encoding with encoding-type=url, paging on with the decoded markers", Requires: []string{mintest.RequiresVersioning}, Run: testListObjectVersionsURLEncoding},
+		{Name: "testListObjectsVersionsWithEmptyDirObject", Description: "List object versions along with empty directory objects", Requires: []string{mintest.RequiresVersioning}, Run: testListObjectsVersionsWithEmptyDirObject},
+		{Name: "testTagging", Description: "PUT, GET and DELETE the tags of separate versions", Requires: []string{mintest.RequiresVersioning}, Run: testTagging},
+		{Name: "testTaggingMetadataPerVersion", Description: "Set and delete the tags and metadata of each version separately", Requires: []string{mintest.RequiresVersioning}, Run: testTaggingMetadataPerVersion},
+		{Name: "testLockingLegalhold", Description: "Lock versions of an object with a legal hold", Requires: []string{mintest.RequiresVersioning, mintest.RequiresObjectLock}, Run: testLockingLegalhold},
+		{Name: "testLegalHoldToggle", Description: "Turn the legal hold of a version on and off around deletions", Requires: []string{mintest.RequiresVersioning, mintest.RequiresObjectLock}, Run: testLegalHoldToggle},
+		{Name: "testPutGetRetentionCompliance", Description: "PUT and GET the compliance retention of a version", Requires: []string{mintest.RequiresVersioning, mintest.RequiresObjectLock}, Run: testPutGetRetentionCompliance},
+		{Name: "testPutGetDeleteRetentionGovernance", Description: "PUT, GET and DELETE the governance retention of a version", Requires: []string{mintest.RequiresVersioning, mintest.RequiresObjectLock}, Run: testPutGetDeleteRetentionGovernance},
+		{Name: "testLockingRetentionGovernance", Description: "Lock versions under governance retention", Requires: []string{mintest.RequiresVersioning, mintest.RequiresObjectLock}, Run: testLockingRetentionGovernance},
+		{Name: "testLockingRetentionCompliance", Description: "Lock versions under compliance retention", Requires: []string{mintest.RequiresVersioning, mintest.RequiresObjectLock}, Run: testLockingRetentionCompliance},
+		{Name: "testEncryptedLockedVersions", Description: "Combine object locking, versioning and default SSE-KMS encryption with ranged reads, tagging and deletes of versions", Requires: []string{mintest.RequiresVersioning, mintest.RequiresObjectLock, mintest.RequiresKMS, mintest.RequiresObjectTagging}, Run: testEncryptedLockedVersions},
+	}, mintest.NewCapabilities(config, s3Client).Supports)
+	mintest.TestResourceLeaks(s3Client, bucketPrefix, runStartTime)
 }
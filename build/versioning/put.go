@@ -30,6 +30,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
 )
 
 var etagRegex = regexp.MustCompile(`\"(.*)\"`)
@@ -38,7 +39,7 @@ var etagRegex = regexp.MustCompile(`\"(.*)\"`)
 func testPutObject() {
 	startTime := time.Now()
 	function := "testPutObject"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	expiry := 1 * time.Minute
 	args := map[string]interface{}{
@@ -51,7 +52,7 @@ func testPutObject() {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucket, function, args, startTime)
@@ -69,7 +70,7 @@ func testPutObject() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
 		return
 	}
 
@@ -80,7 +81,7 @@ func testPutObject() {
 	}
 	_, err = s3Client.PutObject(putInput1)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 	putInput2 := &s3.PutObjectInput{
@@ -90,7 +91,7 @@ func testPutObject() {
 	}
 	_, err = s3Client.PutObject(putInput2)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
@@ -100,12 +101,12 @@ func testPutObject() {
 
 	result, err := s3Client.ListObjectVersions(input)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
 	if len(result.Versions) != 2 {
-		failureLog(function, args, startTime, "", "Unexpected list content", errors.New("unexpected number of versions")).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Unexpected list content", errors.New("unexpected number of versions")).Fatal()
 		return
 	}
 
@@ -113,43 +114,43 @@ func testPutObject() {
 	vid2 := *result.Versions[1]
 
 	if *vid1.VersionId == "" || *vid2.VersionId == "" || *vid1.VersionId == *vid2.VersionId {
-		failureLog(function, args, startTime, "", "Unexpected list content", errors.New("unexpected VersionId field")).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Unexpected list content", errors.New("unexpected VersionId field")).Fatal()
 		return
 	}
 
 	if *vid1.IsLatest == false || *vid2.IsLatest == true {
-		failureLog(function, args, startTime, "", "Unexpected list content", errors.New("unexpected IsLatest field")).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Unexpected list content", errors.New("unexpected IsLatest field")).Fatal()
 		return
 	}
 
 	if *vid1.Size != 14 || *vid2.Size != 12 {
-		failureLog(function, args, startTime, "", "Unexpected list content", errors.New("unexpected Size field")).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Unexpected list content", errors.New("unexpected Size field")).Fatal()
 		return
 	}
 
 	if !etagRegex.MatchString(*vid1.ETag) || !etagRegex.MatchString(*vid2.ETag) {
-		failureLog(function, args, startTime, "", "Unexpected list content", errors.New("unexpected ETag field")).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Unexpected list content", errors.New("unexpected ETag field")).Fatal()
 		return
 	}
 
 	if *vid1.Key != "testObject" || *vid2.Key != "testObject" {
-		failureLog(function, args, startTime, "", "Unexpected list content", errors.New("unexpected Key field")).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Unexpected list content", errors.New("unexpected Key field")).Fatal()
 		return
 	}
 
 	if (*vid1.LastModified).Before(*vid2.LastModified) {
-		failureLog(function, args, startTime, "", "Unexpected list content", errors.New("unexpected Last modified field")).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Unexpected list content", errors.New("unexpected Last modified field")).Fatal()
 		return
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 // Upload object versions with tagging and metadata and check them
 func testPutObjectWithTaggingAndMetadata() {
 	startTime := time.Now()
 	function := "testPutObjectWithTaggingAndMetadata"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	expiry := 1 * time.Minute
 	args := map[string]interface{}{
@@ -162,7 +163,7 @@ func testPutObjectWithTaggingAndMetadata() {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucket, function, args, startTime)
@@ -180,7 +181,7 @@ func testPutObjectWithTaggingAndMetadata() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
 		return
 	}
 
@@ -214,7 +215,7 @@ func testPutObjectWithTaggingAndMetadata() {
 		}
 		result, err := s3Client.PutObject(putInput)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("PUT object expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT object expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 		uploads[i].versionId = *result.VersionId
@@ -237,7 +238,7 @@ func testPutObjectWithTaggingAndMetadata() {
 		}
 		result, err := s3Client.PutObject(putInput)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("PUT object expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT object expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 		uploads[i].versionId = *result.VersionId
@@ -253,7 +254,7 @@ func testPutObjectWithTaggingAndMetadata() {
 			}
 			tagResult, err := s3Client.GetObjectTagging(input)
 			if err != nil {
-				failureLog(function, args, startTime, "", fmt.Sprintf("GET Object tagging expected to succeed but got %v", err), err).Fatal()
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET Object tagging expected to succeed but got %v", err), err).Fatal()
 				return
 			}
 			vals := make(url.Values)
@@ -261,7 +262,7 @@ func testPutObjectWithTaggingAndMetadata() {
 				vals.Add(*tag.Key, *tag.Value)
 			}
 			if uploads[i].tags != vals.Encode() {
-				failureLog(function, args, startTime, "", "PUT Object with tagging header returned unexpected result", nil).Fatal()
+				mintest.FailureLog(function, args, startTime, "", "PUT Object with tagging header returned unexpected result", nil).Fatal()
 				return
 
 			}
@@ -275,23 +276,23 @@ func testPutObjectWithTaggingAndMetadata() {
 			}
 			result, err := s3Client.HeadObject(input)
 			if err != nil {
-				failureLog(function, args, startTime, "", fmt.Sprintf("HEAD Object expected to succeed but got %v", err), err).Fatal()
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HEAD Object expected to succeed but got %v", err), err).Fatal()
 				return
 			}
 
 			for expectedKey, expectedVal := range uploads[i].metadata {
 				gotValue, ok := result.Metadata[expectedKey]
 				if !ok {
-					failureLog(function, args, startTime, "", "HEAD Object returned unexpected metadata key result", nil).Fatal()
+					mintest.FailureLog(function, args, startTime, "", "HEAD Object returned unexpected metadata key result", nil).Fatal()
 					return
 				}
 				if expectedVal != *gotValue {
-					failureLog(function, args, startTime, "", "HEAD Object returned unexpected metadata value result", nil).Fatal()
+					mintest.FailureLog(function, args, startTime, "", "HEAD Object returned unexpected metadata value result", nil).Fatal()
 					return
 				}
 			}
 		}
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
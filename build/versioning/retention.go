@@ -27,13 +27,14 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
 )
 
 // Test locking retention governance
 func testLockingRetentionGovernance() {
 	startTime := time.Now()
 	function := "testLockingRetentionGovernance"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	expiry := 1 * time.Minute
 	args := map[string]interface{}{
@@ -51,7 +52,7 @@ func testLockingRetentionGovernance() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucket, function, args, startTime)
@@ -84,7 +85,7 @@ func testLockingRetentionGovernance() {
 		}
 		output, err := s3Client.PutObject(putInput)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 		uploads[i].versionId = *output.VersionId
@@ -98,7 +99,7 @@ func testLockingRetentionGovernance() {
 	}
 	deleteOutput, err := s3Client.DeleteObject(deleteInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
@@ -116,23 +117,23 @@ func testLockingRetentionGovernance() {
 		}
 		_, err = s3Client.DeleteObject(deleteInput)
 		if err == nil && uploads[i].retention != "" {
-			failureLog(function, args, startTime, "", "DELETE expected to fail but succeed instead", nil).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "DELETE expected to fail but succeed instead", nil).Fatal()
 			return
 		}
 		if err != nil && uploads[i].retention == "" {
-			failureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 // Test locking retention compliance
 func testLockingRetentionCompliance() {
 	startTime := time.Now()
 	function := "testLockingRetentionCompliance"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	expiry := 1 * time.Minute
 	args := map[string]interface{}{
@@ -150,7 +151,7 @@ func testLockingRetentionCompliance() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 
@@ -184,7 +185,7 @@ func testLockingRetentionCompliance() {
 		}
 		output, err := s3Client.PutObject(putInput)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 		uploads[i].versionId = *output.VersionId
@@ -198,7 +199,7 @@ func testLockingRetentionCompliance() {
 	}
 	deleteOutput, err := s3Client.DeleteObject(deleteInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
@@ -216,16 +217,16 @@ func testLockingRetentionCompliance() {
 		}
 		_, err = s3Client.DeleteObject(deleteInput)
 		if err == nil && uploads[i].retention != "" {
-			failureLog(function, args, startTime, "", "DELETE expected to fail but succeed instead", nil).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "DELETE expected to fail but succeed instead", nil).Fatal()
 			return
 		}
 		if err != nil && uploads[i].retention == "" {
-			failureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 func testPutGetDeleteRetentionGovernance() {
@@ -241,7 +242,7 @@ func testPutGetRetentionCompliance() {
 // Test locking retention governance
 func testPutGetDeleteLockingRetention(function, retentionMode string) {
 	startTime := time.Now()
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	args := map[string]interface{}{
 		"bucketName":    bucket,
@@ -258,7 +259,7 @@ func testPutGetDeleteLockingRetention(function, retentionMode string) {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 
@@ -278,7 +279,7 @@ func testPutGetDeleteLockingRetention(function, retentionMode string) {
 
 	output, err := s3Client.PutObject(putInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 	versionId := *output.VersionId
@@ -295,7 +296,7 @@ func testPutGetDeleteLockingRetention(function, retentionMode string) {
 	}
 	_, err = s3Client.PutObjectRetention(putRetentionInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("PutObjectRetention expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PutObjectRetention expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
@@ -307,13 +308,13 @@ func testPutGetDeleteLockingRetention(function, retentionMode string) {
 
 	retentionOutput, err := s3Client.GetObjectRetention(getRetentionInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("GetObjectRetention expected to succeed but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObjectRetention expected to succeed but got %v", err), err).Fatal()
 		return
 	}
 
 	// Compare until retention date with truncating precision less than second
 	if retentionOutput.Retention.RetainUntilDate.Truncate(time.Second).String() != twoMinutesRetention.Truncate(time.Second).String() {
-		failureLog(function, args, startTime, "", "Unexpected until retention date", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Unexpected until retention date", nil).Fatal()
 		return
 	}
 
@@ -329,7 +330,7 @@ func testPutGetDeleteLockingRetention(function, retentionMode string) {
 	}
 	_, err = s3Client.PutObjectRetention(putRetentionInput)
 	if err == nil {
-		failureLog(function, args, startTime, "", "PutObjectRetention expected to fail but succeeded", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "PutObjectRetention expected to fail but succeeded", nil).Fatal()
 		return
 	}
 
@@ -345,7 +346,7 @@ func testPutGetDeleteLockingRetention(function, retentionMode string) {
 
 	_, err = s3Client.PutObjectRetention(putRetentionInput)
 	if err == nil {
-		failureLog(function, args, startTime, "", "Operation expected to fail but succeeded", nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Operation expected to fail but succeeded", nil).Fatal()
 		return
 	}
 
@@ -363,10 +364,10 @@ func testPutGetDeleteLockingRetention(function, retentionMode string) {
 
 		_, err = s3Client.PutObjectRetention(putRetentionInput)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("Expected to succeed but failed with %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Expected to succeed but failed with %v", err), err).Fatal()
 			return
 		}
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
@@ -0,0 +1,281 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Test the features of a bucket together rather than in isolation: in a
+// bucket with object locking, versioning, a default governance retention
+// and a default SSE-KMS encryption, each version of an object keeps its
+// own encryption, retention, tags and content. Ranged reads decrypt the
+// right version, a delete marker hides the locked versions without
+// removing them, and a locked version is only deleted bypassing its
+// governance retention.
+func testEncryptedLockedVersions() {
+	startTime := time.Now()
+	function := "testEncryptedLockedVersions"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	object := "testObject"
+	seed := mintest.DataSeed()
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"seed":       seed,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket:                     aws.String(bucket),
+		ObjectLockEnabledForBucket: aws.Bool(true),
+	})
+	if err != nil {
+		if mintest.IsNotImplemented(err) {
+			ignoreLog(function, args, startTime, "Object locking is not implemented").Info()
+			return
+		}
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err = s3Client.PutBucketEncryption(&s3.PutBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+		ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+			Rules: []*s3.ServerSideEncryptionRule{{
+				ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{SSEAlgorithm: aws.String(s3.ServerSideEncryptionAwsKms)},
+			}},
+		},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutBucketEncryption failed", err).Fatal()
+		return
+	}
+	_, err = s3Client.PutObjectLockConfiguration(&s3.PutObjectLockConfigurationInput{
+		Bucket: aws.String(bucket),
+		ObjectLockConfiguration: &s3.ObjectLockConfiguration{
+			ObjectLockEnabled: aws.String(s3.ObjectLockEnabledEnabled),
+			Rule: &s3.ObjectLockRule{
+				DefaultRetention: &s3.DefaultRetention{Mode: aws.String(s3.ObjectLockRetentionModeGovernance), Days: aws.Int64(1)},
+			},
+		},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "PutObjectLockConfiguration failed", err).Fatal()
+		return
+	}
+
+	type version struct {
+		id      string
+		content []byte
+	}
+	versions := make([]version, 3)
+	for i := range versions {
+		content, err := ioutil.ReadAll(mintest.NewDataReader(seed+uint64(i), int64(80*1024+i*1000)))
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Generating the object content failed", err).Fatal()
+			return
+		}
+		output, err := s3Client.PutObject(&s3.PutObjectInput{
+			Body:    bytes.NewReader(content),
+			Bucket:  aws.String(bucket),
+			Key:     aws.String(object),
+			Tagging: aws.String(fmt.Sprintf("version=%d", i)),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+		if sse := aws.StringValue(output.ServerSideEncryption); sse != s3.ServerSideEncryptionAwsKms {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to be encrypted with %s by default but got %q", s3.ServerSideEncryptionAwsKms, sse), nil).Fatal()
+			return
+		}
+		versions[i] = version{aws.StringValue(output.VersionId), content}
+	}
+
+	// readVersion reads the range of a version of the object, the whole of
+	// it when rng is empty
+	readVersion := func(versionID, rng string) ([]byte, error) {
+		input := &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		}
+		if versionID != "" {
+			input.VersionId = aws.String(versionID)
+		}
+		if rng != "" {
+			input.Range = aws.String(rng)
+		}
+		output, err := s3Client.GetObject(input)
+		if err != nil {
+			return nil, err
+		}
+		defer output.Body.Close()
+		return ioutil.ReadAll(output.Body)
+	}
+
+	for i, v := range versions {
+		head, err := s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			VersionId: aws.String(v.id),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HEAD of version %s expected to succeed but got %v", v.id, err), err).Fatal()
+			return
+		}
+		if aws.StringValue(head.ServerSideEncryption) != s3.ServerSideEncryptionAwsKms ||
+			aws.StringValue(head.ObjectLockMode) != s3.ObjectLockModeGovernance || head.ObjectLockRetainUntilDate == nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HEAD of version %s expected aws:kms encryption and the default governance retention but got %q, %q until %v",
+				v.id, aws.StringValue(head.ServerSideEncryption), aws.StringValue(head.ObjectLockMode), head.ObjectLockRetainUntilDate), nil).Fatal()
+			return
+		}
+
+		tagging, err := s3Client.GetObjectTagging(&s3.GetObjectTaggingInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			VersionId: aws.String(v.id),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObjectTagging of version %s expected to succeed but got %v", v.id, err), err).Fatal()
+			return
+		}
+		if len(tagging.TagSet) != 1 || aws.StringValue(tagging.TagSet[0].Value) != fmt.Sprint(i) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObjectTagging of version %s expected version=%d but got %v", v.id, i, tagging.TagSet), nil).Fatal()
+			return
+		}
+
+		// Cross the 64KiB packages MinIO encrypts objects in
+		start, end := 64*1024-100-i, 64*1024+200+i
+		data, err := readVersion(v.id, fmt.Sprintf("bytes=%d-%d", start, end))
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Ranged GET of version %s expected to succeed but got %v", v.id, err), err).Fatal()
+			return
+		}
+		if !bytes.Equal(data, v.content[start:end+1]) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Ranged GET of bytes %d-%d of version %s returned the content of another range or version", start, end, v.id), nil).Fatal()
+			return
+		}
+	}
+
+	// Retagging a noncurrent version leaves the others alone
+	_, err = s3Client.PutObjectTagging(&s3.PutObjectTaggingInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(object),
+		VersionId: aws.String(versions[0].id),
+		Tagging:   &s3.Tagging{TagSet: []*s3.Tag{{Key: aws.String("retagged"), Value: aws.String("true")}}},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PutObjectTagging of noncurrent version %s expected to succeed but got %v", versions[0].id, err), err).Fatal()
+		return
+	}
+	tagging, err := s3Client.GetObjectTagging(&s3.GetObjectTaggingInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(object),
+		VersionId: aws.String(versions[1].id),
+	})
+	if err != nil || len(tagging.TagSet) != 1 || aws.StringValue(tagging.TagSet[0].Key) != "version" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Tags of version %s expected to be left alone by retagging version %s", versions[1].id, versions[0].id), err).Fatal()
+		return
+	}
+
+	// A delete without version ID adds a delete marker despite the retention
+	deleteOutput, err := s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil || !aws.BoolValue(deleteOutput.DeleteMarker) {
+		mintest.FailureLog(function, args, startTime, "", "DELETE without version ID expected to add a delete marker", err).Fatal()
+		return
+	}
+	if _, err = readVersion("", ""); err == nil {
+		mintest.FailureLog(function, args, startTime, "", "GET behind a delete marker expected to fail but succeeded", nil).Fatal()
+		return
+	}
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != s3.ErrCodeNoSuchKey {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET behind a delete marker expected NoSuchKey but got %v", err), err).Fatal()
+		return
+	}
+
+	// A locked version is only deleted bypassing its governance retention
+	_, err = s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(object),
+		VersionId: aws.String(versions[1].id),
+	})
+	if err == nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE of locked version %s expected to fail but succeeded", versions[1].id), nil).Fatal()
+		return
+	}
+	_, err = s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket:                    aws.String(bucket),
+		Key:                       aws.String(object),
+		VersionId:                 aws.String(versions[1].id),
+		BypassGovernanceRetention: aws.Bool(true),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE of locked version %s bypassing governance retention expected to succeed but got %v", versions[1].id, err), err).Fatal()
+		return
+	}
+	if _, err = readVersion(versions[1].id, ""); err == nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET of deleted version %s expected to fail but succeeded", versions[1].id), nil).Fatal()
+		return
+	}
+
+	// Removing the delete marker brings back the latest remaining version
+	_, err = s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(object),
+		VersionId: deleteOutput.VersionId,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE of the delete marker expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	for _, read := range []struct {
+		versionID string
+		content   []byte
+	}{
+		{"", versions[2].content},
+		{versions[0].id, versions[0].content},
+		{versions[2].id, versions[2].content},
+	} {
+		data, err := readVersion(read.versionID, "")
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET of version %q expected to succeed but got %v", read.versionID, err), err).Fatal()
+			return
+		}
+		if !bytes.Equal(data, read.content) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET of version %q returned the content of another version", read.versionID), nil).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
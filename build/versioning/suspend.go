@@ -0,0 +1,228 @@
+/*
+*
+*  Mint, (C) 2021 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// nullVersionID is the version id of the objects and delete markers
+// written while versioning is not enabled
+const nullVersionID = "null"
+
+// testVersioningSuspended writes versions of an object in a versioned
+// bucket, then suspends versioning. Objects written afterwards must be
+// stored under the null version, each replacing the previous one, and
+// deleting the object must turn the null version into a delete marker.
+// Versions written before the suspension must be kept throughout, also
+// once versioning is enabled again.
+func testVersioningSuspended() {
+	startTime := time.Now()
+	function := "testVersioningSuspended"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	setVersioning := func(status string) error {
+		_, err := s3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+			Bucket: aws.String(bucket),
+			VersioningConfiguration: &s3.VersioningConfiguration{
+				Status: aws.String(status),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		output, err := s3Client.GetBucketVersioning(&s3.GetBucketVersioningInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			return err
+		}
+		if aws.StringValue(output.Status) != status {
+			return fmt.Errorf("versioning status is %q instead of %q", aws.StringValue(output.Status), status)
+		}
+		return nil
+	}
+	put := func(content string) (string, error) {
+		output, err := s3Client.PutObject(&s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader(content)),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			return "", err
+		}
+		return aws.StringValue(output.VersionId), nil
+	}
+	get := func(versionID string) (string, error) {
+		output, err := s3Client.GetObject(&s3.GetObjectInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			VersionId: aws.String(versionID),
+		})
+		if err != nil {
+			return "", err
+		}
+		defer output.Body.Close()
+		body, err := ioutil.ReadAll(output.Body)
+		return string(body), err
+	}
+	// checkVersions compares the version ids of the latest version, of
+	// the object versions, and of the delete markers with the expected ones
+	checkVersions := func(step, latest string, versions, deleteMarkers []string) bool {
+		output, err := s3Client.ListObjectVersions(&s3.ListObjectVersionsInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions %s expected to succeed but got %v", step, err), err).Fatal()
+			return false
+		}
+		var gotLatest string
+		var gotVersions, gotDeleteMarkers []string
+		for _, v := range output.Versions {
+			gotVersions = append(gotVersions, aws.StringValue(v.VersionId))
+			if aws.BoolValue(v.IsLatest) {
+				gotLatest = aws.StringValue(v.VersionId)
+			}
+		}
+		for _, m := range output.DeleteMarkers {
+			gotDeleteMarkers = append(gotDeleteMarkers, aws.StringValue(m.VersionId))
+			if aws.BoolValue(m.IsLatest) {
+				gotLatest = aws.StringValue(m.VersionId)
+			}
+		}
+		if gotLatest != latest || !reflect.DeepEqual(gotVersions, versions) || !reflect.DeepEqual(gotDeleteMarkers, deleteMarkers) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ListObjectVersions %s expected latest %s, versions %v and delete markers %v but got latest %s, versions %v and delete markers %v",
+				step, latest, versions, deleteMarkers, gotLatest, gotVersions, gotDeleteMarkers), nil).Fatal()
+			return false
+		}
+		return true
+	}
+
+	if err = setVersioning(s3.BucketVersioningStatusEnabled); err != nil {
+		if mintest.IsNotImplemented(err) {
+			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
+			return
+		}
+		mintest.FailureLog(function, args, startTime, "", "Enabling versioning failed", err).Fatal()
+		return
+	}
+	var versionIDs []string
+	for _, content := range []string{"version 1", "version 2"} {
+		versionID, err := put(content)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+		if versionID == "" || versionID == nullVersionID {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT to a versioned bucket returned version id %q", versionID), nil).Fatal()
+			return
+		}
+		versionIDs = append(versionIDs, versionID)
+	}
+
+	if err = setVersioning(s3.BucketVersioningStatusSuspended); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Suspending versioning failed", err).Fatal()
+		return
+	}
+
+	// Each PUT replaces the null version
+	for _, content := range []string{"null version 1", "null version 2"} {
+		versionID, err := put(content)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+		if versionID != "" && versionID != nullVersionID {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT with versioning suspended expected the null version but got %q", versionID), nil).Fatal()
+			return
+		}
+		if !checkVersions("after a PUT with versioning suspended", nullVersionID, []string{nullVersionID, versionIDs[1], versionIDs[0]}, nil) {
+			return
+		}
+		body, err := get(nullVersionID)
+		if err != nil || body != content {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject of the null version expected %q but got %q", content, body), err).Fatal()
+			return
+		}
+	}
+
+	// The delete marker replaces the null version
+	output, err := s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Delete expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	if !aws.BoolValue(output.DeleteMarker) || aws.StringValue(output.VersionId) != nullVersionID {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Delete with versioning suspended expected a null delete marker but got version %q, delete marker %t",
+			aws.StringValue(output.VersionId), aws.BoolValue(output.DeleteMarker)), nil).Fatal()
+		return
+	}
+	if !checkVersions("after a DELETE with versioning suspended", nullVersionID, []string{versionIDs[1], versionIDs[0]}, []string{nullVersionID}) {
+		return
+	}
+
+	if err = setVersioning(s3.BucketVersioningStatusEnabled); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Enabling versioning again failed", err).Fatal()
+		return
+	}
+	versionID, err := put("version 3")
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	if !checkVersions("after versioning is enabled again", versionID, []string{versionID, versionIDs[1], versionIDs[0]}, []string{nullVersionID}) {
+		return
+	}
+	for i, content := range []string{"version 1", "version 2"} {
+		body, err := get(versionIDs[i])
+		if err != nil || body != content {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GetObject of version %s expected %q but got %q", versionIDs[i], content, body), err).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
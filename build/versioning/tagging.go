@@ -28,13 +28,14 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
 )
 
 // Test PUT/GET/DELETE tagging for separate versions
 func testTagging() {
 	startTime := time.Now()
 	function := "testTagging"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
 	object := "testObject"
 	expiry := 1 * time.Minute
 	args := map[string]interface{}{
@@ -47,7 +48,7 @@ func testTagging() {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
 		return
 	}
 	defer cleanupBucket(bucket, function, args, startTime)
@@ -65,7 +66,7 @@ func testTagging() {
 			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
 			return
 		}
-		failureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
 		return
 	}
 
@@ -93,7 +94,7 @@ func testTagging() {
 			}
 			deleteOutput, err := s3Client.DeleteObject(deleteInput)
 			if err != nil {
-				failureLog(function, args, startTime, "", fmt.Sprintf("DELETE object expected to succeed but got %v", err), err).Fatal()
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE object expected to succeed but got %v", err), err).Fatal()
 				return
 			}
 			uploads[i].versionId = *deleteOutput.VersionId
@@ -107,7 +108,7 @@ func testTagging() {
 		}
 		output, err := s3Client.PutObject(putInput)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 		uploads[i].versionId = *output.VersionId
@@ -126,7 +127,7 @@ func testTagging() {
 		}
 		_, err = s3Client.PutObjectTagging(putTaggingInput)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("PUT Object tagging expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT Object tagging expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 	}
@@ -140,11 +141,11 @@ func testTagging() {
 		}
 		result, err := s3Client.GetObjectTagging(input)
 		if err == nil && uploads[i].deleteMarker {
-			failureLog(function, args, startTime, "", "GET Object tagging expected to fail with delete marker but succeded", err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "GET Object tagging expected to fail with delete marker but succeded", err).Fatal()
 			return
 		}
 		if err != nil && !uploads[i].deleteMarker {
-			failureLog(function, args, startTime, "", fmt.Sprintf("GET Object tagging expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET Object tagging expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 
@@ -153,7 +154,7 @@ func testTagging() {
 		}
 
 		if !reflect.DeepEqual(result.TagSet, uploads[i].tagging) {
-			failureLog(function, args, startTime, "", "GET Object tagging returned unexpected result", nil).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "GET Object tagging returned unexpected result", nil).Fatal()
 			return
 		}
 	}
@@ -167,11 +168,11 @@ func testTagging() {
 		}
 		_, err := s3Client.DeleteObjectTagging(input)
 		if err == nil && uploads[i].deleteMarker {
-			failureLog(function, args, startTime, "", "DELETE Object tagging expected to fail with delete marker but succeded", err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "DELETE Object tagging expected to fail with delete marker but succeded", err).Fatal()
 			return
 		}
 		if err != nil && !uploads[i].deleteMarker {
-			failureLog(function, args, startTime, "", fmt.Sprintf("GET Object tagging expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET Object tagging expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 	}
@@ -189,15 +190,177 @@ func testTagging() {
 		}
 		result, err := s3Client.GetObjectTagging(input)
 		if err != nil {
-			failureLog(function, args, startTime, "", fmt.Sprintf("GET Object tagging expected to succeed but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET Object tagging expected to succeed but got %v", err), err).Fatal()
 			return
 		}
 		var nilTagSet []*s3.Tag
 		if !reflect.DeepEqual(result.TagSet, nilTagSet) {
-			failureLog(function, args, startTime, "", "GET Object tagging after DELETE returned unexpected result", nil).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "GET Object tagging after DELETE returned unexpected result", nil).Fatal()
 			return
 		}
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Create versions of an object with their own metadata, the first one by
+// PUT and the others by copying it onto itself replacing its metadata,
+// then set different tags on each version. Tags and metadata must be read
+// back per version ID, and deleting the tags of one version must leave the
+// tags of the others untouched.
+func testTaggingMetadataPerVersion() {
+	startTime := time.Now()
+	function := "testTaggingMetadataPerVersion"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "versioning-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "CreateBucket failed", err).Fatal()
+		return
+	}
+	defer cleanupBucket(bucket, function, args, startTime)
+
+	_, err = s3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket: aws.String(bucket),
+		VersioningConfiguration: &s3.VersioningConfiguration{
+			Status: aws.String("Enabled"),
+		},
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "NotImplemented: A header you provided implies functionality that is not implemented") {
+			ignoreLog(function, args, startTime, "Versioning is not implemented").Info()
+			return
+		}
+		mintest.FailureLog(function, args, startTime, "", "Put versioning failed", err).Fatal()
+		return
+	}
+
+	type version struct {
+		versionID string
+		metadata  map[string]*string
+		tagging   []*s3.Tag
+	}
+	versions := []version{
+		{
+			metadata: map[string]*string{"Revision": aws.String("first")},
+			tagging:  []*s3.Tag{{Key: aws.String("revision"), Value: aws.String("first")}},
+		},
+		{
+			metadata: map[string]*string{"Revision": aws.String("second"), "Reviewer": aws.String("someone")},
+			tagging: []*s3.Tag{
+				{Key: aws.String("revision"), Value: aws.String("second")},
+				{Key: aws.String("reviewed"), Value: aws.String("true")},
+			},
+		},
+		{
+			metadata: map[string]*string{"Revision": aws.String("third")},
+			tagging:  []*s3.Tag{{Key: aws.String("stage"), Value: aws.String("final")}},
+		},
+	}
+
+	output, err := s3Client.PutObject(&s3.PutObjectInput{
+		Body:     aws.ReadSeekCloser(strings.NewReader("content")),
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(object),
+		Metadata: versions[0].metadata,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	versions[0].versionID = aws.StringValue(output.VersionId)
+	for i := 1; i < len(versions); i++ {
+		output, err := s3Client.CopyObject(&s3.CopyObjectInput{
+			Bucket:            aws.String(bucket),
+			Key:               aws.String(object),
+			CopySource:        aws.String(bucket + "/" + object + "?versionId=" + versions[0].versionID),
+			Metadata:          versions[i].metadata,
+			MetadataDirective: aws.String(s3.MetadataDirectiveReplace),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("CopyObject expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+		versions[i].versionID = aws.StringValue(output.VersionId)
+	}
+
+	for _, v := range versions {
+		_, err = s3Client.PutObjectTagging(&s3.PutObjectTaggingInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			Tagging:   &s3.Tagging{TagSet: v.tagging},
+			VersionId: aws.String(v.versionID),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("PUT Object tagging of version %s expected to succeed but got %v", v.versionID, err), err).Fatal()
+			return
+		}
+	}
+
+	// Tags are compared regardless of their order
+	tagMap := func(tags []*s3.Tag) map[string]string {
+		m := map[string]string{}
+		for _, tag := range tags {
+			m[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		return m
+	}
+	checkVersions := func(stage string) bool {
+		for _, v := range versions {
+			head, err := s3Client.HeadObject(&s3.HeadObjectInput{
+				Bucket:    aws.String(bucket),
+				Key:       aws.String(object),
+				VersionId: aws.String(v.versionID),
+			})
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HEAD of version %s %s expected to succeed but got %v", v.versionID, stage, err), err).Fatal()
+				return false
+			}
+			if !reflect.DeepEqual(head.Metadata, v.metadata) {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("HEAD of version %s %s expected metadata %v but got %v", v.versionID, stage, v.metadata, head.Metadata), nil).Fatal()
+				return false
+			}
+			result, err := s3Client.GetObjectTagging(&s3.GetObjectTaggingInput{
+				Bucket:    aws.String(bucket),
+				Key:       aws.String(object),
+				VersionId: aws.String(v.versionID),
+			})
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET Object tagging of version %s %s expected to succeed but got %v", v.versionID, stage, err), err).Fatal()
+				return false
+			}
+			if aws.StringValue(result.VersionId) != v.versionID || !reflect.DeepEqual(tagMap(result.TagSet), tagMap(v.tagging)) {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET Object tagging of version %s %s expected %v but got %v of version %s",
+					v.versionID, stage, v.tagging, result.TagSet, aws.StringValue(result.VersionId)), nil).Fatal()
+				return false
+			}
+		}
+		return true
+	}
+	if !checkVersions("after tagging") {
+		return
+	}
+
+	_, err = s3Client.DeleteObjectTagging(&s3.DeleteObjectTaggingInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String(object),
+		VersionId: aws.String(versions[1].versionID),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("DELETE Object tagging expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	versions[1].tagging = nil
+	if !checkVersions("after deleting the tags of version " + versions[1].versionID) {
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
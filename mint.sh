@@ -22,6 +22,7 @@ SERVER_REGION=${SERVER_REGION:-us-east-1}
 ENABLE_HTTPS=${ENABLE_HTTPS:-0}
 ENABLE_VIRTUAL_STYLE=${ENABLE_VIRTUAL_STYLE:-0}
 RUN_ON_FAIL=${RUN_ON_FAIL:-0}
+MINT_RUN_ID=${MINT_RUN_ID:-$(tr -dc 'a-z0-9' </dev/urandom | head -c 8)}
 
 if [ -z "$SERVER_ENDPOINT" ]; then
 	SERVER_ENDPOINT="play.minio.io:9000"
@@ -45,7 +46,9 @@ if [ "$ENABLE_VIRTUAL_STYLE" -eq 1 ]; then
 			exit 1
 		fi
 	done
+	MINT_ADDRESSING_STYLE=${MINT_ADDRESSING_STYLE:-virtual}
 fi
+MINT_ADDRESSING_STYLE=${MINT_ADDRESSING_STYLE:-path}
 
 ROOT_DIR="$PWD"
 TESTS_DIR="$ROOT_DIR/run/core"
@@ -128,27 +131,75 @@ function main() {
 
 	export ACCESS_KEY
 	export SECRET_KEY
+	export SESSION_TOKEN
 	export ENABLE_HTTPS
 	export SERVER_REGION
 	export ENABLE_VIRTUAL_STYLE
+	export MINT_ADDRESSING_STYLE
 	export RUN_ON_FAIL
+	export MINT_OBJECT_SIZES
+	export NOTIFY_ARN
+	export WEB_IDENTITY_TOKEN
+	export OIDC_TOKEN
+	export ROLE_ARN
+	export MINT_BENCH_SIZES
+	export MINT_BENCH_OBJECTS
+	export MINT_BENCH_WORKERS
+	export MINT_BENCH_LIST_OBJECTS
+	export TIER_STORAGE_CLASS
+	export MINT_DATA_SEED
+	export SERVER_ENDPOINT_2
+	export MINT_REPLICATION_TIMEOUT
+	export MINT_LIFECYCLE_TIMEOUT
+	export MINT_TEST_TIMEOUT
+	export MINT_GLOBAL_DEADLINE
+	export MINT_LIST_TESTS
+	export MINT_STARTUP_TIMEOUT
+	export MINT_TRACE_ON_FAIL
+	export MINT_SERVER_PROFILE
+	export MINT_CA_CERT
+	export MINT_CLIENT_CERT
+	export MINT_CLIENT_KEY
+	export MINT_INSECURE_SKIP_VERIFY
+	export MINT_TEST_BUCKET
+	export MINT_CHECKPOINT_FILE
+	export MINT_CHAOS
+	export MINT_RUN_ID
+	export MINT_MIN_GET_MBPS
+	# Start of the run, for the tests to tell when the global deadline is
+	export MINT_RUN_START
+	MINT_RUN_START=$(date +%s)
+	export MINT_JUNIT_FILE
+	# Each Go suite replaces its own results, drop the ones of a previous run
+	[ -n "$MINT_JUNIT_FILE" ] && rm -f "$MINT_JUNIT_FILE"
 
 	echo "Running with"
 	echo "SERVER_ENDPOINT:      $SERVER_ENDPOINT"
 	echo "ACCESS_KEY:           $ACCESS_KEY"
 	echo "SECRET_KEY:           ***REDACTED***"
+	[ -n "$SESSION_TOKEN" ] && echo "SESSION_TOKEN:        ***REDACTED***"
 	echo "ENABLE_HTTPS:         $ENABLE_HTTPS"
 	echo "SERVER_REGION:        $SERVER_REGION"
 	echo "MINT_DATA_DIR:        $MINT_DATA_DIR"
 	echo "MINT_MODE:            $MINT_MODE"
 	echo "ENABLE_VIRTUAL_STYLE: $ENABLE_VIRTUAL_STYLE"
 	echo "RUN_ON_FAIL:          $RUN_ON_FAIL"
+	echo "MINT_RUN_ID:          $MINT_RUN_ID"
 	echo
 	echo "To get logs, run 'docker cp ${CONTAINER_ID}:/mint/log /tmp/mint-logs'"
 	echo
 
 	[ "$ENABLE_HTTPS" == "1" ] && trust_s3_endpoint_tls_cert
 
+	# Remove the buckets left behind by a run instead of running the tests
+	if [ "$1" == "cleanup" ]; then
+		if [ -z "$2" ]; then
+			echo "usage: mint.sh cleanup <MINT_RUN_ID>"
+			exit 1
+		fi
+		exec "$TESTS_DIR/aws-sdk-go/aws-sdk-go" -cleanup-run "$2"
+	fi
+
 	declare -a run_list
 	sdks=("$@")
 
@@ -0,0 +1,169 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Benchmark settings used when MINT_BENCH_* are not set
+const (
+	defaultBenchmarkSizes       = "4KiB,1MiB,16MiB"
+	defaultBenchmarkObjects     = 64
+	defaultBenchmarkConcurrency = 16
+)
+
+var sizeUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+	{"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+	{"B", 1},
+}
+
+// ParseSize parses a size like "64MiB", "1GB" or "1024"
+func ParseSize(s string) (int64, error) {
+	number, unit := strings.TrimSpace(s), int64(1)
+	for _, u := range sizeUnits {
+		if strings.HasSuffix(number, u.suffix) {
+			number, unit = strings.TrimSuffix(number, u.suffix), u.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(number), 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid object size %q", s)
+	}
+	return n * unit, nil
+}
+
+// PositiveInt returns the positive integer set in the environment variable
+// name, or defaultValue when it is not set
+func PositiveInt(name string, defaultValue int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, value)
+	}
+	return n, nil
+}
+
+// BenchmarkSettings returns the object sizes, the number of objects per
+// size and the concurrency set in MINT_BENCH_SIZES,
+// MINT_BENCH_OBJECTS and MINT_BENCH_WORKERS.
+func BenchmarkSettings() (sizes []int64, objects int, concurrency int, err error) {
+	value := os.Getenv("MINT_BENCH_SIZES")
+	if value == "" {
+		value = defaultBenchmarkSizes
+	}
+	for _, s := range strings.Split(value, ",") {
+		size, err := ParseSize(s)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		sizes = append(sizes, size)
+	}
+
+	if objects, err = PositiveInt("MINT_BENCH_OBJECTS", defaultBenchmarkObjects); err != nil {
+		return nil, 0, 0, err
+	}
+	if concurrency, err = PositiveInt("MINT_BENCH_WORKERS", defaultBenchmarkConcurrency); err != nil {
+		return nil, 0, 0, err
+	}
+	return sizes, objects, concurrency, nil
+}
+
+// BenchmarkMetrics are the results of a benchmark, logged along with its
+// PASS record
+type BenchmarkMetrics struct {
+	ThroughputMiBps float64 `json:"throughputMiBps"`
+	OpsPerSecond    float64 `json:"opsPerSecond"`
+	LatencyP50      float64 `json:"latencyP50Ms"`
+	LatencyP90      float64 `json:"latencyP90Ms"`
+	LatencyP99      float64 `json:"latencyP99Ms"`
+	LatencyMax      float64 `json:"latencyMaxMs"`
+}
+
+// NewBenchmarkMetrics computes the metrics of ops operations on size bytes
+// each, taking elapsed overall with the given latencies.
+func NewBenchmarkMetrics(size int64, elapsed time.Duration, latencies []time.Duration) BenchmarkMetrics {
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	percentile := func(p float64) float64 {
+		i := int(p*float64(len(latencies))+0.5) - 1
+		if i < 0 {
+			i = 0
+		}
+		return float64(latencies[i]) / float64(time.Millisecond)
+	}
+	ops := float64(len(latencies))
+	return BenchmarkMetrics{
+		ThroughputMiBps: ops * float64(size) / (1 << 20) / elapsed.Seconds(),
+		OpsPerSecond:    ops / elapsed.Seconds(),
+		LatencyP50:      percentile(0.50),
+		LatencyP90:      percentile(0.90),
+		LatencyP99:      percentile(0.99),
+		LatencyMax:      percentile(1),
+	}
+}
+
+// RunBenchmark runs op on every object index with the given concurrency,
+// and returns the time taken overall along with the latency of each call.
+func RunBenchmark(objects, concurrency int, op func(i int) error) (time.Duration, []time.Duration, error) {
+	latencies := make([]time.Duration, objects)
+	errs := make([]error, objects)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	start := time.Now()
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				opStart := time.Now()
+				errs[i] = op(i)
+				latencies[i] = time.Since(opStart)
+			}
+		}()
+	}
+	for i := 0; i < objects; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	for _, err := range errs {
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+	return elapsed, latencies, nil
+}
@@ -0,0 +1,180 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"math/rand"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+const letterBytes = "abcdefghijklmnopqrstuvwxyz01234569"
+const (
+	letterIdxBits = 6                    // 6 bits to represent a letter index
+	letterIdxMask = 1<<letterIdxBits - 1 // All 1-bits, as many as letterIdxBits
+	letterIdxMax  = 63 / letterIdxBits   // # of letter indices fitting in 63 bits
+)
+
+// RandString returns a random name of 30 characters starting with prefix,
+// suitable as a bucket or object name. A non empty prefix is followed by
+// the RunID and a dash, which come on top of the 30 characters.
+func RandString(n int, src rand.Source, prefix string) string {
+	b := make([]byte, n)
+	// A rand.Int63() generates 63 random bits, enough for letterIdxMax letters!
+	for i, cache, remain := n-1, src.Int63(), letterIdxMax; i >= 0; {
+		if remain == 0 {
+			cache, remain = src.Int63(), letterIdxMax
+		}
+		if idx := int(cache & letterIdxMask); idx < len(letterBytes) {
+			b[i] = letterBytes[idx]
+			i--
+		}
+		cache >>= letterIdxBits
+		remain--
+	}
+	if prefix == "" {
+		return string(b[0:30])
+	}
+	return prefix + RunID() + "-" + string(b[0:30-len(prefix)])
+}
+
+// IsNotImplemented reports whether err tells the server does not implement
+// the requested functionality.
+func IsNotImplemented(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "NotImplemented" {
+		return true
+	}
+	return err != nil && strings.Contains(err.Error(), "NotImplemented: A header you provided implies functionality that is not implemented")
+}
+
+// Error codes other implementations of S3 answer with instead of the one
+// MinIO answers with, by server profile
+var alternativeCodes = map[string]map[string][]string{
+	ProfileAWS: {
+		"XAmzContentChecksumMismatch": {"BadDigest"},
+	},
+	ProfileGeneric: {
+		"XAmzContentChecksumMismatch": {"BadDigest"},
+	},
+}
+
+// IsErrorCode reports whether err is an S3 error with code, the one MinIO
+// answers with, or with an alternative of it when MINT_SERVER_PROFILE is
+// another implementation of S3.
+func IsErrorCode(err error, code string) bool {
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+	if aerr.Code() == code {
+		return true
+	}
+	for _, alternative := range alternativeCodes[serverProfile()][code] {
+		if aerr.Code() == alternative {
+			return true
+		}
+	}
+	return false
+}
+
+// EmptyBucket removes all the objects of a bucket, with all their versions
+// and delete markers, bypassing governance retention, as well as its
+// incomplete multipart uploads. It returns the first error met.
+func EmptyBucket(client *s3.S3, bucket string) error {
+	return emptyPrefix(client, bucket, "")
+}
+
+// emptyPrefix removes the objects and uploads of a bucket under prefix,
+// like EmptyBucket does for all of them
+func emptyPrefix(client *s3.S3, bucket, prefix string) error {
+	var listPrefix *string
+	if prefix != "" {
+		listPrefix = aws.String(prefix)
+	}
+	var firstErr error
+	remove := func(key, versionID *string) {
+		_, err := client.DeleteObject(&s3.DeleteObjectInput{
+			Bucket:                    aws.String(bucket),
+			Key:                       key,
+			VersionId:                 versionID,
+			BypassGovernanceRetention: aws.Bool(true),
+		})
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	err := client.ListObjectVersionsPages(&s3.ListObjectVersionsInput{Bucket: aws.String(bucket), Prefix: listPrefix},
+		func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
+			for _, v := range page.Versions {
+				remove(v.Key, v.VersionId)
+			}
+			for _, v := range page.DeleteMarkers {
+				remove(v.Key, v.VersionId)
+			}
+			return true
+		})
+	if err != nil {
+		// Versions are not supported, remove the objects
+		err = client.ListObjectsV2Pages(&s3.ListObjectsV2Input{Bucket: aws.String(bucket), Prefix: listPrefix},
+			func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+				for _, obj := range page.Contents {
+					remove(obj.Key, nil)
+				}
+				return true
+			})
+	}
+	if err != nil {
+		return err
+	}
+
+	err = client.ListMultipartUploadsPages(&s3.ListMultipartUploadsInput{Bucket: aws.String(bucket), Prefix: listPrefix},
+		func(page *s3.ListMultipartUploadsOutput, lastPage bool) bool {
+			for _, upload := range page.Uploads {
+				_, err := client.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+					Bucket:   aws.String(bucket),
+					Key:      upload.Key,
+					UploadId: upload.UploadId,
+				})
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+			}
+			return true
+		})
+	if err != nil && !IsNotImplemented(err) {
+		return err
+	}
+	return firstErr
+}
+
+// RemoveBucket empties a bucket, then deletes it
+func RemoveBucket(client *s3.S3, bucket string) error {
+	if err := EmptyBucket(client, bucket); err != nil {
+		return err
+	}
+	_, err := client.DeleteBucket(&s3.DeleteBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	return err
+}
@@ -0,0 +1,252 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// Capabilities tells which requirements of the tests the server and the
+// configuration of mint meet. Features of the server are probed once, the
+// first time a test requires them, in a bucket of their own, and reported
+// as missing when MINT_TEST_BUCKET prohibits creating buckets. Its Supports
+// method is meant to be passed to RunTests.
+type Capabilities struct {
+	config Config
+	client *s3.S3
+
+	mutex  sync.Mutex
+	probed map[string]bool
+}
+
+// NewCapabilities returns the capabilities of the server of config,
+// probed through client
+func NewCapabilities(config Config, client *s3.S3) *Capabilities {
+	return &Capabilities{
+		config: config,
+		client: client,
+		probed: make(map[string]bool),
+	}
+}
+
+// notImplemented turns the error of a probing request into whether the
+// feature is supported: NotImplemented tells it is not, other errors are
+// failures of the probe.
+func notImplemented(err error) (bool, error) {
+	if IsNotImplemented(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Error codes of a request with SSE-KMS to a server without a KMS
+// configured
+var kmsNotConfiguredCodes = map[string]bool{
+	"NotImplemented":                  true,
+	"KMS.NotConfigured":               true,
+	"InvalidArgument":                 true,
+	"InvalidEncryptionAlgorithmError": true,
+}
+
+// createProbeBucket creates the bucket of a probe, holding an object when
+// object is not empty
+func createProbeBucket(client *s3.S3, bucket string, object string, content string) error {
+	_, err := client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil || object == "" {
+		return err
+	}
+	_, err = client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader(content)),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	return err
+}
+
+// Probes of the features of the server, given the name of a bucket to
+// create for the probe
+var probes = map[string]func(client *s3.S3, bucket string) (bool, error){
+	RequiresVersioning: func(client *s3.S3, bucket string) (bool, error) {
+		if err := createProbeBucket(client, bucket, "", ""); err != nil {
+			return false, err
+		}
+		_, err := client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+			Bucket:                  aws.String(bucket),
+			VersioningConfiguration: &s3.VersioningConfiguration{Status: aws.String(s3.BucketVersioningStatusEnabled)},
+		})
+		return notImplemented(err)
+	},
+	RequiresObjectLock: func(client *s3.S3, bucket string) (bool, error) {
+		_, err := client.CreateBucket(&s3.CreateBucketInput{
+			Bucket:                     aws.String(bucket),
+			ObjectLockEnabledForBucket: aws.Bool(true),
+		})
+		return notImplemented(err)
+	},
+	RequiresObjectTagging: func(client *s3.S3, bucket string) (bool, error) {
+		if err := createProbeBucket(client, bucket, "object", "content"); err != nil {
+			return false, err
+		}
+		_, err := client.GetObjectTagging(&s3.GetObjectTaggingInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String("object"),
+		})
+		return notImplemented(err)
+	},
+	RequiresSelect: func(client *s3.S3, bucket string) (bool, error) {
+		if err := createProbeBucket(client, bucket, "object.csv", "a,b\n1,2\n"); err != nil {
+			return false, err
+		}
+		output, err := client.SelectObjectContent(&s3.SelectObjectContentInput{
+			Bucket:         aws.String(bucket),
+			Key:            aws.String("object.csv"),
+			Expression:     aws.String("SELECT * FROM S3Object"),
+			ExpressionType: aws.String(s3.ExpressionTypeSql),
+			InputSerialization: &s3.InputSerialization{
+				CSV: &s3.CSVInput{FileHeaderInfo: aws.String(s3.FileHeaderInfoUse)},
+			},
+			OutputSerialization: &s3.OutputSerialization{CSV: &s3.CSVOutput{}},
+		})
+		if err == nil {
+			output.EventStream.Close()
+		}
+		return notImplemented(err)
+	},
+	RequiresACL: func(client *s3.S3, bucket string) (bool, error) {
+		if err := createProbeBucket(client, bucket, "", ""); err != nil {
+			return false, err
+		}
+		_, err := client.GetBucketAcl(&s3.GetBucketAclInput{
+			Bucket: aws.String(bucket),
+		})
+		return notImplemented(err)
+	},
+	RequiresReplication: func(client *s3.S3, bucket string) (bool, error) {
+		if err := createProbeBucket(client, bucket, "", ""); err != nil {
+			return false, err
+		}
+		// A bucket without rules is enough to tell the API is implemented
+		_, err := client.GetBucketReplication(&s3.GetBucketReplicationInput{
+			Bucket: aws.String(bucket),
+		})
+		if IsErrorCode(err, "ReplicationConfigurationNotFoundError") {
+			return true, nil
+		}
+		return notImplemented(err)
+	},
+	RequiresKMS: func(client *s3.S3, bucket string) (bool, error) {
+		if err := createProbeBucket(client, bucket, "", ""); err != nil {
+			return false, err
+		}
+		_, err := client.PutObject(&s3.PutObjectInput{
+			Body:                 aws.ReadSeekCloser(strings.NewReader("content")),
+			Bucket:               aws.String(bucket),
+			Key:                  aws.String("object"),
+			ServerSideEncryption: aws.String(s3.ServerSideEncryptionAwsKms),
+		})
+		// Servers without a KMS configured reject the SSE header with
+		// errors of their own, other errors are failures of the probe
+		if aerr, ok := err.(awserr.Error); ok && kmsNotConfiguredCodes[aerr.Code()] {
+			return false, nil
+		}
+		return notImplemented(err)
+	},
+}
+
+// Supports reports whether requirement is met. Requirements on the setup
+// are read from the configuration, features of the server are probed, and
+// requirements which can be neither are assumed to be met.
+func (c *Capabilities) Supports(requirement string) bool {
+	switch requirement {
+	case RequiresHTTPS:
+		return c.config.Secure
+	case RequiresFull:
+		return c.config.Full()
+	case RequiresBenchmark:
+		return c.config.Benchmark()
+	case RequiresSecondSite:
+		return c.config.Endpoint2 != ""
+	case RequiresTier:
+		return os.Getenv("TIER_STORAGE_CLASS") != ""
+	case RequiresGetFloor:
+		return os.Getenv("MINT_MIN_GET_MBPS") != ""
+	case RequiresNotification:
+		return os.Getenv("NOTIFY_ARN") != ""
+	case RequiresWebIdentity:
+		return c.config.WebIdentityToken != ""
+	case RequiresMinIO, RequiresAdmin:
+		// The admin API is an extension of MinIO
+		return c.config.MinIO()
+	}
+
+	probe, ok := probes[requirement]
+	if !ok {
+		return true
+	}
+	if c.config.TestBucket != "" {
+		// Probes need a bucket of their own
+		return false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if supported, ok := c.probed[requirement]; ok {
+		return supported
+	}
+	supported := c.probe(requirement, probe)
+	c.probed[requirement] = supported
+	return supported
+}
+
+// probe runs the probe of requirement, then removes its bucket. The run
+// ends when the probe fails.
+func (c *Capabilities) probe(requirement string, probe func(client *s3.S3, bucket string) (bool, error)) bool {
+	startTime := time.Now()
+	function := "probe"
+	bucket := RandString(60, rand.NewSource(time.Now().UnixNano()), "mint-probe-")
+	args := map[string]interface{}{
+		"requirement": requirement,
+		"bucketName":  bucket,
+	}
+
+	supported, err := probe(c.client, bucket)
+	if err != nil {
+		FailureLog(function, args, startTime, "", "Probing the server for "+requirement+" failed", err).Fatal()
+		return false
+	}
+	err = RemoveBucket(c.client, bucket)
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchBucket {
+		err = nil
+	}
+	if err != nil {
+		FailureLog(function, args, startTime, "", "Removing the bucket of the probe for "+requirement+" failed", err).Fatal()
+		return false
+	}
+	return supported
+}
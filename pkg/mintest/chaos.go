@@ -0,0 +1,221 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Delay of the requests slowed down by the chaos proxy
+const chaosLatency = 2 * time.Second
+
+// Faults injected by the chaos proxy into the requests to the server
+const (
+	chaosNone     = iota
+	chaosLatent   // the request reaches the server late
+	chaosReset    // the connection is reset instead of answering
+	chaosTruncate // the connection is closed halfway through the answer
+	chaosFaults   // number of faults, none included
+)
+
+// chaosCounts are the numbers of requests proxied, by fault injected
+var chaosCounts [chaosFaults]int64
+
+// chaosFraction returns the fraction of the requests to the server the
+// chaos proxy injects faults in, as set in MINT_CHAOS, 0 when it is not set
+func chaosFraction() (float64, error) {
+	value := os.Getenv("MINT_CHAOS")
+	if value == "" {
+		return 0, nil
+	}
+	fraction, err := strconv.ParseFloat(value, 64)
+	if err == nil && (fraction < 0 || fraction > 1) {
+		err = fmt.Errorf("%s is not between 0 and 1", value)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("Invalid MINT_CHAOS, %w", err)
+	}
+	return fraction, nil
+}
+
+// chaosProxy is a local TCP proxy to address, injecting faults into a
+// fraction of the requests relayed
+type chaosProxy struct {
+	address  string
+	fraction float64
+	listener net.Listener
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// chaosProxies are the proxies started, by the address they relay to
+var chaosProxies struct {
+	sync.Mutex
+	proxies map[string]*chaosProxy
+}
+
+// chaosDialer returns a dial function connecting through a chaos proxy to
+// the address dialed, started on the first connection to that address.
+// The proxy relays the bytes as they are, so TLS is still negotiated with
+// the server, under the host name of the request.
+func chaosDialer(fraction float64) func(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		chaosProxies.Lock()
+		proxy, ok := chaosProxies.proxies[address]
+		if !ok {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				chaosProxies.Unlock()
+				return nil, err
+			}
+			proxy = &chaosProxy{
+				address:  address,
+				fraction: fraction,
+				listener: listener,
+				rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+			}
+			if chaosProxies.proxies == nil {
+				chaosProxies.proxies = make(map[string]*chaosProxy)
+			}
+			chaosProxies.proxies[address] = proxy
+			go proxy.serve()
+		}
+		chaosProxies.Unlock()
+		return dialer.DialContext(ctx, network, proxy.listener.Addr().String())
+	}
+}
+
+// serve relays the connections accepted by the proxy, until its listener
+// fails
+func (p *chaosProxy) serve() {
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		go p.relay(conn)
+	}
+}
+
+// fault draws the fault injected into a request
+func (p *chaosProxy) fault() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fault := chaosNone
+	if p.rand.Float64() < p.fraction {
+		fault = chaosNone + 1 + p.rand.Intn(chaosFaults-1)
+	}
+	atomic.AddInt64(&chaosCounts[fault], 1)
+	return fault
+}
+
+// relay passes the bytes of client to the server and back. A request
+// starts with the first bytes of the client, and then with the first ones
+// after an answer of the server, which is when the fault injected into
+// it is drawn.
+func (p *chaosProxy) relay(client net.Conn) {
+	defer client.Close()
+	server, err := net.DialTimeout("tcp", p.address, 30*time.Second)
+	if err != nil {
+		return
+	}
+	defer server.Close()
+
+	var mu sync.Mutex
+	fault, answered := chaosNone, true
+	go func() {
+		defer server.Close()
+		defer client.Close()
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := client.Read(buf)
+			if n > 0 {
+				mu.Lock()
+				request := answered
+				if request {
+					fault, answered = p.fault(), false
+				}
+				latent := request && fault == chaosLatent
+				mu.Unlock()
+				if latent {
+					time.Sleep(chaosLatency)
+				}
+				if _, err := server.Write(buf[:n]); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := server.Read(buf)
+		if n > 0 {
+			mu.Lock()
+			injected := fault
+			fault, answered = chaosNone, true
+			mu.Unlock()
+			switch injected {
+			case chaosReset:
+				if tcp, ok := client.(*net.TCPConn); ok {
+					tcp.SetLinger(0)
+				}
+				return
+			case chaosTruncate:
+				client.Write(buf[:n/2])
+				return
+			}
+			if _, err := client.Write(buf[:n]); err != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// reportChaos writes the numbers of faults the chaos proxy injected, if it
+// relayed any request, to stderr
+func reportChaos() {
+	requests := int64(0)
+	for i := range chaosCounts {
+		requests += atomic.LoadInt64(&chaosCounts[i])
+	}
+	if requests == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "MINT_CHAOS: %d requests relayed, %d delayed by %v, %d reset, %d truncated\n", requests,
+		atomic.LoadInt64(&chaosCounts[chaosLatent]), chaosLatency,
+		atomic.LoadInt64(&chaosCounts[chaosReset]), atomic.LoadInt64(&chaosCounts[chaosTruncate]))
+}
@@ -0,0 +1,123 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// checkpointFile records the tests which passed in MINT_CHECKPOINT_FILE,
+// one line per test with the name of its suite, for a run resumed after
+// dying partway to skip them. As a logrus hook, it counts the passes
+// logged, RunTests recording a test when it logged one.
+type checkpointFile struct {
+	path string
+
+	mutex  sync.Mutex
+	passed map[string]bool
+	passes int
+}
+
+// Checkpoint of the run, nil when MINT_CHECKPOINT_FILE is not set
+var checkpoint *checkpointFile
+
+// loadCheckpoint reads the tests recorded in the checkpoint file at path,
+// which may not exist yet
+func loadCheckpoint(path string) (*checkpointFile, error) {
+	c := &checkpointFile{path: path, passed: make(map[string]bool)}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		c.passed[scanner.Text()] = true
+	}
+	return c, scanner.Err()
+}
+
+// checkpointKey names a test of the suite in the checkpoint file, the
+// occurrence telling apart the tests registered several times under the
+// same name
+func checkpointKey(name string, occurrence int) string {
+	if occurrence > 1 {
+		return fmt.Sprintf("%s %s#%d", suiteName, name, occurrence)
+	}
+	return suiteName + " " + name
+}
+
+// Levels implements logrus.Hook
+func (c *checkpointFile) Levels() []log.Level {
+	return log.AllLevels
+}
+
+// Fire implements logrus.Hook
+func (c *checkpointFile) Fire(entry *log.Entry) error {
+	if status, _ := entry.Data["status"].(string); status == PASS {
+		c.mutex.Lock()
+		c.passes++
+		c.mutex.Unlock()
+	}
+	return nil
+}
+
+// passCount returns the number of passes logged so far
+func (c *checkpointFile) passCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.passes
+}
+
+// hasPassed reports whether the test of key passed in a previous run
+func (c *checkpointFile) hasPassed(key string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.passed[key]
+}
+
+// record appends the test of key to the checkpoint file, synced for the
+// record to survive the run dying right after
+func (c *checkpointFile) record(key string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	file, err := os.OpenFile(c.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err = fmt.Fprintln(file, key); err == nil {
+		err = file.Sync()
+	}
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		c.passed[key] = true
+	}
+	return err
+}
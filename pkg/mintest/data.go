@@ -0,0 +1,121 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// DataSeed returns the seed of the generated contents of the run, set in
+// MINT_DATA_SEED to reproduce the contents of a previous run, or picked
+// from the current time otherwise. Tests log it in their args. The run
+// ends when MINT_DATA_SEED is invalid.
+func DataSeed() uint64 {
+	value := os.Getenv("MINT_DATA_SEED")
+	if value == "" {
+		return uint64(time.Now().UnixNano())
+	}
+	seed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		FailureLog("main", map[string]interface{}{"dataSeed": value}, time.Now(), "", "Invalid MINT_DATA_SEED", err).Fatal()
+	}
+	return seed
+}
+
+// DataReader generates size pseudo-random bytes out of a seed, the same
+// seed always giving the same content. Contents of any size can be
+// streamed without being held in memory, and checked with DataSHA256.
+type DataReader struct {
+	seed   uint64
+	size   int64
+	offset int64
+}
+
+// NewDataReader returns a reader of size bytes generated out of seed
+func NewDataReader(seed uint64, size int64) *DataReader {
+	return &DataReader{seed: seed, size: size}
+}
+
+// word returns the i-th 8 bytes of the content, as the output of an
+// xorshift64* step from a state derived from the seed and i, so that any
+// offset can be read without generating what comes before.
+func (r *DataReader) word(i int64) uint64 {
+	x := r.seed ^ uint64(i+1)*0x9e3779b97f4a7c15
+	x ^= x >> 12
+	x ^= x << 25
+	x ^= x >> 27
+	return x * 0x2545f4914f6cdd1d
+}
+
+// Size returns the number of bytes of the content
+func (r *DataReader) Size() int64 {
+	return r.size
+}
+
+// Read implements io.Reader
+func (r *DataReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+	if remaining := r.size - r.offset; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+	var word [8]byte
+	for n := 0; n < len(p); {
+		binary.LittleEndian.PutUint64(word[:], r.word(r.offset/8))
+		copied := copy(p[n:], word[r.offset%8:])
+		n += copied
+		r.offset += int64(copied)
+	}
+	return len(p), nil
+}
+
+// Seek implements io.Seeker
+func (r *DataReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	r.offset = offset
+	return offset, nil
+}
+
+// DataSHA256 returns the hex encoded SHA256 of the content NewDataReader
+// generates out of seed, of size bytes
+func DataSHA256(seed uint64, size int64) string {
+	hash := sha256.New()
+	io.Copy(hash, NewDataReader(seed, size))
+	return hex.EncodeToString(hash.Sum(nil))
+}
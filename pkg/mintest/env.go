@@ -0,0 +1,175 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// Config is the server under test, as passed by mint in the environment
+type Config struct {
+	Endpoint  string // SERVER_ENDPOINT, as HOST:PORT
+	Endpoint2 string // SERVER_ENDPOINT_2, second site of a replicated deployment
+	AccessKey string // ACCESS_KEY
+	SecretKey string // SECRET_KEY
+	Secure    bool   // ENABLE_HTTPS set to 1
+	Region    string // SERVER_REGION, us-east-1 by default
+	Mode      string // MINT_MODE, core by default
+
+	AddressingStyle string // MINT_ADDRESSING_STYLE, path or virtual, path by default
+	Domain          string // DOMAIN of the server, for virtual host style
+
+	SessionToken string // SESSION_TOKEN, set along with temporary credentials
+
+	WebIdentityToken string // WEB_IDENTITY_TOKEN, or OIDC_TOKEN, exchanged for temporary credentials
+	RoleARN          string // ROLE_ARN, assumed with WebIdentityToken, arn:minio:iam:::role/mint by default
+
+	ServerProfile string // MINT_SERVER_PROFILE, minio, aws or generic, minio by default
+
+	CACert             string // MINT_CA_CERT, PEM file of CAs to trust besides the system ones
+	ClientCert         string // MINT_CLIENT_CERT, PEM certificate presented to the server
+	ClientKey          string // MINT_CLIENT_KEY, PEM key of MINT_CLIENT_CERT
+	InsecureSkipVerify bool   // MINT_INSECURE_SKIP_VERIFY set to 1
+
+	TestBucket string // MINT_TEST_BUCKET, existing bucket to test in when buckets may not be created
+}
+
+// Server profiles, telling which implementation of S3 is under test
+const (
+	ProfileMinIO   = "minio"   // MinIO, whose extensions are tested and error codes expected
+	ProfileAWS     = "aws"     // Amazon S3
+	ProfileGeneric = "generic" // any other S3 compatible server
+)
+
+// serverProfile returns the profile set in MINT_SERVER_PROFILE. The run
+// ends when it is not one of the profiles.
+func serverProfile() string {
+	switch profile := os.Getenv("MINT_SERVER_PROFILE"); profile {
+	case "":
+		return ProfileMinIO
+	case ProfileMinIO, ProfileAWS, ProfileGeneric:
+		return profile
+	default:
+		FailureLog("main", map[string]interface{}{"serverProfile": profile}, time.Now(), "", "Invalid MINT_SERVER_PROFILE",
+			fmt.Errorf("%q is not one of %s, %s or %s", profile, ProfileMinIO, ProfileAWS, ProfileGeneric)).Fatal()
+		return ""
+	}
+}
+
+// LoadConfig reads the server configuration from the environment
+func LoadConfig() Config {
+	config := Config{
+		Endpoint:  os.Getenv("SERVER_ENDPOINT"),
+		Endpoint2: os.Getenv("SERVER_ENDPOINT_2"),
+		AccessKey: os.Getenv("ACCESS_KEY"),
+		SecretKey: os.Getenv("SECRET_KEY"),
+		Secure:    os.Getenv("ENABLE_HTTPS") == "1",
+		Region:    os.Getenv("SERVER_REGION"),
+		Mode:      os.Getenv("MINT_MODE"),
+
+		AddressingStyle: os.Getenv("MINT_ADDRESSING_STYLE"),
+		Domain:          os.Getenv("DOMAIN"),
+
+		SessionToken: os.Getenv("SESSION_TOKEN"),
+
+		WebIdentityToken: os.Getenv("WEB_IDENTITY_TOKEN"),
+		RoleARN:          os.Getenv("ROLE_ARN"),
+
+		ServerProfile: serverProfile(),
+
+		CACert:             os.Getenv("MINT_CA_CERT"),
+		ClientCert:         os.Getenv("MINT_CLIENT_CERT"),
+		ClientKey:          os.Getenv("MINT_CLIENT_KEY"),
+		InsecureSkipVerify: os.Getenv("MINT_INSECURE_SKIP_VERIFY") == "1",
+
+		TestBucket: os.Getenv("MINT_TEST_BUCKET"),
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	if config.Mode == "" {
+		config.Mode = "core"
+	}
+	if config.AddressingStyle == "" {
+		config.AddressingStyle = "path"
+	}
+	if config.WebIdentityToken == "" {
+		config.WebIdentityToken = os.Getenv("OIDC_TOKEN")
+	}
+	if config.RoleARN == "" {
+		config.RoleARN = "arn:minio:iam:::role/mint"
+	}
+	return config
+}
+
+// URL returns the base URL of the server
+func (c Config) URL() string {
+	if c.Secure {
+		return "https://" + c.Endpoint
+	}
+	return "http://" + c.Endpoint
+}
+
+// Full reports whether the full set of tests is to be run
+func (c Config) Full() bool {
+	return c.Mode == "full"
+}
+
+// Benchmark reports whether benchmarks are to be run along with the tests
+func (c Config) Benchmark() bool {
+	return c.Mode == "benchmark"
+}
+
+// MinIO reports whether the server under test is MinIO, as opposed to
+// another implementation of S3
+func (c Config) MinIO() bool {
+	return c.ServerProfile == ProfileMinIO
+}
+
+// VirtualHostStyle reports whether buckets are to be addressed in the host
+// name of the requests rather than in their path
+func (c Config) VirtualHostStyle() bool {
+	return c.AddressingStyle == "virtual"
+}
+
+// S3Config returns an aws-sdk-go configuration for the server, using path
+// style requests signed with the configured credentials, along with their
+// session token when there is one. Requests go through HTTPClient.
+func (c Config) S3Config() *aws.Config {
+	return &aws.Config{
+		Credentials:      credentials.NewStaticCredentials(c.AccessKey, c.SecretKey, c.SessionToken),
+		Endpoint:         aws.String(c.URL()),
+		HTTPClient:       c.HTTPClient(),
+		Region:           aws.String(c.Region),
+		S3ForcePathStyle: aws.Bool(true),
+	}
+}
+
+// NewS3Client returns an S3 client for the server
+func (c Config) NewS3Client() *s3.S3 {
+	return s3.New(session.New(), c.S3Config())
+}
@@ -0,0 +1,13 @@
+module mint.minio.io/mintest
+
+go 1.19
+
+require (
+	github.com/aws/aws-sdk-go v1.44.257
+	github.com/sirupsen/logrus v1.9.0
+)
+
+require (
+	github.com/jmespath/go-jmespath v0.4.0 // indirect
+	golang.org/x/sys v0.5.0 // indirect
+)
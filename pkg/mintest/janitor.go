@@ -0,0 +1,148 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	log "github.com/sirupsen/logrus"
+)
+
+// StartJanitor makes the buckets created through the tracked clients and
+// left behind be removed through client when the run ends on a failed
+// test. Failures end the run with os.Exit, which skips deferred calls, so
+// the cleanup is registered as a logrus exit handler. The returned
+// function runs the cleanup as well, to be deferred for the runs ending
+// normally or with a panic.
+func StartJanitor(client *s3.S3) func() {
+	cleanup := func() { CleanupBuckets(client) }
+	log.RegisterExitHandler(cleanup)
+	return cleanup
+}
+
+// CleanupBuckets removes the buckets created through the tracked clients
+// which still exist, along with their versions and uploads, and empties
+// the prefixes of MINT_TEST_BUCKET scopes left behind. Buckets which
+// cannot be removed, like the ones holding objects under compliance
+// retention, are reported as warnings on stderr, out of the mint log.
+func CleanupBuckets(client *s3.S3) {
+	cleanupPrefixes(client)
+
+	bucketOwners.Lock()
+	buckets := make([]string, 0, len(bucketOwners.tests))
+	owners := make(map[string]string, len(bucketOwners.tests))
+	for bucket, test := range bucketOwners.tests {
+		buckets = append(buckets, bucket)
+		owners[bucket] = test
+	}
+	bucketOwners.Unlock()
+	sort.Strings(buckets)
+
+	for _, bucket := range buckets {
+		err := forceRemoveBucket(client, bucket)
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchBucket {
+			err = nil
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: unable to remove bucket %s left behind by %s: %v\n", bucket, owners[bucket], err)
+			continue
+		}
+		bucketOwners.Lock()
+		delete(bucketOwners.tests, bucket)
+		bucketOwners.Unlock()
+	}
+}
+
+// forceRemoveBucket removes a bucket and everything it holds, falling back
+// on MinIO's forced deletion when it cannot be emptied.
+func forceRemoveBucket(client *s3.S3, bucket string) error {
+	err := RemoveBucket(client, bucket)
+	if err == nil {
+		return nil
+	}
+	_, ferr := client.DeleteBucketWithContext(aws.BackgroundContext(), &s3.DeleteBucketInput{
+		Bucket: aws.String(bucket),
+	}, request.WithSetRequestHeaders(map[string]string{"x-minio-force-delete": "true"}))
+	if ferr == nil {
+		return nil
+	}
+	return err
+}
+
+// Prefixes of the names of the buckets created by the Go suites and the
+// capability probes, which RandString follows with the run ID. A suite
+// whose prefix is missing here keeps its buckets on CleanupRun.
+var runBucketPrefixes = []string{
+	"admin-test-",
+	"aws-sdk-go-test-",
+	"aws-sdk-go-v2-test-",
+	"conformance-test-",
+	"minio-go-v7-test-",
+	"mint-probe-",
+	"postpolicy-test-",
+	"replication-test-",
+	"sts-test-",
+	"versioning-test-",
+}
+
+// isRunBucket reports whether bucket was created by the run of the given
+// ID, its name starting with the prefix of a suite followed by the ID,
+// as TestResourceLeaks recognizes the buckets of the current run.
+func isRunBucket(bucket, id string) bool {
+	for _, prefix := range runBucketPrefixes {
+		if strings.HasPrefix(bucket, prefix+id+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// CleanupRun removes the buckets of the run of the given ID, see
+// isRunBucket, along with everything they hold, whichever test or suite
+// created them. It returns the buckets removed, and the first error met.
+func CleanupRun(client *s3.S3, id string) ([]string, error) {
+	output, err := client.ListBuckets(&s3.ListBucketsInput{})
+	if err != nil {
+		return nil, err
+	}
+	var removed []string
+	var firstErr error
+	for _, b := range output.Buckets {
+		bucket := aws.StringValue(b.Name)
+		if !isRunBucket(bucket, id) {
+			continue
+		}
+		if err := forceRemoveBucket(client, bucket); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", bucket, err)
+			}
+			continue
+		}
+		removed = append(removed, bucket)
+	}
+	return removed, firstErr
+}
@@ -0,0 +1,52 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import "testing"
+
+func TestIsRunBucket(t *testing.T) {
+	testCases := []struct {
+		bucket string
+		id     string
+		want   bool
+	}{
+		{"aws-sdk-go-test-ci1234-y4z56kq46qinku", "ci1234", true},
+		{"versioning-test-ci1234-y4z56kq46qinku", "ci1234", true},
+		{"mint-probe-ci1234-y4z56kq46qinku", "ci1234", true},
+		{"aws-sdk-go-v2-test-v2-y4z56kq46qinku", "v2", true},
+		// Other runs
+		{"aws-sdk-go-test-ci1235-y4z56kq46qinku", "ci1234", false},
+		{"aws-sdk-go-test-ci12345-y4z56kq46qinku", "ci1234", false},
+		// IDs which are also parts of the prefixes or of other names
+		{"aws-sdk-go-test-ci1234-y4z56kq46qinku", "test", false},
+		{"aws-sdk-go-test-ci1234-y4z56kq46qinku", "go", false},
+		{"aws-sdk-go-v2-test-ci1234-y4z56kq46qinku", "v2", false},
+		{"aws-sdk-go-test-ci1234-y4z56kq46qinku", "y4z56kq46qinku", false},
+		{"my-data-lake", "data", false},
+		{"backup-ci1234-daily", "ci1234", false},
+		// Buckets of unknown suites
+		{"other-test-ci1234-y4z56kq46qinku", "ci1234", false},
+	}
+	for _, testCase := range testCases {
+		if got := isRunBucket(testCase.bucket, testCase.id); got != testCase.want {
+			t.Errorf("isRunBucket(%q, %q) = %v, want %v", testCase.bucket, testCase.id, got, testCase.want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// junitTestSuites is the root of a JUnit XML report
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite holds the test runs of a mint suite
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     float64         `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is a test run, as logged
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// junitHook adds the test runs logged to the JUnit XML report at path. As
+// the suites run one after the other, the report holds one testsuite per
+// suite, and each suite replaces its own in the report left by the
+// previous ones. The report is rewritten on every test run, for it to be
+// complete when a failure ends the run.
+type junitHook struct {
+	path  string
+	mutex sync.Mutex
+	suite junitTestSuite
+}
+
+// Levels implements logrus.Hook
+func (h *junitHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+// Fire implements logrus.Hook
+func (h *junitHook) Fire(entry *log.Entry) error {
+	status, _ := entry.Data["status"].(string)
+	if status == "" {
+		return nil
+	}
+	function, _ := entry.Data["function"].(string)
+	duration, _ := entry.Data["duration"].(int64)
+	alert, _ := entry.Data["alert"].(string)
+
+	testCase := junitTestCase{
+		Name:      function,
+		ClassName: suiteName,
+		Time:      float64(duration) / 1000,
+	}
+	switch status {
+	case FAIL:
+		message, _ := entry.Data["message"].(string)
+		text := message
+		if err, ok := entry.Data["error"]; ok {
+			text = fmt.Sprintf("%s: %v", message, err)
+		}
+		if source, ok := entry.Data["source"]; ok {
+			text = fmt.Sprintf("%s\n%v", text, source)
+		}
+		testCase.Failure = &junitFailure{Message: message, Type: alert, Text: text}
+	case NA:
+		testCase.Skipped = &junitSkipped{Message: alert}
+	case SKIP:
+		reason, _ := entry.Data["reason"].(string)
+		testCase.Skipped = &junitSkipped{Message: reason}
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.suite.Name = suiteName
+	h.suite.Cases = append(h.suite.Cases, testCase)
+	h.suite.Tests++
+	h.suite.Time += testCase.Time
+	if testCase.Failure != nil {
+		h.suite.Failures++
+	}
+	if testCase.Skipped != nil {
+		h.suite.Skipped++
+	}
+	return h.write()
+}
+
+// write replaces the testsuite of this suite in the report
+func (h *junitHook) write() error {
+	var report junitTestSuites
+	if data, err := ioutil.ReadFile(h.path); err == nil {
+		if err = xml.Unmarshal(data, &report); err != nil {
+			return fmt.Errorf("Failed to parse JUnit report %s, %w", h.path, err)
+		}
+	}
+	replaced := false
+	for i := range report.Suites {
+		if report.Suites[i].Name == h.suite.Name {
+			report.Suites[i] = h.suite
+			replaced = true
+		}
+	}
+	if !replaced {
+		report.Suites = append(report.Suites, h.suite)
+	}
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to marshal JUnit report, %w", err)
+	}
+	// Replace the report at once, for it never to be seen half written
+	tmp, err := ioutil.TempFile(filepath.Dir(h.path), ".junit-*.xml")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(append([]byte(xml.Header), append(data, '\n')...)); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), h.path)
+}
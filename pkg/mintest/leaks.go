@@ -0,0 +1,143 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// bucketOwners maps the buckets created during the run to the test
+// which created them.
+var bucketOwners struct {
+	sync.Mutex
+	tests map[string]string
+}
+
+// callingTest returns the name of the test function in the call stack
+func callingTest() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		name := strings.TrimPrefix(frame.Function, "main.")
+		if name != frame.Function {
+			// Report closures as the function declaring them
+			return strings.Split(name, ".")[0]
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// TrackBuckets records the test creating each bucket through client, for
+// TestResourceLeaks to report the buckets left behind, and forgets the
+// buckets once deleted.
+func TrackBuckets(client *s3.S3) {
+	client.Handlers.Complete.PushBack(func(r *request.Request) {
+		if r.Error != nil {
+			return
+		}
+		bucketOwners.Lock()
+		defer bucketOwners.Unlock()
+		switch input := r.Params.(type) {
+		case *s3.CreateBucketInput:
+			if bucketOwners.tests == nil {
+				bucketOwners.tests = make(map[string]string)
+			}
+			bucketOwners.tests[aws.StringValue(input.Bucket)] = callingTest()
+		case *s3.DeleteBucketInput:
+			delete(bucketOwners.tests, aws.StringValue(input.Bucket))
+		}
+	})
+}
+
+// TestResourceLeaks looks for the buckets created during the run which
+// still exist once all the tests are done, and reports them along with the
+// tests which leaked them. Buckets created through a client which is not
+// tracked are recognized by their prefix, followed by the RunID.
+func TestResourceLeaks(client *s3.S3, bucketPrefix string, runStartTime time.Time) {
+	startTime := time.Now()
+	function := "testResourceLeaks"
+	args := map[string]interface{}{}
+
+	output, err := client.ListBuckets(&s3.ListBucketsInput{})
+	if err != nil {
+		FailureLog(function, args, startTime, "", "ListBuckets failed", err).Fatal()
+		return
+	}
+
+	bucketOwners.Lock()
+	owners := make(map[string]string, len(bucketOwners.tests))
+	for bucket, test := range bucketOwners.tests {
+		owners[bucket] = test
+	}
+	bucketOwners.Unlock()
+
+	leaks := make(map[string]interface{})
+	tests := make(map[string]bool)
+	for _, b := range output.Buckets {
+		bucket := aws.StringValue(b.Name)
+		test, ok := owners[bucket]
+		if !ok {
+			// Not created through a tracked client, but still part of the run
+			if !strings.HasPrefix(bucket, bucketPrefix+RunID()+"-") || aws.TimeValue(b.CreationDate).Before(runStartTime.Truncate(time.Second)) {
+				continue
+			}
+			test = "unknown"
+		}
+
+		leak := map[string]interface{}{"test": test}
+		versions, err := client.ListObjectVersions(&s3.ListObjectVersionsInput{Bucket: b.Name})
+		if err == nil {
+			leak["versions"] = len(versions.Versions) + len(versions.DeleteMarkers)
+		}
+		uploads, err := client.ListMultipartUploads(&s3.ListMultipartUploadsInput{Bucket: b.Name})
+		if err == nil {
+			leak["uploads"] = len(uploads.Uploads)
+		}
+		leaks[bucket] = leak
+		tests[test] = true
+	}
+
+	if len(leaks) > 0 {
+		var names []string
+		for test := range tests {
+			names = append(names, test)
+		}
+		sort.Strings(names)
+		args["leaks"] = leaks
+		FailureLog(function, args, startTime, "resource-leak", fmt.Sprintf("%d buckets leaked by %s", len(leaks), strings.Join(names, ", ")),
+			errors.New("resources left behind after the tests")).Fatal()
+		return
+	}
+
+	SuccessLogger(function, args, startTime).Info()
+}
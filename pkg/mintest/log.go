@@ -0,0 +1,154 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrorResponse is an S3 XML error
+type ErrorResponse struct {
+	XMLName    xml.Name `xml:"Error" json:"-"`
+	Code       string
+	Message    string
+	BucketName string
+	Key        string
+	RequestID  string `xml:"RequestId"`
+	HostID     string `xml:"HostId"`
+
+	// Region where the bucket is located. This header is returned
+	// only in HEAD bucket and ListObjects response.
+	Region string
+
+	// Headers of the returned S3 XML error
+	Headers http.Header `xml:"-" json:"-"`
+}
+
+// JSONFormatter formats log entries as the JSON lines mint expects
+type JSONFormatter struct{}
+
+// Format implements logrus.Formatter
+func (f *JSONFormatter) Format(entry *log.Entry) ([]byte, error) {
+	data := make(log.Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		switch v := v.(type) {
+		case error:
+			// Otherwise errors are ignored by `encoding/json`
+			// https://github.com/sirupsen/logrus/issues/137
+			data[k] = v.Error()
+		default:
+			data[k] = v
+		}
+	}
+
+	serialized, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal fields to JSON, %w", err)
+	}
+	return append(serialized, '\n'), nil
+}
+
+// failureFields returns suite specific fields added to failed test runs
+var failureFields func(startTime time.Time) log.Fields
+
+// SetFailureFields makes fields returned by f, given the start time of the
+// test, be logged along with every failed test run.
+func SetFailureFields(f func(startTime time.Time) log.Fields) {
+	failureFields = f
+}
+
+// entry returns the fields common to all the test runs
+func entry(function string, args map[string]interface{}, startTime time.Time, status string) log.Fields {
+	testFinished()
+	fields := log.Fields{
+		"name": suiteName, "function": function, "args": args,
+		"duration": time.Since(startTime).Nanoseconds() / 1000000, "status": status,
+	}
+	// Timings of tests which did not complete are dropped
+	if timings := takeTimings(); timings != nil && status == PASS {
+		fields["timings"] = timings
+	}
+	return fields
+}
+
+// caller returns the location of the first caller outside of this package
+func caller() string {
+	pcs := make([]uintptr, 16)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		// Goroutines of this package, like the watchdog, have only runtime frames left
+		if !strings.HasPrefix(frame.Function, "mint.minio.io/mintest.") && !strings.HasPrefix(frame.Function, "runtime.") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// SuccessLogger logs successful test runs
+func SuccessLogger(function string, args map[string]interface{}, startTime time.Time) *log.Entry {
+	return log.WithFields(entry(function, args, startTime, PASS))
+}
+
+// IgnoreLog logs test runs which are not applicable, alert telling why
+func IgnoreLog(function string, args map[string]interface{}, startTime time.Time, alert string) *log.Entry {
+	fields := entry(function, args, startTime, NA)
+	fields["alert"] = alert
+	return log.WithFields(fields)
+}
+
+// SkipLogger logs tests which were not run, reason telling why
+func SkipLogger(function string, args map[string]interface{}, startTime time.Time, reason string) *log.Entry {
+	fields := entry(function, args, startTime, SKIP)
+	fields["reason"] = reason
+	return log.WithFields(fields)
+}
+
+// FailureLog logs failed test runs, along with the location they failed at
+func FailureLog(function string, args map[string]interface{}, startTime time.Time, alert string, message string, err error) *log.Entry {
+	fields := entry(function, args, startTime, FAIL)
+	fields["alert"] = alert
+	fields["message"] = message
+	if err != nil {
+		fields["error"] = err
+	}
+	if source := caller(); source != "" {
+		fields["source"] = source
+	}
+	if failureFields != nil {
+		for k, v := range failureFields(startTime) {
+			fields[k] = v
+		}
+	}
+	if records := tracesSince(startTime); len(records) > 0 {
+		fields["trace"] = records
+	}
+	return log.WithFields(fields)
+}
@@ -0,0 +1,73 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+// Package mintest holds what the Go test suites of mint share: the mint
+// JSON log format and JUnit XML reports, the registry of their tests and
+// the probes of the capabilities they require, server configuration from
+// the environment, bucket lifecycle helpers, and reproducible object
+// contents.
+package mintest
+
+import (
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Status of a test run, as logged
+const (
+	PASS = "PASS" // Indicate that a test passed
+	FAIL = "FAIL" // Indicate that a test failed
+	NA   = "NA"   // Indicate that a test is not applicable
+	SKIP = "SKIP" // Indicate that a test was not run, its requirements not being met
+)
+
+// Name of the suite, logged with every test run
+var suiteName string
+
+// Init sets the name of the suite and makes the log entries follow the
+// mint format on stdout. Success cases are logged at Info level, failures
+// at Fatal level. When MINT_JUNIT_FILE is set, the test runs are reported
+// in that JUnit XML file as well, and when MINT_CHECKPOINT_FILE is set,
+// the tests passed are recorded in that file for RunTests to skip them
+// when the run is resumed. Tests running for too long are failed, see
+// startWatchdog.
+func Init(name string) {
+	suiteName = name
+	log.SetOutput(os.Stdout)
+	log.SetFormatter(&JSONFormatter{})
+	log.SetLevel(log.InfoLevel)
+	if path := os.Getenv("MINT_JUNIT_FILE"); path != "" {
+		log.AddHook(&junitHook{path: path})
+	}
+	if path := os.Getenv("MINT_CHECKPOINT_FILE"); path != "" {
+		var err error
+		if checkpoint, err = loadCheckpoint(path); err != nil {
+			FailureLog("main", map[string]interface{}{"checkpointFile": path}, time.Now(), "", "Unable to read the checkpoint file", err).Fatal()
+		}
+		log.AddHook(checkpoint)
+	}
+	// An invalid MINT_RUN_ID, MINT_DATA_SEED or MINT_SERVER_PROFILE ends
+	// the run before any test
+	RunID()
+	DataSeed()
+	serverProfile()
+	startWatchdog()
+}
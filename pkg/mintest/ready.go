@@ -0,0 +1,110 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Time waited for the server to get ready by default
+const defaultStartupTimeout = 2 * time.Minute
+
+// Longest wait between two checks of the server
+const maxReadyBackoff = 10 * time.Second
+
+// checkHealth GETs a MinIO health endpoint, returning whether the server
+// serves it at all and an error when it reports not being ready.
+func checkHealth(config Config, endpoint string) (bool, error) {
+	client := &http.Client{Transport: config.Transport()}
+	defer client.CloseIdleConnections()
+	resp, err := client.Get(config.URL() + endpoint)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound, http.StatusForbidden, http.StatusMethodNotAllowed:
+		return false, nil
+	}
+	return true, fmt.Errorf("%s answered %s", endpoint, resp.Status)
+}
+
+// serverReady reports through an error why the server of config is not
+// ready to be tested yet. MinIO servers are checked through their liveness
+// and cluster health endpoints, other servers by listing the buckets.
+func serverReady(config Config) error {
+	served, err := checkHealth(config, "/minio/health/live")
+	if err != nil {
+		return err
+	}
+	if served {
+		_, err = checkHealth(config, "/minio/health/cluster")
+		return err
+	}
+	_, err = config.NewS3Client().ListBuckets(nil)
+	return err
+}
+
+// waitReady waits for the server of config to be ready, checking it again
+// with an exponential backoff for up to MINT_STARTUP_TIMEOUT, 2 minutes by
+// default. The run ends with a failure of alert "infrastructure" when the
+// server is still not ready by then, rather than with confusing failures
+// of the first tests.
+func waitReady(config Config) {
+	startTime := time.Now()
+	function := "waitReady"
+	args := map[string]interface{}{
+		"endpoint": config.Endpoint,
+	}
+
+	timeout, err := durationEnv("MINT_STARTUP_TIMEOUT")
+	if err != nil {
+		FailureLog(function, args, startTime, "", "Invalid MINT_STARTUP_TIMEOUT", err).Fatal()
+		return
+	}
+	if timeout == 0 {
+		timeout = defaultStartupTimeout
+	}
+	args["startupTimeout"] = timeout.String()
+
+	backoff := 500 * time.Millisecond
+	for {
+		err = serverReady(config)
+		if err == nil {
+			return
+		}
+		remaining := timeout - time.Since(startTime)
+		if remaining <= 0 {
+			break
+		}
+		if backoff > remaining {
+			backoff = remaining
+		}
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > maxReadyBackoff {
+			backoff = maxReadyBackoff
+		}
+	}
+	FailureLog(function, args, startTime, "infrastructure", fmt.Sprintf("Server %s not ready after %v", config.Endpoint, timeout), err).Fatal()
+}
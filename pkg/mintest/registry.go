@@ -0,0 +1,159 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Requirements of the tests beyond a plain S3 server, listed along with
+// them so that they can be scheduled on the right setups
+const (
+	RequiresHTTPS         = "https"          // the server is reached over TLS
+	RequiresVersioning    = "versioning"     // buckets can be versioned
+	RequiresObjectLock    = "object-lock"    // buckets can be created with object locking
+	RequiresObjectTagging = "object-tagging" // objects can be tagged
+	RequiresSelect        = "select"         // objects can be queried with S3 Select
+	RequiresACL           = "acl"            // ACLs can be read and set
+	RequiresKMS           = "kms"            // objects can be encrypted with SSE-KMS
+	RequiresTier          = "tier"           // TIER_STORAGE_CLASS is a remote tier
+	RequiresNotification  = "notification"   // NOTIFY_ARN is a notification target
+	RequiresReplication   = "replication"    // buckets can have replication rules
+	RequiresSecondSite    = "second-site"    // SERVER_ENDPOINT_2 replicates with the server
+	RequiresAdmin         = "admin"          // the credentials are the ones of the admin
+	RequiresWebIdentity   = "web-identity"   // WEB_IDENTITY_TOKEN or OIDC_TOKEN is set
+	RequiresFull          = "full"           // MINT_MODE is full
+	RequiresBenchmark     = "benchmark"      // MINT_MODE is benchmark
+	RequiresGetFloor      = "get-floor"      // MINT_MIN_GET_MBPS is set
+	RequiresMinIO         = "minio"          // MINT_SERVER_PROFILE is minio, for its extensions of S3
+)
+
+// Test is a test of a suite, registered with RunTests
+type Test struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Requires    []string `json:"requires,omitempty"`
+	Scoped      bool     `json:"scoped,omitempty"` // keeps its objects in a Scope, so runs with MINT_TEST_BUCKET
+	Run         func()   `json:"-"`
+}
+
+// listingTests reports whether the tests are to be listed rather than run,
+// with MINT_LIST_TESTS set to 1 or the -list flag.
+func listingTests() bool {
+	if os.Getenv("MINT_LIST_TESTS") == "1" {
+		return true
+	}
+	for _, arg := range os.Args[1:] {
+		if arg == "-list" || arg == "--list" {
+			return true
+		}
+	}
+	return false
+}
+
+// RunTests waits for the server to be ready, see waitReady, then runs
+// tests in order. The ones with a requirement that supported reports as
+// not met, like Capabilities.Supports does, are logged as SKIP rather than
+// run, and so are the ones which are not Scoped when MINT_TEST_BUCKET
+// prohibits creating buckets. A nil supported meets every requirement,
+// which is left to the tests to check. When listing the tests, see
+// ListTests, none of them is run. With MINT_CHECKPOINT_FILE set, the tests
+// which passed are recorded there, and the ones recorded by a previous run
+// are skipped. Given the -cleanup-run flag with a run ID, the buckets of
+// that run are removed instead of running the tests, see CleanupRun. With
+// MINT_CHAOS set, the faults injected into the requests
+// are reported once the tests are run.
+func RunTests(tests []Test, supported func(requirement string) bool) {
+	ListTests(tests)
+	if id := cleanupRunArg(); id != "" {
+		runCleanup(id)
+	}
+	config := LoadConfig()
+	waitReady(config)
+	occurrences := make(map[string]int)
+	for _, test := range tests {
+		occurrences[test.Name]++
+		key := checkpointKey(test.Name, occurrences[test.Name])
+		if checkpoint != nil && checkpoint.hasPassed(key) {
+			SkipLogger(test.Name, map[string]interface{}{"checkpointFile": checkpoint.path}, time.Now(),
+				"Passed in a previous run, recorded in MINT_CHECKPOINT_FILE").Info()
+			continue
+		}
+		if config.TestBucket != "" && !test.Scoped {
+			SkipLogger(test.Name, map[string]interface{}{"testBucket": config.TestBucket}, time.Now(),
+				"Creates buckets, which MINT_TEST_BUCKET is set to avoid").Info()
+			continue
+		}
+		if requirement := test.unsupported(supported); requirement != "" {
+			SkipLogger(test.Name, map[string]interface{}{"requires": test.Requires}, time.Now(),
+				fmt.Sprintf("Requires %s, which is not supported", requirement)).Info()
+			continue
+		}
+		testStarted(test.Name)
+		if checkpoint == nil {
+			test.Run()
+			continue
+		}
+		passes := checkpoint.passCount()
+		test.Run()
+		if checkpoint.passCount() > passes {
+			if err := checkpoint.record(key); err != nil {
+				fmt.Fprintf(os.Stderr, "WARNING: unable to record %s in checkpoint file %s: %v\n", key, checkpoint.path, err)
+			}
+		}
+	}
+	reportChaos()
+}
+
+// ListTests prints each of tests as a JSON line, with the name of the
+// suite, its description and its requirements, then exits, when the tests
+// are to be listed rather than run. It is called by RunTests, and before
+// anything reaches the server, so that the suites can be listed without
+// one; suites which may stop before RunTests call it beforehand.
+func ListTests(tests []Test) {
+	if !listingTests() {
+		return
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	for _, test := range tests {
+		encoder.Encode(struct {
+			Suite string `json:"suite"`
+			Test
+		}{suiteName, test})
+	}
+	os.Exit(0)
+}
+
+// unsupported returns the first requirement of t that supported reports
+// as not met, if any
+func (t Test) unsupported(supported func(requirement string) bool) string {
+	if supported == nil {
+		return ""
+	}
+	for _, requirement := range t.Requires {
+		if !supported(requirement) {
+			return requirement
+		}
+	}
+	return ""
+}
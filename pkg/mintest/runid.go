@@ -0,0 +1,112 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Longest run ID, for the bucket names carrying it to stay within 63
+// characters
+const maxRunIDLength = 16
+
+// runID is the ID of the run, read or generated once
+var runID struct {
+	sync.Once
+	id string
+}
+
+// validRunID reports whether id can be part of bucket names, and told
+// apart from the rest of them, being lower case letters and digits only
+func validRunID(id string) bool {
+	if id == "" || len(id) > maxRunIDLength {
+		return false
+	}
+	for _, c := range id {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// RunID returns the ID of the run set in MINT_RUN_ID, by mint.sh for all
+// the suites of a run, or generated for the suite when it is not set.
+// RandString puts it in the names it generates after a prefix, so that
+// concurrent runs against the same server do not collide, and the buckets
+// of a run can be told apart, see CleanupRun. The run ends when
+// MINT_RUN_ID is invalid.
+func RunID() string {
+	runID.Do(func() {
+		runID.id = os.Getenv("MINT_RUN_ID")
+		if runID.id == "" {
+			random := rand.New(rand.NewSource(time.Now().UnixNano()))
+			b := make([]byte, 8)
+			for i := range b {
+				b[i] = letterBytes[random.Intn(len(letterBytes))]
+			}
+			runID.id = string(b)
+		}
+		if !validRunID(runID.id) {
+			FailureLog("main", map[string]interface{}{"runId": runID.id}, time.Now(), "", "Invalid MINT_RUN_ID",
+				fmt.Errorf("%q is not 1 to %d lower case letters and digits", runID.id, maxRunIDLength)).Fatal()
+		}
+	})
+	return runID.id
+}
+
+// cleanupRunArg returns the run ID given with the -cleanup-run flag, empty
+// when it is not given
+func cleanupRunArg() string {
+	args := os.Args[1:]
+	for i, arg := range args {
+		arg = strings.TrimPrefix(arg, "-")
+		if value := strings.TrimPrefix(arg, "-cleanup-run="); value != arg {
+			return value
+		}
+		if arg == "-cleanup-run" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// runCleanup removes the buckets of run id with CleanupRun, listing them on
+// stdout, then exits, with status 1 when some could not be removed.
+func runCleanup(id string) {
+	if !validRunID(id) {
+		fmt.Fprintf(os.Stderr, "Invalid run ID %q, expected 1 to %d lower case letters and digits\n", id, maxRunIDLength)
+		os.Exit(1)
+	}
+	removed, err := CleanupRun(LoadConfig().NewS3Client(), id)
+	for _, bucket := range removed {
+		fmt.Println(bucket)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to remove all the buckets of run %s: %v\n", id, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
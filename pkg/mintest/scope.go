@@ -0,0 +1,108 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// Scope is where a test keeps its objects: a bucket of its own, or a key
+// prefix of the bucket set in MINT_TEST_BUCKET, for setups where buckets
+// may not be created.
+type Scope struct {
+	Bucket string
+	Prefix string // empty when the bucket is the one of the test
+}
+
+// prefixOwners maps the prefixes of MINT_TEST_BUCKET handed out during
+// the run to the test which got them, until they are emptied.
+var prefixOwners struct {
+	sync.Mutex
+	tests map[string]string
+}
+
+// NewScope creates the bucket name for a test, or, when MINT_TEST_BUCKET
+// is set, returns the prefix name/ of that bucket instead. Tests using a
+// scope are to be registered as Scoped.
+func NewScope(client *s3.S3, name string) (Scope, error) {
+	bucket := os.Getenv("MINT_TEST_BUCKET")
+	if bucket == "" {
+		_, err := client.CreateBucket(&s3.CreateBucketInput{
+			Bucket: aws.String(name),
+		})
+		return Scope{Bucket: name}, err
+	}
+
+	scope := Scope{Bucket: bucket, Prefix: name + "/"}
+	prefixOwners.Lock()
+	defer prefixOwners.Unlock()
+	if prefixOwners.tests == nil {
+		prefixOwners.tests = make(map[string]string)
+	}
+	prefixOwners.tests[scope.Prefix] = callingTest()
+	return scope, nil
+}
+
+// Key returns the key of object in the scope
+func (s Scope) Key(object string) string {
+	return s.Prefix + object
+}
+
+// Remove removes the bucket of the scope, or only the objects and uploads
+// under its prefix of MINT_TEST_BUCKET.
+func (s Scope) Remove(client *s3.S3) error {
+	if s.Prefix == "" {
+		return RemoveBucket(client, s.Bucket)
+	}
+	if err := emptyPrefix(client, s.Bucket, s.Prefix); err != nil {
+		return err
+	}
+	prefixOwners.Lock()
+	delete(prefixOwners.tests, s.Prefix)
+	prefixOwners.Unlock()
+	return nil
+}
+
+// cleanupPrefixes empties the prefixes of MINT_TEST_BUCKET left behind,
+// reporting the ones which cannot be like CleanupBuckets does.
+func cleanupPrefixes(client *s3.S3) {
+	bucket := os.Getenv("MINT_TEST_BUCKET")
+	prefixOwners.Lock()
+	prefixes := make([]string, 0, len(prefixOwners.tests))
+	owners := make(map[string]string, len(prefixOwners.tests))
+	for prefix, test := range prefixOwners.tests {
+		prefixes = append(prefixes, prefix)
+		owners[prefix] = test
+	}
+	prefixOwners.Unlock()
+	sort.Strings(prefixes)
+
+	for _, prefix := range prefixes {
+		if err := (Scope{Bucket: bucket, Prefix: prefix}).Remove(client); err != nil {
+			fmt.Fprintf(os.Stderr, "WARNING: unable to empty %s/%s left behind by %s: %v\n", bucket, prefix, owners[prefix], err)
+		}
+	}
+}
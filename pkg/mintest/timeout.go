@@ -0,0 +1,139 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Time given to the exit handlers, like the janitor, once a test timed out
+const timeoutExitDelay = time.Minute
+
+// Interval between two checks of the running test
+const watchdogInterval = time.Second
+
+// lastResult is when the last test result was logged, which is when the
+// running test started, along with the name of the test RunTests runs.
+var lastResult struct {
+	sync.Mutex
+	time time.Time
+	test string
+}
+
+// testFinished records the end of a test, thus the start of the next one
+func testFinished() {
+	lastResult.Lock()
+	lastResult.time = time.Now()
+	lastResult.Unlock()
+}
+
+// testStarted records the name of the test RunTests is about to run
+func testStarted(name string) {
+	lastResult.Lock()
+	lastResult.test = name
+	lastResult.Unlock()
+}
+
+// durationEnv returns the duration set in the environment variable name,
+// 0 when it is not set
+func durationEnv(name string) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid %s, %w", name, err)
+	}
+	return d, nil
+}
+
+// runDeadline returns when MINT_GLOBAL_DEADLINE passed since mint started,
+// which is MINT_RUN_START in Unix seconds as set by mint.sh, or else since
+// suiteStart. It is zero without a global deadline.
+func runDeadline(suiteStart time.Time) (time.Time, error) {
+	timeout, err := durationEnv("MINT_GLOBAL_DEADLINE")
+	if err != nil || timeout == 0 {
+		return time.Time{}, err
+	}
+	runStart := suiteStart
+	if value := os.Getenv("MINT_RUN_START"); value != "" {
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("Invalid MINT_RUN_START, %w", err)
+		}
+		runStart = time.Unix(seconds, 0)
+	}
+	return runStart.Add(timeout), nil
+}
+
+// startWatchdog fails the running test with the alert "timeout" once it
+// runs for longer than MINT_TEST_TIMEOUT, or once the global deadline is
+// reached. The tests do not take a context, so instead of cancelling the
+// test the watchdog ends the run as a failed test does, which leaves the
+// exit handlers some time to clean up.
+func startWatchdog() {
+	suiteStart := time.Now()
+	testTimeout, err := durationEnv("MINT_TEST_TIMEOUT")
+	if err != nil {
+		FailureLog("watchdog", map[string]interface{}{}, suiteStart, "", "Invalid test timeout", err).Fatal()
+		return
+	}
+	deadline, err := runDeadline(suiteStart)
+	if err != nil {
+		FailureLog("watchdog", map[string]interface{}{}, suiteStart, "", "Invalid global deadline", err).Fatal()
+		return
+	}
+	if testTimeout == 0 && deadline.IsZero() {
+		return
+	}
+
+	testFinished()
+	go func() {
+		for range time.Tick(watchdogInterval) {
+			lastResult.Lock()
+			startTime, test := lastResult.time, lastResult.test
+			lastResult.Unlock()
+			if test == "" {
+				test = "unknown"
+			}
+
+			args := map[string]interface{}{}
+			var message string
+			switch {
+			case testTimeout > 0 && time.Since(startTime) > testTimeout:
+				args["testTimeout"] = testTimeout.String()
+				message = fmt.Sprintf("Test did not finish within %s", testTimeout)
+			case !deadline.IsZero() && time.Now().After(deadline):
+				args["deadline"] = deadline.UTC().Format(time.RFC3339)
+				message = "Global deadline reached"
+			default:
+				continue
+			}
+			// Exit even when the exit handlers hang on the server
+			time.AfterFunc(timeoutExitDelay, func() { os.Exit(1) })
+			FailureLog(test, args, startTime, "timeout", message, nil).Fatal()
+		}
+	}()
+}
@@ -0,0 +1,72 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"sync"
+	"time"
+)
+
+// timings are the durations of the operations of the running test, by name
+var timings struct {
+	sync.Mutex
+	durations map[string]time.Duration
+}
+
+// RecordTiming adds d to the time spent by the running test on operation,
+// which is logged in milliseconds under "timings" when the test passes.
+// Operations run more than once add up.
+func RecordTiming(operation string, d time.Duration) {
+	timings.Lock()
+	defer timings.Unlock()
+	if timings.durations == nil {
+		timings.durations = make(map[string]time.Duration)
+	}
+	timings.durations[operation] += d
+}
+
+// StartTiming starts timing operation for the running test, until the
+// returned function is called. For instance:
+//
+//	stop := mintest.StartTiming("putObject")
+//	_, err := s3Client.PutObject(input)
+//	stop()
+func StartTiming(operation string) func() {
+	start := time.Now()
+	return func() {
+		RecordTiming(operation, time.Since(start))
+	}
+}
+
+// takeTimings returns the timings of the test which just finished, in
+// milliseconds, and resets them for the next one
+func takeTimings() map[string]int64 {
+	timings.Lock()
+	defer timings.Unlock()
+	if len(timings.durations) == 0 {
+		return nil
+	}
+	result := make(map[string]int64, len(timings.durations))
+	for operation, d := range timings.durations {
+		result[operation] = d.Milliseconds()
+	}
+	timings.durations = nil
+	return result
+}
@@ -0,0 +1,99 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// TLSConfig returns the TLS configuration of the requests to the server,
+// nil when the defaults of Go apply, which already trust the CAs of
+// SSL_CERT_FILE. The CAs of MINT_CA_CERT are trusted along with the ones
+// of the system, MINT_CLIENT_CERT and MINT_CLIENT_KEY are presented to
+// servers requiring client certificates, and the certificate of the
+// server is not verified when MINT_INSECURE_SKIP_VERIFY is set to 1.
+func (c Config) TLSConfig() (*tls.Config, error) {
+	if c.CACert == "" && c.ClientCert == "" && c.ClientKey == "" && !c.InsecureSkipVerify {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.CACert != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := os.ReadFile(c.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid MINT_CA_CERT, %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("Invalid MINT_CA_CERT, no PEM certificate in %s", c.CACert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if c.ClientCert != "" || c.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCert, c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid MINT_CLIENT_CERT or MINT_CLIENT_KEY, %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// Transport returns a new transport of requests to the server, using the
+// TLS configuration of TLSConfig. Like the default transport, it sends
+// them through the proxies of HTTP_PROXY and HTTPS_PROXY, except to the
+// hosts of NO_PROXY. With MINT_CHAOS set, the connections go
+// through a local proxy injecting faults into that fraction of the
+// requests, see chaosDialer. The run ends when either is invalid.
+func (c Config) Transport() *http.Transport {
+	tlsConfig, err := c.TLSConfig()
+	if err != nil {
+		FailureLog("tls", map[string]interface{}{"endpoint": c.Endpoint}, time.Now(), "", "Invalid TLS configuration", err).Fatal()
+	}
+	fraction, err := chaosFraction()
+	if err != nil {
+		FailureLog("chaos", map[string]interface{}{"endpoint": c.Endpoint}, time.Now(), "", "Invalid chaos configuration", err).Fatal()
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	if fraction > 0 {
+		transport.DialContext = chaosDialer(fraction)
+	}
+	return transport
+}
+
+// HTTPClient returns a new HTTP client for requests to the server, going
+// through Transport and traced as told by TraceTransport
+func (c Config) HTTPClient() *http.Client {
+	return &http.Client{Transport: TraceTransport(c.Transport())}
+}
@@ -0,0 +1,202 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// Number of the last request/response pairs kept for the failure logs
+	traceSize = 20
+	// Bytes of the bodies of a request or response kept in its trace
+	traceBodyLimit = 2048
+)
+
+// Headers and query parameters whose values are not traced
+var (
+	redactedHeaders = []string{
+		"Authorization",
+		"X-Amz-Security-Token",
+		"X-Amz-Server-Side-Encryption-Customer-Key",
+		"X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key",
+	}
+	redactedQuery = []string{"X-Amz-Signature", "X-Amz-Security-Token", "X-Amz-Credential"}
+)
+
+// traceRecord is a request and its response, as logged with the failures
+type traceRecord struct {
+	Time            time.Time   `json:"time"`
+	Method          string      `json:"method"`
+	URL             string      `json:"url"`
+	RequestHeaders  http.Header `json:"requestHeaders"`
+	RequestBody     string      `json:"requestBody,omitempty"`
+	Status          int         `json:"status,omitempty"`
+	ResponseHeaders http.Header `json:"responseHeaders,omitempty"`
+	ResponseBody    string      `json:"responseBody,omitempty"`
+	Error           string      `json:"error,omitempty"`
+
+	// Beginnings of the bodies, filled in as they are read
+	requestBody  *bodyRecorder
+	responseBody *bodyRecorder
+}
+
+// bodyRecorder passes a body through, keeping its first bytes
+type bodyRecorder struct {
+	io.ReadCloser
+	mu   sync.Mutex
+	head []byte
+}
+
+func (b *bodyRecorder) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.mu.Lock()
+	if room := traceBodyLimit - len(b.head); room > 0 {
+		if room > n {
+			room = n
+		}
+		b.head = append(b.head, p[:room]...)
+	}
+	b.mu.Unlock()
+	return n, err
+}
+
+func (b *bodyRecorder) String() string {
+	if b == nil {
+		return ""
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.head)
+}
+
+// traces are the last requests sent through a traced transport
+var traces struct {
+	sync.Mutex
+	records []*traceRecord
+}
+
+// tracingEnabled reports whether MINT_TRACE_ON_FAIL asks for the requests
+// to be logged along with the failures
+func tracingEnabled() bool {
+	return os.Getenv("MINT_TRACE_ON_FAIL") == "1"
+}
+
+// tracingTransport records the requests sent through next
+type tracingTransport struct {
+	next http.RoundTripper
+}
+
+// TraceTransport returns next recording the requests sent through it when
+// MINT_TRACE_ON_FAIL is set to 1, for the last ones to be logged with the
+// failure of a test. It returns next as is otherwise.
+func TraceTransport(next http.RoundTripper) http.RoundTripper {
+	if !tracingEnabled() {
+		return next
+	}
+	return &tracingTransport{next: next}
+}
+
+// redactHeaders returns a copy of h without the values of secret headers
+func redactHeaders(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range redactedHeaders {
+		if h.Get(name) != "" {
+			h.Set(name, "REDACTED")
+		}
+	}
+	return h
+}
+
+// redactURL returns u without the values of secret query parameters
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	redacted := false
+	for _, name := range redactedQuery {
+		if query.Get(name) != "" {
+			query.Set(name, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+	c := *u
+	c.RawQuery = query.Encode()
+	return c.String()
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	record := &traceRecord{
+		Time:           time.Now(),
+		Method:         req.Method,
+		URL:            redactURL(req.URL),
+		RequestHeaders: redactHeaders(req.Header),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		// The request must not be changed by the transport, send a copy
+		record.requestBody = &bodyRecorder{ReadCloser: req.Body}
+		req = req.Clone(req.Context())
+		req.Body = record.requestBody
+	}
+	traces.Lock()
+	traces.records = append(traces.records, record)
+	if len(traces.records) > traceSize {
+		traces.records = traces.records[len(traces.records)-traceSize:]
+	}
+	traces.Unlock()
+
+	resp, err := t.next.RoundTrip(req)
+	traces.Lock()
+	defer traces.Unlock()
+	if err != nil {
+		record.Error = err.Error()
+		return resp, err
+	}
+	record.Status = resp.StatusCode
+	record.ResponseHeaders = redactHeaders(resp.Header)
+	record.responseBody = &bodyRecorder{ReadCloser: resp.Body}
+	resp.Body = record.responseBody
+	return resp, nil
+}
+
+// tracesSince returns the traces of the requests sent since startTime,
+// with the beginnings of their bodies read so far
+func tracesSince(startTime time.Time) []traceRecord {
+	traces.Lock()
+	defer traces.Unlock()
+	var records []traceRecord
+	for _, record := range traces.records {
+		if record.Time.Before(startTime) {
+			continue
+		}
+		r := *record
+		r.RequestBody = record.requestBody.String()
+		r.ResponseBody = record.responseBody.String()
+		records = append(records, r)
+	}
+	return records
+}
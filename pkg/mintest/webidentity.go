@@ -0,0 +1,59 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package mintest
+
+import (
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// Name of the sessions of the role assumed with a web identity token
+const webIdentitySessionName = "mint"
+
+// webIdentityToken is an OpenID Connect token given in the environment
+// rather than in a file, as stscreds expects by default
+type webIdentityToken string
+
+// FetchToken returns the token
+func (t webIdentityToken) FetchToken(credentials.Context) ([]byte, error) {
+	return []byte(t), nil
+}
+
+// WebIdentityCredentials returns credentials obtained by assuming RoleARN
+// with AssumeRoleWithWebIdentity, given WebIdentityToken. The STS request
+// is sent unsigned to the server, which serves STS along with S3. The
+// temporary credentials are retrieved on first use, and again once they
+// expire.
+func (c Config) WebIdentityCredentials() *credentials.Credentials {
+	stsClient := sts.New(session.New(), c.S3Config())
+	return credentials.NewCredentials(stscreds.NewWebIdentityRoleProviderWithOptions(
+		stsClient, c.RoleARN, webIdentitySessionName, webIdentityToken(c.WebIdentityToken)))
+}
+
+// NewWebIdentityS3Client returns an S3 client for the server signing with
+// WebIdentityCredentials
+func (c Config) NewWebIdentityS3Client() *s3.S3 {
+	s3Config := c.S3Config()
+	s3Config.Credentials = c.WebIdentityCredentials()
+	return s3.New(session.New(), s3Config)
+}
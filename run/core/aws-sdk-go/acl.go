@@ -0,0 +1,266 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Grantee URIs of the predefined groups
+const (
+	allUsersGroup           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	authenticatedUsersGroup = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+// isObjectACLImplemented reports whether the server supports object ACLs
+// granting access to others than the owner, probing it with a public-read
+// canned ACL.
+func isObjectACLImplemented(s3Client *s3.S3) bool {
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "")
+	startTime := time.Now()
+	function := "isObjectACLImplemented"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return false
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader("testfile")),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		return false
+	}
+
+	_, err = s3Client.PutObjectAcl(&s3.PutObjectAclInput{
+		ACL:    aws.String(s3.ObjectCannedACLPublicRead),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	return !mintest.IsNotImplemented(err)
+}
+
+// hasGroupGrant reports whether grants hold permission for the group with
+// the given URI.
+func hasGroupGrant(grants []*s3.Grant, group, permission string) bool {
+	for _, grant := range grants {
+		if grant.Grantee == nil || aws.StringValue(grant.Permission) != permission {
+			continue
+		}
+		if aws.StringValue(grant.Grantee.URI) == group {
+			return true
+		}
+	}
+	return false
+}
+
+// hasOwnerGrant reports whether grants hold full control for the canonical
+// user owner.
+func hasOwnerGrant(grants []*s3.Grant, owner string) bool {
+	for _, grant := range grants {
+		if grant.Grantee == nil || aws.StringValue(grant.Permission) != s3.PermissionFullControl {
+			continue
+		}
+		if aws.StringValue(grant.Grantee.Type) == s3.TypeCanonicalUser && aws.StringValue(grant.Grantee.ID) == owner {
+			return true
+		}
+	}
+	return false
+}
+
+// Upload an object with the private canned ACL and check its ACL grants
+// full control to its owner, then set the private canned ACL again with
+// PutObjectAcl. Every server supporting ACLs at all supports this one.
+func testObjectACL(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testObjectACL"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		ACL:    aws.String(s3.ObjectCannedACLPrivate),
+		Body:   aws.ReadSeekCloser(strings.NewReader("acl")),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT with private ACL expected to success but got %v", err), err).Fatal()
+		return
+	}
+
+	aclOutput, err := s3Client.GetObjectAcl(&s3.GetObjectAclInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		if mintest.IsNotImplemented(err) {
+			mintest.IgnoreLog(function, args, startTime, "GetObjectAcl is NotImplemented").Info()
+			return
+		}
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObjectAcl Failed", err).Fatal()
+		return
+	}
+	if aclOutput.Owner == nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObjectAcl expected an owner", nil).Fatal()
+		return
+	}
+	// MinIO has no canonical user IDs, and leaves the owner ID empty
+	if !hasOwnerGrant(aclOutput.Grants, aws.StringValue(aclOutput.Owner.ID)) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObjectAcl expected FULL_CONTROL for the owner but got %v", aclOutput.Grants), nil).Fatal()
+		return
+	}
+	for _, group := range []string{allUsersGroup, authenticatedUsersGroup} {
+		if hasGroupGrant(aclOutput.Grants, group, s3.PermissionRead) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObjectAcl of a private object granted READ to %s", group), nil).Fatal()
+			return
+		}
+	}
+
+	_, err = s3Client.PutObjectAcl(&s3.PutObjectAclInput{
+		ACL:    aws.String(s3.ObjectCannedACLPrivate),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutObjectAcl with private ACL Failed", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Set the public-read and authenticated-read canned ACLs on objects, with
+// PutObject and PutObjectAcl, and check the grants GetObjectAcl returns.
+// Servers only supporting private ACLs, like MinIO, are skipped.
+func testObjectCannedACLs(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testObjectCannedACLs"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	if !isObjectACLImplemented(s3Client) {
+		mintest.IgnoreLog(function, args, startTime, "Object ACLs other than private are NotImplemented").Info()
+		return
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	cannedACLs := map[string]string{
+		s3.ObjectCannedACLPublicRead:        allUsersGroup,
+		s3.ObjectCannedACLAuthenticatedRead: authenticatedUsersGroup,
+	}
+	for acl, group := range cannedACLs {
+		args["acl"] = acl
+
+		_, err = s3Client.PutObject(&s3.PutObjectInput{
+			ACL:    aws.String(acl),
+			Body:   aws.ReadSeekCloser(strings.NewReader("acl")),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT with %s ACL expected to success but got %v", acl, err), err).Fatal()
+			return
+		}
+		aclOutput, err := s3Client.GetObjectAcl(&s3.GetObjectAclInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObjectAcl Failed", err).Fatal()
+			return
+		}
+		if !hasGroupGrant(aclOutput.Grants, group, s3.PermissionRead) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObjectAcl after PUT with %s ACL expected READ for %s but got %v", acl, group, aclOutput.Grants), nil).Fatal()
+			return
+		}
+
+		// Reset the ACL, then set it again on the existing object
+		for _, cannedACL := range []string{s3.ObjectCannedACLPrivate, acl} {
+			_, err = s3Client.PutObjectAcl(&s3.PutObjectAclInput{
+				ACL:    aws.String(cannedACL),
+				Bucket: aws.String(bucket),
+				Key:    aws.String(object),
+			})
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PutObjectAcl with %s ACL Failed", cannedACL), err).Fatal()
+				return
+			}
+			aclOutput, err = s3Client.GetObjectAcl(&s3.GetObjectAclInput{
+				Bucket: aws.String(bucket),
+				Key:    aws.String(object),
+			})
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObjectAcl Failed", err).Fatal()
+				return
+			}
+			if granted := hasGroupGrant(aclOutput.Grants, group, s3.PermissionRead); granted != (cannedACL == acl) {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObjectAcl after PutObjectAcl with %s ACL returned unexpected grants %v", cannedACL, aclOutput.Grants), nil).Fatal()
+				return
+			}
+		}
+	}
+	delete(args, "acl")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
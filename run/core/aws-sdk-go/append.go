@@ -0,0 +1,131 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// writeOffset is the request option appending a PUT at offset of the
+// existing object, with x-amz-write-offset-bytes
+func writeOffset(offset int) request.Option {
+	return request.WithSetRequestHeaders(map[string]string{"X-Amz-Write-Offset-Bytes": strconv.Itoa(offset)})
+}
+
+// Append chunks to an object with PUTs at x-amz-write-offset-bytes, which
+// must match the size of the object, and check the chunks end up in order.
+// PUTs at an offset before or past the end of the object must fail with
+// InvalidWriteOffset and leave it untouched. Servers which do not append
+// are skipped, the first append probing for it.
+func testAppendObject(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testAppendObject"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	chunks := []string{"first chunk,", " second chunk,", " third chunk,", " and the last one"}
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"chunks":     chunks,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	putObject := func(content string, opts ...request.Option) error {
+		_, err := s3Client.PutObjectWithContext(aws.BackgroundContext(), &s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader(content)),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		}, opts...)
+		return err
+	}
+	if err = putObject(chunks[0]); err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		return
+	}
+
+	content := chunks[0]
+	for i, chunk := range chunks[1:] {
+		args["offset"] = len(content)
+		err = putObject(chunk, writeOffset(len(content)))
+		if i == 0 && mintest.IsNotImplemented(err) {
+			mintest.IgnoreLog(function, args, startTime, "Appending to objects is not implemented").Info()
+			return
+		}
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT at offset %d expected to success but got %v", len(content), err), err).Fatal()
+			return
+		}
+		data, err := getObjectContent(s3Client, bucket, object)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject Failed", err).Fatal()
+			return
+		}
+		// A server ignoring the header replaces the object instead
+		if i == 0 && string(data) == chunk {
+			mintest.IgnoreLog(function, args, startTime, "Appending to objects is not supported, x-amz-write-offset-bytes is ignored").Info()
+			return
+		}
+		content += chunk
+		if string(data) != content {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject after appending at offset %d expected %q but got %q", len(content)-len(chunk), content, data), errors.New("appended content mismatch")).Fatal()
+			return
+		}
+	}
+
+	for _, offset := range []int{0, len(content) - 1, len(content) + 1} {
+		args["offset"] = offset
+		err = putObject("misplaced chunk", writeOffset(offset))
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "InvalidWriteOffset" {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT at offset %d of an object of %d bytes expected to fail with InvalidWriteOffset but got %v", offset, len(content), err), err).Fatal()
+			return
+		}
+	}
+	delete(args, "offset")
+	data, err := getObjectContent(s3Client, bucket, object)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject Failed", err).Fatal()
+		return
+	}
+	if string(data) != content {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject after PUTs at wrong offsets expected %q but got %q", content, data), errors.New("object changed by a rejected append")).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
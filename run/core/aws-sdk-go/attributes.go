@@ -0,0 +1,252 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// objectPartSizes returns the part numbers and sizes of parts
+func objectPartSizes(parts []*s3.ObjectPart) map[int64]int64 {
+	sizes := make(map[int64]int64)
+	for _, part := range parts {
+		sizes[aws.Int64Value(part.PartNumber)] = aws.Int64Value(part.Size)
+	}
+	return sizes
+}
+
+// checkObjectParts checks GetObjectAttributes reports the object size and
+// the parts of a multipart object of rangeObjectSize, first all at once,
+// then two by two following NextPartNumberMarker.
+func checkObjectParts(s3Client *s3.S3, bucket, object string, sseKey *string) error {
+	expected := map[int64]int64{1: rangePartSize, 2: rangePartSize, 3: rangeObjectSize - 2*rangePartSize}
+	getAttributes := func(maxParts, marker *int64) (*s3.GetObjectAttributesOutput, error) {
+		return s3Client.GetObjectAttributes(&s3.GetObjectAttributesInput{
+			Bucket:               aws.String(bucket),
+			Key:                  aws.String(object),
+			MaxParts:             maxParts,
+			ObjectAttributes:     aws.StringSlice([]string{s3.ObjectAttributesObjectSize, s3.ObjectAttributesObjectParts}),
+			PartNumberMarker:     marker,
+			SSECustomerAlgorithm: sseCustomerAlgorithm(sseKey),
+			SSECustomerKey:       sseKey,
+		})
+	}
+
+	output, err := getAttributes(nil, nil)
+	if err != nil {
+		return fmt.Errorf("GetObjectAttributes failed, %w", err)
+	}
+	if aws.Int64Value(output.ObjectSize) != rangeObjectSize {
+		return fmt.Errorf("GetObjectAttributes expected object size %d but got %d", rangeObjectSize, aws.Int64Value(output.ObjectSize))
+	}
+	if output.ObjectParts == nil || aws.Int64Value(output.ObjectParts.TotalPartsCount) != 3 || aws.BoolValue(output.ObjectParts.IsTruncated) ||
+		!reflect.DeepEqual(objectPartSizes(output.ObjectParts.Parts), expected) {
+		return fmt.Errorf("GetObjectAttributes expected 3 parts of sizes %v but got %v", expected, output.ObjectParts)
+	}
+
+	parts := make(map[int64]int64)
+	var marker *int64
+	for page := 0; ; page++ {
+		output, err = getAttributes(aws.Int64(2), marker)
+		if err != nil {
+			return fmt.Errorf("GetObjectAttributes with MaxParts 2 and PartNumberMarker %d failed, %w", aws.Int64Value(marker), err)
+		}
+		objectParts := output.ObjectParts
+		if objectParts == nil || len(objectParts.Parts) > 2 || page > 1 {
+			return fmt.Errorf("GetObjectAttributes with MaxParts 2 returned %v at page %d", objectParts, page)
+		}
+		for number, size := range objectPartSizes(objectParts.Parts) {
+			parts[number] = size
+		}
+		if !aws.BoolValue(objectParts.IsTruncated) {
+			break
+		}
+		if next := aws.Int64Value(objectParts.NextPartNumberMarker); next != 2 {
+			return fmt.Errorf("GetObjectAttributes with MaxParts 2 expected NextPartNumberMarker 2 but got %d", next)
+		}
+		marker = objectParts.NextPartNumberMarker
+	}
+	if marker == nil || !reflect.DeepEqual(parts, expected) {
+		return fmt.Errorf("GetObjectAttributes with MaxParts 2 expected 2 pages of parts of sizes %v but got %v", expected, parts)
+	}
+	return nil
+}
+
+// Get the attributes of a multipart object, its parts all at once and
+// page by page, and of a previous version of it by version ID. Unknown
+// attribute names must be rejected with InvalidArgument.
+func testGetObjectAttributesMultipart(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testGetObjectAttributesMultipart"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "multipart"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	// Version IDs are only checked when the server supports versioning
+	_, err = s3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket:                  aws.String(bucket),
+		VersioningConfiguration: &s3.VersioningConfiguration{Status: aws.String(s3.BucketVersioningStatusEnabled)},
+	})
+	versioned := err == nil
+	if err != nil && !mintest.IsNotImplemented(err) {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutBucketVersioning Failed", err).Fatal()
+		return
+	}
+	args["versioned"] = versioned
+
+	firstContent := "first version"
+	putOutput, err := s3Client.PutObject(&s3.PutObjectInput{
+		Body:   bytes.NewReader([]byte(firstContent)),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		return
+	}
+
+	seed := mintest.DataSeed()
+	args["seed"] = seed
+	content, err := ioutil.ReadAll(mintest.NewDataReader(seed, rangeObjectSize))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Generating the object content failed", err).Fatal()
+		return
+	}
+	if err = putMultipartContent(s3Client, bucket, object, content, nil); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go multipart upload Failed", err).Fatal()
+		return
+	}
+	if err = checkObjectParts(s3Client, bucket, object, nil); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObjectAttributes of a multipart object Failed", err).Fatal()
+		return
+	}
+
+	if versioned {
+		output, err := s3Client.GetObjectAttributes(&s3.GetObjectAttributesInput{
+			Bucket:           aws.String(bucket),
+			Key:              aws.String(object),
+			ObjectAttributes: aws.StringSlice([]string{s3.ObjectAttributesObjectSize, s3.ObjectAttributesObjectParts}),
+			VersionId:        putOutput.VersionId,
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObjectAttributes of a previous version Failed", err).Fatal()
+			return
+		}
+		if aws.StringValue(output.VersionId) != aws.StringValue(putOutput.VersionId) || aws.Int64Value(output.ObjectSize) != int64(len(firstContent)) ||
+			(output.ObjectParts != nil && len(output.ObjectParts.Parts) > 0) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObjectAttributes of version %s expected a single part object of %d bytes but got version %s of %d bytes with parts %v",
+				aws.StringValue(putOutput.VersionId), len(firstContent), aws.StringValue(output.VersionId), aws.Int64Value(output.ObjectSize), output.ObjectParts),
+				errors.New("version attributes mismatch")).Fatal()
+			return
+		}
+	}
+
+	_, err = s3Client.GetObjectAttributes(&s3.GetObjectAttributesInput{
+		Bucket:           aws.String(bucket),
+		Key:              aws.String(object),
+		ObjectAttributes: aws.StringSlice([]string{"UnknownAttribute"}),
+	})
+	if rerr, ok := err.(awserr.RequestFailure); !ok || rerr.StatusCode() != http.StatusBadRequest || rerr.Code() != "InvalidArgument" {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObjectAttributes of an unknown attribute expected to fail with InvalidArgument", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Get the attributes of an SSE-C encrypted multipart object, which needs
+// its key.
+func testGetObjectAttributesSSEC(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testGetObjectAttributesSSEC"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "multipart"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, "", function, args, startTime, true)
+
+	seed := mintest.DataSeed()
+	args["seed"] = seed
+	content, err := ioutil.ReadAll(mintest.NewDataReader(seed, rangeObjectSize))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Generating the object content failed", err).Fatal()
+		return
+	}
+	sseKey := aws.String("32byteslongsecretkeymustbegiven1")
+	defer cleanup(s3Client, bucket, object, function, args, startTime, false)
+	if err = putMultipartContent(s3Client, bucket, object, content, sseKey); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go SSE-C multipart upload Failed", err).Fatal()
+		return
+	}
+	if err = checkObjectParts(s3Client, bucket, object, sseKey); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObjectAttributes of an SSE-C multipart object Failed", err).Fatal()
+		return
+	}
+
+	_, err = s3Client.GetObjectAttributes(&s3.GetObjectAttributesInput{
+		Bucket:           aws.String(bucket),
+		Key:              aws.String(object),
+		ObjectAttributes: aws.StringSlice([]string{s3.ObjectAttributesObjectSize}),
+	})
+	if rerr, ok := err.(awserr.RequestFailure); !ok || rerr.StatusCode() != http.StatusBadRequest {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObjectAttributes of an SSE-C object without its key expected to fail with status 400 but got %v", err), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
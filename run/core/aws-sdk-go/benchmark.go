@@ -0,0 +1,177 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Number of objects listed when MINT_BENCH_LIST_OBJECTS is not set
+const defaultBenchmarkListObjects = 100000
+
+// listMetrics are the results of the listing benchmark, logged along with
+// its PASS record
+type listMetrics struct {
+	Objects           int     `json:"objects"`
+	Pages             int     `json:"pages"`
+	PutSeconds        float64 `json:"putSeconds"`
+	ListSeconds       float64 `json:"listSeconds"`
+	ListObjectsPerSec float64 `json:"listObjectsPerSecond"`
+	PageLatencyP50    float64 `json:"pageLatencyP50Ms"`
+	PageLatencyP99    float64 `json:"pageLatencyP99Ms"`
+	PageLatencyMax    float64 `json:"pageLatencyMaxMs"`
+	DeleteSeconds     float64 `json:"deleteSeconds"`
+}
+
+// Fill a bucket with MINT_BENCH_LIST_OBJECTS empty objects through
+// concurrent PUTs, then list all of them with ListObjectsV2 pages of 1000
+// keys, timing the whole listing and each page. The listing must return
+// every key exactly once in lexical order. Only run in benchmark mode.
+func benchmarkListObjects(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "benchmarkListObjects"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	args := map[string]interface{}{
+		"bucketName": bucket,
+	}
+
+	_, _, concurrency, err := mintest.BenchmarkSettings()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Invalid benchmark settings", err).Fatal()
+		return
+	}
+	objects, err := mintest.PositiveInt("MINT_BENCH_LIST_OBJECTS", defaultBenchmarkListObjects)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Invalid benchmark settings", err).Fatal()
+		return
+	}
+	args["objects"] = objects
+	args["concurrency"] = concurrency
+
+	_, err = s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	// Numbers are not padded, for the lexical order to differ from the
+	// order they are written in
+	keys := make([]string, objects)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("list/%d", i)
+	}
+	putElapsed, _, err := mintest.RunBenchmark(objects, concurrency, func(i int) error {
+		_, err := s3Client.PutObject(&s3.PutObjectInput{
+			Body:   strings.NewReader(""),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(keys[i]),
+		})
+		return err
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutObject Failed", err).Fatal()
+		return
+	}
+	sort.Strings(keys)
+
+	var listed []string
+	var pageLatencies []time.Duration
+	listStart := time.Now()
+	pageStart := listStart
+	err = s3Client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucket),
+		MaxKeys: aws.Int64(1000),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		pageLatencies = append(pageLatencies, time.Since(pageStart))
+		for _, object := range page.Contents {
+			listed = append(listed, aws.StringValue(object.Key))
+		}
+		pageStart = time.Now()
+		return true
+	})
+	listElapsed := time.Since(listStart)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListObjectsV2 Failed", err).Fatal()
+		return
+	}
+	if len(listed) != objects {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 expected %d keys but got %d", objects, len(listed)), nil).Fatal()
+		return
+	}
+	for i, key := range listed {
+		if key != keys[i] {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 expected key %q at position %d but got %q", keys[i], i, key), nil).Fatal()
+			return
+		}
+	}
+
+	// Batches of 1000 keys, for the cleanup not to delete them one by one
+	batches := (objects + 999) / 1000
+	deleteElapsed, _, err := mintest.RunBenchmark(batches, concurrency, func(i int) error {
+		end := (i + 1) * 1000
+		if end > objects {
+			end = objects
+		}
+		var identifiers []*s3.ObjectIdentifier
+		for _, key := range keys[i*1000 : end] {
+			identifiers = append(identifiers, &s3.ObjectIdentifier{Key: aws.String(key)})
+		}
+		output, err := s3Client.DeleteObjects(&s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &s3.Delete{Objects: identifiers, Quiet: aws.Bool(true)},
+		})
+		if err == nil && len(output.Errors) > 0 {
+			err = fmt.Errorf("deleting %s failed: %s", aws.StringValue(output.Errors[0].Key), aws.StringValue(output.Errors[0].Message))
+		}
+		return err
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteObjects Failed", err).Fatal()
+		return
+	}
+
+	pageMetrics := mintest.NewBenchmarkMetrics(0, listElapsed, pageLatencies)
+	mintest.SuccessLogger(function, args, startTime).WithField("metrics", listMetrics{
+		Objects:           objects,
+		Pages:             len(pageLatencies),
+		PutSeconds:        putElapsed.Seconds(),
+		ListSeconds:       listElapsed.Seconds(),
+		ListObjectsPerSec: float64(objects) / listElapsed.Seconds(),
+		PageLatencyP50:    pageMetrics.LatencyP50,
+		PageLatencyP99:    pageMetrics.LatencyP99,
+		PageLatencyMax:    pageMetrics.LatencyMax,
+		DeleteSeconds:     deleteElapsed.Seconds(),
+	}).Info()
+}
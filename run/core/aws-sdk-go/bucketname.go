@@ -0,0 +1,183 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// newAddressedS3Client returns an S3 client addressing buckets in the
+// style set by MINT_ADDRESSING_STYLE. Virtual host style requests are sent
+// to DOMAIN, when set, on the port of the server.
+func newAddressedS3Client(config mintest.Config) *s3.S3 {
+	awsConfig := config.S3Config()
+	if config.VirtualHostStyle() {
+		awsConfig.S3ForcePathStyle = aws.Bool(false)
+		if config.Domain != "" {
+			_, port, _ := net.SplitHostPort(config.Endpoint)
+			config.Endpoint = net.JoinHostPort(config.Domain, port)
+			awsConfig.Endpoint = aws.String(config.URL())
+		}
+	}
+	s3Client := s3.New(session.New(), awsConfig)
+	mintest.TrackBuckets(s3Client)
+	return s3Client
+}
+
+// Create buckets with names at the edges of the naming rules: of the
+// maximum length, with dots, and with consecutive hyphens. Each must be
+// usable to store and read an object, and be addressed in the style the
+// client is configured with. aws-sdk-go falls back on path style for the
+// names with dots over TLS, as they do not match the server certificate.
+func testBucketNameAddressing(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testBucketNameAddressing"
+	object := "testObject"
+	pathStyle := aws.BoolValue(s3Client.Config.S3ForcePathStyle)
+	secure := strings.HasPrefix(aws.StringValue(s3Client.Config.Endpoint), "https://")
+	args := map[string]interface{}{
+		"objectName": object,
+		"pathStyle":  pathStyle,
+	}
+
+	// Named after the run as RandString does, short enough for the run ID
+	prefix := bucketPrefix + mintest.RunID() + "-"
+	suffix := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "")[:12]
+	buckets := []string{
+		prefix + suffix + strings.Repeat("a", 63-len(prefix)-len(suffix)),
+		prefix + "with.dots." + suffix,
+		prefix + "hyphens--" + suffix,
+	}
+	data := []byte(mintest.RandString(100, rand.NewSource(time.Now().UnixNano()), ""))
+
+	for _, bucket := range buckets {
+		args["bucketName"] = bucket
+
+		_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+			return
+		}
+
+		req, _ := s3Client.HeadBucketRequest(&s3.HeadBucketInput{
+			Bucket: aws.String(bucket),
+		})
+		if err = req.Send(); err != nil {
+			cleanup(s3Client, bucket, object, function, args, startTime, true)
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadBucket Failed", err).Fatal()
+			return
+		}
+		virtualHost := !pathStyle && !(secure && strings.Contains(bucket, "."))
+		url := req.HTTPRequest.URL
+		if virtualHost != strings.HasPrefix(url.Host, bucket+".") || virtualHost == strings.HasPrefix(url.Path, "/"+bucket) {
+			cleanup(s3Client, bucket, object, function, args, startTime, true)
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadBucket expected virtual host style %t but was sent to %s", virtualHost, url), nil).Fatal()
+			return
+		}
+
+		_, err = s3Client.PutObject(&s3.PutObjectInput{
+			Body:   bytes.NewReader(data),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			cleanup(s3Client, bucket, object, function, args, startTime, true)
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutObject Failed", err).Fatal()
+			return
+		}
+		got, err := getObjectContent(s3Client, bucket, object)
+		if err != nil {
+			cleanup(s3Client, bucket, object, function, args, startTime, true)
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject Failed", err).Fatal()
+			return
+		}
+		if !bytes.Equal(got, data) {
+			cleanup(s3Client, bucket, object, function, args, startTime, true)
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject returned unexpected content", nil).Fatal()
+			return
+		}
+
+		cleanup(s3Client, bucket, object, function, args, startTime, true)
+	}
+	delete(args, "bucketName")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Creating buckets whose names break the naming rules must fail with
+// InvalidBucketName.
+func testInvalidBucketNames(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testInvalidBucketNames"
+	args := map[string]interface{}{}
+
+	suffix := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "")
+	testCases := []struct {
+		bucket string
+		reason string
+	}{
+		{"ab", "too short"},
+		{bucketPrefix + suffix + strings.Repeat("a", 64-len(bucketPrefix)-len(suffix)), "too long"},
+		{bucketPrefix + "Upper" + suffix, "uppercase letters"},
+		{bucketPrefix + "under_score" + suffix, "an underscore"},
+		{bucketPrefix + "double..dots" + suffix, "consecutive dots"},
+		{bucketPrefix + "dot.-hyphen" + suffix, "a dot next to a hyphen"},
+		{"." + bucketPrefix + suffix, "a leading dot"},
+		{"-" + bucketPrefix + suffix, "a leading hyphen"},
+		{bucketPrefix + suffix + "-", "a trailing hyphen"},
+		{"192.168.5.4", "an IP address"},
+	}
+	for _, testCase := range testCases {
+		args["bucketName"] = testCase.bucket
+		args["reason"] = testCase.reason
+
+		_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+			Bucket: aws.String(testCase.bucket),
+		})
+		if err == nil {
+			s3Client.DeleteBucket(&s3.DeleteBucketInput{
+				Bucket: aws.String(testCase.bucket),
+			})
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CreateBucket of a name with %s expected to fail", testCase.reason), nil).Fatal()
+			return
+		}
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "InvalidBucketName" {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CreateBucket of a name with %s expected InvalidBucketName but got %v", testCase.reason, err), err).Fatal()
+			return
+		}
+	}
+	delete(args, "bucketName")
+	delete(args, "reason")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
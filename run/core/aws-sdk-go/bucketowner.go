@@ -0,0 +1,188 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"mint.minio.io/mintest"
+)
+
+// Account ID which owns no bucket
+const wrongBucketOwner = "111122223333"
+
+// bucketOwnerOp is an operation sending owner as the expected bucket owner
+type bucketOwnerOp struct {
+	name string
+	run  func(owner string) error
+}
+
+// bucketOwnerOps returns PUT, GET, list and DELETE operations on object.
+// PUT writes content and GET checks it reads it back.
+func bucketOwnerOps(s3Client *s3.S3, bucket, object, content string) []bucketOwnerOp {
+	return []bucketOwnerOp{
+		{"PutObject", func(owner string) error {
+			_, err := s3Client.PutObject(&s3.PutObjectInput{
+				Body:                aws.ReadSeekCloser(strings.NewReader(content)),
+				Bucket:              aws.String(bucket),
+				Key:                 aws.String(object),
+				ExpectedBucketOwner: aws.String(owner),
+			})
+			return err
+		}},
+		{"GetObject", func(owner string) error {
+			output, err := s3Client.GetObject(&s3.GetObjectInput{
+				Bucket:              aws.String(bucket),
+				Key:                 aws.String(object),
+				ExpectedBucketOwner: aws.String(owner),
+			})
+			if err != nil {
+				return err
+			}
+			defer output.Body.Close()
+			body, err := ioutil.ReadAll(output.Body)
+			if err == nil && string(body) != content {
+				err = fmt.Errorf("read %q, expected %q", body, content)
+			}
+			return err
+		}},
+		{"ListObjectsV2", func(owner string) error {
+			output, err := s3Client.ListObjectsV2(&s3.ListObjectsV2Input{
+				Bucket:              aws.String(bucket),
+				ExpectedBucketOwner: aws.String(owner),
+			})
+			if err == nil && (len(output.Contents) != 1 || aws.StringValue(output.Contents[0].Key) != object) {
+				err = fmt.Errorf("listed %v, expected only %s", output.Contents, object)
+			}
+			return err
+		}},
+		{"DeleteObject", func(owner string) error {
+			_, err := s3Client.DeleteObject(&s3.DeleteObjectInput{
+				Bucket:              aws.String(bucket),
+				Key:                 aws.String(object),
+				ExpectedBucketOwner: aws.String(owner),
+			})
+			return err
+		}},
+	}
+}
+
+// Send PUT, GET, list and DELETE requests expecting the bucket to be owned
+// by another account, which S3 rejects with AccessDenied, leaving the
+// object as is. MinIO ignores the header, so the requests must then all
+// behave as without it. The requests must succeed when expecting the
+// account of the credentials, when known through GetCallerIdentity.
+func testExpectedBucketOwner(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testExpectedBucketOwner"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	content := "owned content"
+	args := map[string]interface{}{
+		"bucketName":    bucket,
+		"objectName":    object,
+		"expectedOwner": wrongBucketOwner,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader(content)),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutObject Failed", err).Fatal()
+		return
+	}
+
+	// Whether the header is enforced is told by the first request, the
+	// others must behave the same way
+	var enforced *bool
+	for _, op := range bucketOwnerOps(s3Client, bucket, object, content) {
+		args["operation"] = op.name
+		err = op.run(wrongBucketOwner)
+		aerr, denied := err.(awserr.Error)
+		denied = denied && aerr.Code() == "AccessDenied"
+		if err != nil && !denied {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go %s with the wrong expected bucket owner expected AccessDenied or success but got %v", op.name, err), err).Fatal()
+			return
+		}
+		if enforced == nil {
+			enforced = &denied
+		}
+		if denied != *enforced {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go %s with the wrong expected bucket owner got %v unlike the previous requests", op.name, err), err).Fatal()
+			return
+		}
+	}
+	delete(args, "operation")
+	if *enforced {
+		args["ownerCheck"] = "enforced"
+		_, err = s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadObject expected the object to be kept after denied requests", err).Fatal()
+			return
+		}
+	} else {
+		args["ownerCheck"] = "ignored"
+	}
+
+	identity, err := sts.New(session.New(), s3Client.Config.Copy()).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil || aws.StringValue(identity.Account) == "" {
+		if *enforced {
+			mintest.IgnoreLog(function, args, startTime, "The account of the credentials is unknown, only the wrong bucket owner was checked").Info()
+		} else {
+			mintest.SuccessLogger(function, args, startTime).Info()
+		}
+		return
+	}
+	owner := aws.StringValue(identity.Account)
+	args["expectedOwner"] = owner
+	for _, op := range bucketOwnerOps(s3Client, bucket, object, content) {
+		args["operation"] = op.name
+		if err = op.run(owner); err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go %s with the right expected bucket owner expected to succeed but got %v", op.name, err), err).Fatal()
+			return
+		}
+	}
+	delete(args, "operation")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,429 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Reversed polynomial of CRC64NVME, which aws-sdk-go v1 does not know of
+const crc64NVMEPolynomial = 0x9a6c9329ac4bc9b5
+
+// Values of x-amz-checksum-type
+const (
+	checksumTypeComposite  = "COMPOSITE"
+	checksumTypeFullObject = "FULL_OBJECT"
+)
+
+// checksumAlgorithm is an x-amz-checksum-algorithm, along with the hash
+// its checksums are computed with
+type checksumAlgorithm struct {
+	name    string
+	newHash func() hash.Hash
+}
+
+var checksumAlgorithms = []checksumAlgorithm{
+	{"CRC32", func() hash.Hash { return crc32.NewIEEE() }},
+	{"CRC32C", func() hash.Hash { return crc32.New(crc32.MakeTable(crc32.Castagnoli)) }},
+	{"CRC64NVME", func() hash.Hash { return crc64.New(crc64.MakeTable(crc64NVMEPolynomial)) }},
+	{"SHA1", sha1.New},
+	{"SHA256", sha256.New},
+}
+
+func findChecksumAlgorithm(name string) checksumAlgorithm {
+	for _, algorithm := range checksumAlgorithms {
+		if algorithm.name == name {
+			return algorithm
+		}
+	}
+	panic("unknown checksum algorithm " + name)
+}
+
+// header returns the header the checksums of the algorithm are sent in
+func (a checksumAlgorithm) header() string {
+	return "x-amz-checksum-" + strings.ToLower(a.name)
+}
+
+func (a checksumAlgorithm) sum(data []byte) []byte {
+	h := a.newHash()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// checksum returns the base64 encoded checksum of data
+func (a checksumAlgorithm) checksum(data []byte) string {
+	return base64.StdEncoding.EncodeToString(a.sum(data))
+}
+
+// compositeChecksum returns the checksum of a multipart object of the
+// given parts, computed over the checksums of the parts.
+func (a checksumAlgorithm) compositeChecksum(parts [][]byte) string {
+	var sums []byte
+	for _, part := range parts {
+		sums = append(sums, a.sum(part)...)
+	}
+	return fmt.Sprintf("%s-%d", a.checksum(sums), len(parts))
+}
+
+// isChecksumMismatch reports whether err is the error returned for a
+// checksum not matching the data, which AWS names BadDigest
+func isChecksumMismatch(err error) bool {
+	return mintest.IsErrorCode(err, "XAmzContentChecksumMismatch")
+}
+
+// headChecksum returns the checksum type and the checksum value of the
+// given algorithm stored along with bucket/object. They are read from the
+// response headers, as aws-sdk-go v1 does not know all of them.
+func headChecksum(s3Client *s3.S3, bucket, object string, algorithm checksumAlgorithm) (string, string, error) {
+	req, _ := s3Client.HeadObjectRequest(&s3.HeadObjectInput{
+		Bucket:       aws.String(bucket),
+		Key:          aws.String(object),
+		ChecksumMode: aws.String(s3.ChecksumModeEnabled),
+	})
+	if err := req.Send(); err != nil {
+		return "", "", err
+	}
+	return req.HTTPResponse.Header.Get("x-amz-checksum-type"), req.HTTPResponse.Header.Get(algorithm.header()), nil
+}
+
+// Upload an object with its checksum in each of the CRC32, CRC32C,
+// CRC64NVME, SHA1 and SHA256 algorithms, and check HeadObject returns it.
+// Uploads with a checksum not matching the data must be rejected.
+func testPutObjectChecksums(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testPutObjectChecksums"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	data := []byte(mintest.RandString(1000, rand.NewSource(time.Now().UnixNano()), ""))
+	put := func(header, checksum string) error {
+		_, err := s3Client.PutObjectWithContext(aws.BackgroundContext(), &s3.PutObjectInput{
+			Body:   bytes.NewReader(data),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		}, request.WithSetRequestHeaders(map[string]string{header: checksum}))
+		return err
+	}
+
+	for _, algorithm := range checksumAlgorithms {
+		args["algorithm"] = algorithm.name
+		checksum := algorithm.checksum(data)
+
+		if err = put(algorithm.header(), checksum); err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT with %s %s expected to succeed", algorithm.header(), checksum), err).Fatal()
+			return
+		}
+		checksumType, got, err := headChecksum(s3Client, bucket, object, algorithm)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadObject Failed", err).Fatal()
+			return
+		}
+		if got != checksum || (checksumType != "" && checksumType != checksumTypeFullObject) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject expected %s checksum %s but got %q of type %q", algorithm.name, checksum, got, checksumType), nil).Fatal()
+			return
+		}
+
+		err = put(algorithm.header(), algorithm.checksum(data[1:]))
+		if !isChecksumMismatch(err) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT with a wrong %s expected BadDigest but got %v", algorithm.header(), err), err).Fatal()
+			return
+		}
+	}
+	delete(args, "algorithm")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// uploadChecksummedParts uploads the given parts of bucket/object with
+// their checksums in algorithm, then completes the upload listing them.
+// The completion request is written by hand, as aws-sdk-go v1 cannot list
+// CRC64NVME checksums.
+func uploadChecksummedParts(s3Client *s3.S3, bucket, object, uploadID string, algorithm checksumAlgorithm, parts [][]byte) error {
+	var completion bytes.Buffer
+	completion.WriteString("<CompleteMultipartUpload>")
+	for i, part := range parts {
+		checksum := algorithm.checksum(part)
+		output, err := s3Client.UploadPartWithContext(aws.BackgroundContext(), &s3.UploadPartInput{
+			Body:       bytes.NewReader(part),
+			Bucket:     aws.String(bucket),
+			Key:        aws.String(object),
+			PartNumber: aws.Int64(int64(i + 1)),
+			UploadId:   aws.String(uploadID),
+		}, request.WithSetRequestHeaders(map[string]string{algorithm.header(): checksum}))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&completion, "<Part><PartNumber>%d</PartNumber><ETag>", i+1)
+		xml.EscapeText(&completion, []byte(aws.StringValue(output.ETag)))
+		fmt.Fprintf(&completion, "</ETag><Checksum%[1]s>%[2]s</Checksum%[1]s></Part>", algorithm.name, checksum)
+	}
+	completion.WriteString("</CompleteMultipartUpload>")
+
+	req, _ := s3Client.CompleteMultipartUploadRequest(&s3.CompleteMultipartUploadInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(object),
+		UploadId: aws.String(uploadID),
+	})
+	req.Handlers.Build.PushBack(func(r *request.Request) {
+		r.SetBufferBody(completion.Bytes())
+	})
+	return req.Send()
+}
+
+// Create multipart uploads with each x-amz-checksum-type, COMPOSITE and
+// FULL_OBJECT, upload their parts with checksums, and check the checksum
+// of the completed object is computed over the checksums of the parts, or
+// over the whole object. Algorithms not supporting a checksum type, SHA256
+// for FULL_OBJECT and CRC64NVME for COMPOSITE, must not be accepted.
+func testMultipartChecksumType(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testMultipartChecksumType"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	parts := [][]byte{make([]byte, 5<<20), make([]byte, 1<<20)}
+	for _, part := range parts {
+		random.Read(part)
+	}
+	data := bytes.Join(parts, nil)
+
+	createUpload := func(algorithm checksumAlgorithm, checksumType string) (*s3.CreateMultipartUploadOutput, string, error) {
+		req, output := s3Client.CreateMultipartUploadRequest(&s3.CreateMultipartUploadInput{
+			Bucket:            aws.String(bucket),
+			Key:               aws.String(object),
+			ChecksumAlgorithm: aws.String(algorithm.name),
+		})
+		req.ApplyOptions(request.WithSetRequestHeaders(map[string]string{"x-amz-checksum-type": checksumType}))
+		if err := req.Send(); err != nil {
+			return nil, "", err
+		}
+		return output, req.HTTPResponse.Header.Get("x-amz-checksum-type"), nil
+	}
+
+	testCases := []struct {
+		algorithm    string
+		checksumType string
+	}{
+		{"CRC32", checksumTypeComposite},
+		{"CRC32C", checksumTypeComposite},
+		{"SHA1", checksumTypeComposite},
+		{"SHA256", checksumTypeComposite},
+		{"CRC32", checksumTypeFullObject},
+		{"CRC32C", checksumTypeFullObject},
+		{"CRC64NVME", checksumTypeFullObject},
+	}
+	for _, testCase := range testCases {
+		algorithm := findChecksumAlgorithm(testCase.algorithm)
+		args["algorithm"] = testCase.algorithm
+		args["checksumType"] = testCase.checksumType
+
+		output, checksumType, err := createUpload(algorithm, testCase.checksumType)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateMultipartUpload Failed", err).Fatal()
+			return
+		}
+		if checksumType != testCase.checksumType || aws.StringValue(output.ChecksumAlgorithm) != testCase.algorithm {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CreateMultipartUpload expected %s %s but got %q %q",
+				testCase.algorithm, testCase.checksumType, aws.StringValue(output.ChecksumAlgorithm), checksumType), nil).Fatal()
+			return
+		}
+		if err = uploadChecksummedParts(s3Client, bucket, object, *output.UploadId, algorithm, parts); err != nil {
+			s3Client.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+				Bucket:   aws.String(bucket),
+				Key:      aws.String(object),
+				UploadId: output.UploadId,
+			})
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go multipart upload with checksums Failed", err).Fatal()
+			return
+		}
+
+		expected := algorithm.checksum(data)
+		if testCase.checksumType == checksumTypeComposite {
+			expected = algorithm.compositeChecksum(parts)
+		}
+		checksumType, checksum, err := headChecksum(s3Client, bucket, object, algorithm)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadObject Failed", err).Fatal()
+			return
+		}
+		if checksumType != testCase.checksumType || checksum != expected {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject expected %s checksum %s but got %s checksum %q", testCase.checksumType, expected, checksumType, checksum), nil).Fatal()
+			return
+		}
+	}
+
+	// MinIO upgrades CRC64NVME uploads to FULL_OBJECT instead of failing
+	invalidCases := []struct {
+		algorithm    string
+		checksumType string
+	}{
+		{"SHA256", checksumTypeFullObject},
+		{"CRC64NVME", checksumTypeComposite},
+	}
+	for _, testCase := range invalidCases {
+		args["algorithm"] = testCase.algorithm
+		args["checksumType"] = testCase.checksumType
+
+		output, checksumType, err := createUpload(findChecksumAlgorithm(testCase.algorithm), testCase.checksumType)
+		if err == nil {
+			s3Client.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+				Bucket:   aws.String(bucket),
+				Key:      aws.String(object),
+				UploadId: output.UploadId,
+			})
+			if checksumType == testCase.checksumType {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CreateMultipartUpload with %s %s expected to be rejected", testCase.algorithm, testCase.checksumType), nil).Fatal()
+				return
+			}
+			continue
+		}
+		if rerr, ok := err.(awserr.RequestFailure); !ok || rerr.StatusCode() != http.StatusBadRequest {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CreateMultipartUpload with %s %s expected 400 Bad Request but got %v", testCase.algorithm, testCase.checksumType, err), err).Fatal()
+			return
+		}
+	}
+	delete(args, "algorithm")
+	delete(args, "checksumType")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Check the requests sending conflicting checksums are rejected: several
+// x-amz-checksum-* headers, several checksum trailers, and multipart
+// uploads with an unknown x-amz-checksum-type. A single trailer announced
+// by a matching x-amz-sdk-checksum-algorithm, as SDKs send it, must be
+// accepted.
+func testChecksumHeaderValidation(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testChecksumHeaderValidation"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	data := streamingTestObject()
+	crc32, crc32c := findChecksumAlgorithm("CRC32"), findChecksumAlgorithm("CRC32C")
+	upload := streamingUpload{
+		payloadHash: streamingSignedPayloadTrailer,
+		trailer:     crc32c.header(),
+		checksum:    crc32c.checksum(data),
+		header:      http.Header{"X-Amz-Sdk-Checksum-Algorithm": []string{crc32c.name}},
+	}
+	status, code, err := upload.put(s3Client, bucket, object, data)
+	if err != nil || status != http.StatusOK {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Streaming PUT with a CRC32C trailer and x-amz-sdk-checksum-algorithm expected 200 but got %d %s", status, code), err).Fatal()
+		return
+	}
+
+	upload.header.Add("X-Amz-Trailer", crc32.header())
+	status, code, err = upload.put(s3Client, bucket, object, data)
+	if err != nil || status != http.StatusBadRequest {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Streaming PUT announcing two checksum trailers expected 400 but got %d %s", status, code), err).Fatal()
+		return
+	}
+
+	_, err = s3Client.PutObjectWithContext(aws.BackgroundContext(), &s3.PutObjectInput{
+		Body:   bytes.NewReader(data),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	}, request.WithSetRequestHeaders(map[string]string{
+		crc32.header():  crc32.checksum(data),
+		crc32c.header(): crc32c.checksum(data),
+	}))
+	if rerr, ok := err.(awserr.RequestFailure); !ok || rerr.StatusCode() != http.StatusBadRequest {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT with two checksum headers expected 400 Bad Request but got %v", err), err).Fatal()
+		return
+	}
+
+	output, err := s3Client.CreateMultipartUploadWithContext(aws.BackgroundContext(), &s3.CreateMultipartUploadInput{
+		Bucket:            aws.String(bucket),
+		Key:               aws.String(object),
+		ChecksumAlgorithm: aws.String(crc32c.name),
+	}, request.WithSetRequestHeaders(map[string]string{"x-amz-checksum-type": "PARTIAL"}))
+	if err == nil {
+		s3Client.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bucket),
+			Key:      aws.String(object),
+			UploadId: output.UploadId,
+		})
+	}
+	if rerr, ok := err.(awserr.RequestFailure); !ok || rerr.StatusCode() != http.StatusBadRequest {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CreateMultipartUpload with an unknown checksum type expected 400 Bad Request but got %v", err), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,167 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// createOnly is the request option of the create-only conditional writes
+var createOnly = request.WithSetRequestHeaders(map[string]string{"If-None-Match": "*"})
+
+// PUT an object with If-None-Match: *, which must succeed only when the
+// key is absent and fail with PreconditionFailed otherwise, leaving the
+// existing object untouched. Completing a multipart upload with the same
+// condition must behave the same way.
+func testPutObjectIfNoneMatch(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testPutObjectIfNoneMatch"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	putIfAbsent := func(content string) error {
+		_, err := s3Client.PutObjectWithContext(aws.BackgroundContext(), &s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader(content)),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		}, createOnly)
+		return err
+	}
+	checkContent := func(content string) bool {
+		data, err := getObjectContent(s3Client, bucket, object)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject Failed", err).Fatal()
+			return false
+		}
+		if string(data) != content {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject expected %q but got %q", content, data), errors.New("content mismatch")).Fatal()
+			return false
+		}
+		return true
+	}
+	isPreconditionFailed := func(err error) bool {
+		rerr, ok := err.(awserr.RequestFailure)
+		return ok && rerr.StatusCode() == http.StatusPreconditionFailed && rerr.Code() == "PreconditionFailed"
+	}
+
+	err = putIfAbsent("first")
+	if mintest.IsNotImplemented(err) {
+		mintest.IgnoreLog(function, args, startTime, "Conditional writes are not implemented").Info()
+		return
+	}
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PUT with If-None-Match * of an absent key expected to succeed", err).Fatal()
+		return
+	}
+	if !checkContent("first") {
+		return
+	}
+	err = putIfAbsent("second")
+	if !isPreconditionFailed(err) {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PUT with If-None-Match * of an existing key expected to fail with 412 PreconditionFailed", err).Fatal()
+		return
+	}
+	if !checkContent("first") {
+		return
+	}
+
+	completeIfAbsent := func(content string) error {
+		upload, err := s3Client.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			return err
+		}
+		defer s3Client.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bucket),
+			Key:      aws.String(object),
+			UploadId: upload.UploadId,
+		})
+		part, err := s3Client.UploadPart(&s3.UploadPartInput{
+			Body:       aws.ReadSeekCloser(strings.NewReader(content)),
+			Bucket:     aws.String(bucket),
+			Key:        aws.String(object),
+			PartNumber: aws.Int64(1),
+			UploadId:   upload.UploadId,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = s3Client.CompleteMultipartUploadWithContext(aws.BackgroundContext(), &s3.CompleteMultipartUploadInput{
+			Bucket:          aws.String(bucket),
+			Key:             aws.String(object),
+			MultipartUpload: &s3.CompletedMultipartUpload{Parts: []*s3.CompletedPart{{ETag: part.ETag, PartNumber: aws.Int64(1)}}},
+			UploadId:        upload.UploadId,
+		}, createOnly)
+		return err
+	}
+
+	err = completeIfAbsent("multipart")
+	if !isPreconditionFailed(err) {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CompleteMultipartUpload with If-None-Match * of an existing key expected to fail with 412 PreconditionFailed", err).Fatal()
+		return
+	}
+	if !checkContent("first") {
+		return
+	}
+
+	_, err = s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteObject Failed", err).Fatal()
+		return
+	}
+	if err = completeIfAbsent("multipart"); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CompleteMultipartUpload with If-None-Match * of an absent key expected to succeed", err).Fatal()
+		return
+	}
+	if !checkContent("multipart") {
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
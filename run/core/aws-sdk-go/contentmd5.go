@@ -0,0 +1,250 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// sendContentMD5 sends req with contentMD5 in place of the Content-MD5
+// computed by the SDK, or with neither Content-MD5 nor checksum headers
+// when contentMD5 is empty. The headers are replaced before signing.
+func sendContentMD5(req *request.Request, contentMD5 string) error {
+	req.Handlers.Build.PushBack(func(r *request.Request) {
+		if contentMD5 != "" {
+			r.HTTPRequest.Header.Set("Content-MD5", contentMD5)
+			return
+		}
+		for header := range r.HTTPRequest.Header {
+			if strings.EqualFold(header, "Content-MD5") || strings.HasPrefix(strings.ToLower(header), "x-amz-checksum-") {
+				r.HTTPRequest.Header.Del(header)
+			}
+		}
+	})
+	return req.Send()
+}
+
+// sentContentMD5 checks the Content-MD5 sent with req is the one of its body
+func sentContentMD5(req *request.Request) error {
+	sent := req.HTTPRequest.Header.Get("Content-MD5")
+	if sent == "" {
+		return errors.New("no Content-MD5 sent")
+	}
+	body := req.GetBody()
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	hash := md5.New()
+	if _, err := io.Copy(hash, body); err != nil {
+		return err
+	}
+	if expected := base64.StdEncoding.EncodeToString(hash.Sum(nil)); sent != expected {
+		return fmt.Errorf("Content-MD5 %s sent for a body of Content-MD5 %s", sent, expected)
+	}
+	return nil
+}
+
+// Send DeleteObjects, PutBucketTagging and PutBucketLifecycleConfiguration
+// without Content-MD5, with the Content-MD5 of another body and with one
+// which is not a MD5 sum. They must be rejected with MissingContentMD5,
+// BadDigest and InvalidDigest, leaving the bucket unchanged. The same
+// requests sent through the SDK, which adds Content-MD5 by itself, must
+// succeed.
+func testContentMD5Enforcement(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testContentMD5Enforcement"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader("content-md5")),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutObject Failed", err).Fatal()
+		return
+	}
+
+	// Rejected requests must not be retried with the same headers
+	noRetryClient := s3.New(session.New(), s3Client.Config.Copy().WithMaxRetries(0))
+
+	otherSum := md5.Sum([]byte("another body"))
+	operations := []struct {
+		name    string
+		request func(*s3.S3) *request.Request
+	}{
+		{"DeleteObjects", func(client *s3.S3) *request.Request {
+			req, _ := client.DeleteObjectsRequest(&s3.DeleteObjectsInput{
+				Bucket: aws.String(bucket),
+				Delete: &s3.Delete{Objects: []*s3.ObjectIdentifier{{Key: aws.String(object)}}},
+			})
+			return req
+		}},
+		{"PutBucketTagging", func(client *s3.S3) *request.Request {
+			req, _ := client.PutBucketTaggingRequest(&s3.PutBucketTaggingInput{
+				Bucket:  aws.String(bucket),
+				Tagging: &s3.Tagging{TagSet: []*s3.Tag{{Key: aws.String("key"), Value: aws.String("value")}}},
+			})
+			return req
+		}},
+		{"PutBucketLifecycleConfiguration", func(client *s3.S3) *request.Request {
+			req, _ := client.PutBucketLifecycleConfigurationRequest(&s3.PutBucketLifecycleConfigurationInput{
+				Bucket: aws.String(bucket),
+				LifecycleConfiguration: &s3.BucketLifecycleConfiguration{
+					Rules: []*s3.LifecycleRule{{
+						ID:         aws.String("expire"),
+						Status:     aws.String(s3.ExpirationStatusEnabled),
+						Filter:     &s3.LifecycleRuleFilter{Prefix: aws.String("prefix/")},
+						Expiration: &s3.LifecycleExpiration{Days: aws.Int64(1)},
+					}},
+				},
+			})
+			return req
+		}},
+	}
+
+	for _, operation := range operations {
+		args["operation"] = operation.name
+		for _, testCase := range []struct {
+			name        string
+			contentMD5  string
+			expectCodes []string
+		}{
+			// Newer S3 accepts a checksum instead and names both in InvalidRequest
+			{"missing", "", []string{"MissingContentMD5", "InvalidRequest"}},
+			{"mismatched", base64.StdEncoding.EncodeToString(otherSum[:]), []string{"BadDigest"}},
+			{"invalid", base64.StdEncoding.EncodeToString([]byte("not a md5 sum")), []string{"InvalidDigest"}},
+		} {
+			args["contentMD5"] = testCase.name
+			err = sendContentMD5(operation.request(noRetryClient), testCase.contentMD5)
+			aerr, ok := err.(awserr.Error)
+			if ok && aerr.Code() == "NotImplemented" {
+				mintest.IgnoreLog(function, args, startTime, operation.name+" is NotImplemented").Info()
+				return
+			}
+			expected := false
+			for _, code := range testCase.expectCodes {
+				expected = expected || mintest.IsErrorCode(err, code)
+			}
+			if !isClientError(err) || !expected {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go %s with a %s Content-MD5 expected to fail with %v", operation.name, testCase.name, testCase.expectCodes), err).Fatal()
+				return
+			}
+		}
+	}
+	delete(args, "operation")
+	delete(args, "contentMD5")
+
+	// None of the rejected requests may have been applied
+	_, err = s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadObject after DeleteObjects without a valid Content-MD5 expected to succeed", err).Fatal()
+		return
+	}
+	_, err = s3Client.GetBucketTagging(&s3.GetBucketTaggingInput{
+		Bucket: aws.String(bucket),
+	})
+	if !mintest.IsErrorCode(err, "NoSuchTagSet") {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetBucketTagging after PutBucketTagging without a valid Content-MD5 expected to fail with NoSuchTagSet", err).Fatal()
+		return
+	}
+	_, err = s3Client.GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+	if !mintest.IsErrorCode(err, "NoSuchLifecycleConfiguration") {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetBucketLifecycleConfiguration after PutBucketLifecycleConfiguration without a valid Content-MD5 expected to fail with NoSuchLifecycleConfiguration", err).Fatal()
+		return
+	}
+
+	// The SDK adds the Content-MD5 of the body by itself
+	for _, operation := range operations {
+		args["operation"] = operation.name
+		req := operation.request(s3Client)
+		if err = req.Send(); err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go %s expected to succeed", operation.name), err).Fatal()
+			return
+		}
+		if err = sentContentMD5(req); err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go %s expected to send the Content-MD5 of its body", operation.name), err).Fatal()
+			return
+		}
+	}
+	delete(args, "operation")
+
+	_, err = s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if !mintest.IsErrorCode(err, "NotFound") {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadObject after DeleteObjects expected to fail with NotFound", err).Fatal()
+		return
+	}
+	tagging, err := s3Client.GetBucketTagging(&s3.GetBucketTaggingInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil || len(tagging.TagSet) != 1 || aws.StringValue(tagging.TagSet[0].Key) != "key" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetBucketTagging after PutBucketTagging expected the tag set but got %v", tagging), err).Fatal()
+		return
+	}
+	lifecycle, err := s3Client.GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil || len(lifecycle.Rules) != 1 || aws.StringValue(lifecycle.Rules[0].ID) != "expire" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetBucketLifecycleConfiguration after PutBucketLifecycleConfiguration expected the rule but got %v", lifecycle), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,210 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// listV2From lists the keys of bucket under prefix with ListObjectsV2,
+// continuing from token until the listing is over
+func listV2From(s3Client *s3.S3, bucket, prefix, token string) ([]string, error) {
+	var keys []string
+	input := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucket),
+		MaxKeys: aws.Int64(2),
+	}
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+	if token != "" {
+		input.ContinuationToken = aws.String(token)
+	}
+	for {
+		output, err := s3Client.ListObjectsV2(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range output.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
+		}
+		if !aws.BoolValue(output.IsTruncated) {
+			return keys, nil
+		}
+		input.ContinuationToken = output.NextContinuationToken
+	}
+}
+
+// isClientError reports whether err is a 4xx error
+func isClientError(err error) bool {
+	rerr, ok := err.(awserr.RequestFailure)
+	return ok && rerr.StatusCode() >= http.StatusBadRequest && rerr.StatusCode() < http.StatusInternalServerError
+}
+
+// List objects with ListObjectsV2 in the corner cases which tend to
+// regress. MaxKeys 0 must give an empty listing, not truncated. A
+// continuation token must be rejected with a 4xx error, or only continue
+// the listing asked for, when used on another bucket or with another
+// prefix. A token of objects deleted since must continue with the objects
+// left after them.
+func testListObjectsV2Continuation(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testListObjectsV2Continuation"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	otherBucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	args := map[string]interface{}{
+		"bucketName":      bucket,
+		"otherBucketName": otherBucket,
+	}
+
+	objects := map[string][]string{
+		bucket:      {"a/1", "a/2", "a/3", "a/4", "a/5", "b/1", "b/2", "b/3", "b/4", "b/5"},
+		otherBucket: {"x/1", "x/2", "x/3"},
+	}
+	for _, b := range []string{bucket, otherBucket} {
+		_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+			Bucket: aws.String(b),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+			return
+		}
+		defer func(b string) {
+			if err := mintest.RemoveBucket(s3Client, b); err != nil {
+				mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+			}
+		}(b)
+		for _, key := range objects[b] {
+			_, err = s3Client.PutObject(&s3.PutObjectInput{
+				Body:   aws.ReadSeekCloser(strings.NewReader(key)),
+				Bucket: aws.String(b),
+				Key:    aws.String(key),
+			})
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+				return
+			}
+		}
+	}
+
+	output, err := s3Client.ListObjectsV2(&s3.ListObjectsV2Input{
+		Bucket:  aws.String(bucket),
+		MaxKeys: aws.Int64(0),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListObjectsV2 with MaxKeys 0 Failed", err).Fatal()
+		return
+	}
+	if len(output.Contents) != 0 || len(output.CommonPrefixes) != 0 || aws.Int64Value(output.KeyCount) != 0 || aws.BoolValue(output.IsTruncated) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 with MaxKeys 0 expected an empty listing, not truncated, but got %d keys, truncated %v",
+			len(output.Contents), aws.BoolValue(output.IsTruncated)), nil).Fatal()
+		return
+	}
+
+	// token returns the continuation token of the first page of 2 keys of
+	// bucket under prefix
+	token := func(prefix string) (string, error) {
+		output, err := s3Client.ListObjectsV2(&s3.ListObjectsV2Input{
+			Bucket:  aws.String(bucket),
+			MaxKeys: aws.Int64(2),
+			Prefix:  aws.String(prefix),
+		})
+		if err != nil {
+			return "", err
+		}
+		if !aws.BoolValue(output.IsTruncated) || aws.StringValue(output.NextContinuationToken) == "" {
+			return "", fmt.Errorf("listing of 2 keys under %q expected to be truncated with a continuation token", prefix)
+		}
+		return aws.StringValue(output.NextContinuationToken), nil
+	}
+
+	for _, misuse := range []struct {
+		name   string
+		bucket string
+		prefix string
+	}{
+		{"another bucket", otherBucket, "x/"},
+		{"another prefix", bucket, "b/"},
+	} {
+		args["misuse"] = misuse.name
+		t, err := token("a/")
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListObjectsV2 Failed", err).Fatal()
+			return
+		}
+		keys, err := listV2From(s3Client, misuse.bucket, misuse.prefix, t)
+		if err != nil {
+			if !isClientError(err) {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 continued on %s expected a 4xx error or a listing of it but got %v", misuse.name, err), err).Fatal()
+				return
+			}
+			continue
+		}
+		// Keys of the listing continued would be listed after a/2
+		for _, key := range keys {
+			if strings.HasPrefix(key, "a/") || !strings.HasPrefix(key, misuse.prefix) {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 continued on %s listed %v, not all under %s", misuse.name, keys, misuse.prefix), nil).Fatal()
+				return
+			}
+		}
+		if !sort.StringsAreSorted(keys) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 continued on %s listed %v out of order", misuse.name, keys), nil).Fatal()
+			return
+		}
+	}
+	delete(args, "misuse")
+
+	// The token continues after a/2 once all the a/ objects are gone
+	t, err := token("")
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListObjectsV2 Failed", err).Fatal()
+		return
+	}
+	for _, key := range objects[bucket][:5] {
+		_, err = s3Client.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteObject Failed", err).Fatal()
+			return
+		}
+	}
+	keys, err := listV2From(s3Client, bucket, "", t)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListObjectsV2 continued after deletions Failed", err).Fatal()
+		return
+	}
+	if expected := objects[bucket][5:]; strings.Join(keys, ",") != strings.Join(expected, ",") {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 continued after deletions expected %v but got %v", expected, keys), nil).Fatal()
+		return
+	}
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
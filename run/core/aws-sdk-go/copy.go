@@ -0,0 +1,207 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// getObjectContent returns the content of object
+func getObjectContent(s3Client *s3.S3, bucket, object string) ([]byte, error) {
+	output, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+	return ioutil.ReadAll(output.Body)
+}
+
+// copySource returns the x-amz-copy-source value of object
+func copySource(bucket, object string) *string {
+	return aws.String(bucket + "/" + object)
+}
+
+// Assemble an SSE-C encrypted multipart object from byte ranges of an
+// SSE-C encrypted source object, encrypted under another key. Copying a
+// part with a wrong or missing key of the source must be rejected. The
+// completed object must only be readable with the key of the destination.
+func testUploadPartCopySSEC(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testUploadPartCopySSEC"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "source"
+	destination := "destination"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	sourceKey := aws.String("32byteslongsecretkeymustbegiven1")
+	destinationKey := aws.String("32byteslongsecretkeymustbegiven2")
+	content := make([]byte, 11*1024*1024)
+	rand.New(rand.NewSource(time.Now().UnixNano())).Read(content)
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:                 bytes.NewReader(content),
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(object),
+		SSECustomerAlgorithm: aws.String("AES256"),
+		SSECustomerKey:       sourceKey,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		return
+	}
+
+	upload, err := s3Client.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(destination),
+		SSECustomerAlgorithm: aws.String("AES256"),
+		SSECustomerKey:       destinationKey,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateMultipartUpload Failed", err).Fatal()
+		return
+	}
+	abort := func() {
+		s3Client.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bucket),
+			Key:      aws.String(destination),
+			UploadId: upload.UploadId,
+		})
+	}
+	uploadPartCopy := func(partNumber int, byteRange [2]int, sourceKey *string) (*s3.UploadPartCopyOutput, error) {
+		input := &s3.UploadPartCopyInput{
+			Bucket:               aws.String(bucket),
+			Key:                  aws.String(destination),
+			UploadId:             upload.UploadId,
+			PartNumber:           aws.Int64(int64(partNumber)),
+			CopySource:           copySource(bucket, object),
+			CopySourceRange:      aws.String(fmt.Sprintf("bytes=%d-%d", byteRange[0], byteRange[1])),
+			SSECustomerAlgorithm: aws.String("AES256"),
+			SSECustomerKey:       destinationKey,
+		}
+		if sourceKey != nil {
+			input.CopySourceSSECustomerAlgorithm = aws.String("AES256")
+			input.CopySourceSSECustomerKey = sourceKey
+		}
+		return s3Client.UploadPartCopy(input)
+	}
+
+	// The last 5 MiB, then the first 6 MiB of the source
+	split := 6 * 1024 * 1024
+	ranges := [][2]int{{split, len(content) - 1}, {0, split - 1}}
+
+	wrongKeys := []struct {
+		key    *string
+		reason string
+	}{
+		{aws.String("32byteslongsecretkeymustbegiven3"), "a wrong"},
+		{destinationKey, "the destination"},
+		{nil, "no"},
+	}
+	for _, wrongKey := range wrongKeys {
+		_, err = uploadPartCopy(1, ranges[0], wrongKey.key)
+		if aerr, ok := err.(awserr.Error); !ok || (aerr.Code() != "AccessDenied" && aerr.Code() != "InvalidRequest") {
+			abort()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go UploadPartCopy with %s source key expected AccessDenied or InvalidRequest but got %v", wrongKey.reason, err), err).Fatal()
+			return
+		}
+	}
+
+	var expected []byte
+	var parts []*s3.CompletedPart
+	for i, r := range ranges {
+		output, err := uploadPartCopy(i+1, r, sourceKey)
+		if err != nil {
+			abort()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go UploadPartCopy of range %d-%d failed", r[0], r[1]), err).Fatal()
+			return
+		}
+		parts = append(parts, &s3.CompletedPart{
+			ETag:       output.CopyPartResult.ETag,
+			PartNumber: aws.Int64(int64(i + 1)),
+		})
+		expected = append(expected, content[r[0]:r[1]+1]...)
+	}
+
+	_, err = s3Client.CompleteMultipartUpload(&s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(destination),
+		UploadId:        upload.UploadId,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CompleteMultipartUpload Failed", err).Fatal()
+		return
+	}
+
+	if _, err = getObjectContent(s3Client, bucket, destination); err == nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject of an SSE-C object without its key expected to fail", nil).Fatal()
+		return
+	}
+	output, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(destination),
+		SSECustomerAlgorithm: aws.String("AES256"),
+		SSECustomerKey:       destinationKey,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject Failed", err).Fatal()
+		return
+	}
+	defer output.Body.Close()
+	data, err := ioutil.ReadAll(output.Body)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject Failed", err).Fatal()
+		return
+	}
+	if !bytes.Equal(data, expected) {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go UploadPartCopy assembled object has unexpected content", errors.New("content mismatch")).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,223 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// MinIO header deleting a bucket along with everything it holds
+const forceDeleteHeader = "x-minio-force-delete"
+
+// bucketExists reports whether HEAD finds bucket
+func bucketExists(s3Client *s3.S3, bucket string) (bool, error) {
+	_, err := s3Client.HeadBucket(&s3.HeadBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if rerr, ok := err.(awserr.RequestFailure); ok && rerr.StatusCode() == http.StatusNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Delete a bucket holding an object, which must fail with BucketNotEmpty
+// and keep the bucket, then delete it once the object is removed. A
+// bucket holding only an incomplete multipart upload may either be
+// rejected the same way, or be deleted along with the upload.
+func testDeleteBucketNotEmpty(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testDeleteBucketNotEmpty"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if exists, _ := bucketExists(s3Client, bucket); !exists {
+			return
+		}
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader("content")),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutObject Failed", err).Fatal()
+		return
+	}
+	_, err = s3Client.DeleteBucket(&s3.DeleteBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "BucketNotEmpty" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go DeleteBucket of a bucket holding an object expected BucketNotEmpty but got %v", err), err).Fatal()
+		return
+	}
+	if exists, err := bucketExists(s3Client, bucket); !exists {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadBucket expected the bucket to be kept after a failed DeleteBucket", err).Fatal()
+		return
+	}
+	_, err = s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteObject Failed", err).Fatal()
+		return
+	}
+
+	upload, err := s3Client.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateMultipartUpload Failed", err).Fatal()
+		return
+	}
+	_, err = s3Client.UploadPart(&s3.UploadPartInput{
+		Body:       aws.ReadSeekCloser(strings.NewReader("part")),
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(object),
+		PartNumber: aws.Int64(1),
+		UploadId:   upload.UploadId,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go UploadPart Failed", err).Fatal()
+		return
+	}
+	_, err = s3Client.DeleteBucket(&s3.DeleteBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err == nil {
+		// The upload must not outlive its bucket
+		_, err = s3Client.CreateBucket(&s3.CreateBucketInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+			return
+		}
+		uploads, err := s3Client.ListMultipartUploads(&s3.ListMultipartUploadsInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListMultipartUploads Failed", err).Fatal()
+			return
+		}
+		if len(uploads.Uploads) != 0 {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListMultipartUploads of a re-created bucket expected no upload but got %v", uploads.Uploads), nil).Fatal()
+			return
+		}
+	} else if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "BucketNotEmpty" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go DeleteBucket of a bucket holding an upload expected BucketNotEmpty or success but got %v", err), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Delete a bucket holding objects and an incomplete multipart upload with
+// the x-minio-force-delete header, which must remove the bucket and all
+// its contents. Servers ignoring the header are skipped.
+func testDeleteBucketForce(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testDeleteBucketForce"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	args := map[string]interface{}{
+		"bucketName": bucket,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if exists, _ := bucketExists(s3Client, bucket); !exists {
+			return
+		}
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	for _, object := range []string{"object", "prefix/object"} {
+		_, err = s3Client.PutObject(&s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader("content")),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutObject Failed", err).Fatal()
+			return
+		}
+	}
+	_, err = s3Client.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String("upload"),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateMultipartUpload Failed", err).Fatal()
+		return
+	}
+
+	_, err = s3Client.DeleteBucketWithContext(aws.BackgroundContext(), &s3.DeleteBucketInput{
+		Bucket: aws.String(bucket),
+	}, request.WithSetRequestHeaders(map[string]string{forceDeleteHeader: "true"}))
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "BucketNotEmpty" {
+		mintest.IgnoreLog(function, args, startTime, "Forced bucket deletion is not supported").Info()
+		return
+	}
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket with x-minio-force-delete Failed", err).Fatal()
+		return
+	}
+	if exists, err := bucketExists(s3Client, bucket); exists || err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadBucket expected 404 after a forced DeleteBucket", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,219 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// dirListing is a listing of a scope, with the keys and the common
+// prefixes relative to it
+type dirListing struct {
+	Keys     []string
+	Prefixes []string
+}
+
+// listDir lists the objects of scope under prefix with ListObjectsV2,
+// grouped by delimiter when not empty
+func listDir(s3Client *s3.S3, scope mintest.Scope, prefix, delimiter string) (dirListing, error) {
+	var listing dirListing
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(scope.Bucket),
+		Prefix: aws.String(scope.Key(prefix)),
+	}
+	if delimiter != "" {
+		input.Delimiter = aws.String(delimiter)
+	}
+	err := s3Client.ListObjectsV2Pages(input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			listing.Keys = append(listing.Keys, strings.TrimPrefix(aws.StringValue(object.Key), scope.Prefix))
+		}
+		for _, commonPrefix := range page.CommonPrefixes {
+			listing.Prefixes = append(listing.Prefixes, strings.TrimPrefix(aws.StringValue(commonPrefix.Prefix), scope.Prefix))
+		}
+		return true
+	})
+	return listing, err
+}
+
+// Store zero-byte directory objects, keys ending in a slash, alongside
+// objects under the same prefixes. A directory object must read back
+// empty, be distinct from the key without the slash, be listed as a key
+// within its prefix and as a common prefix from its parent, and deleting
+// it must leave the objects under its prefix alone, the prefix being
+// listed for as long as an object remains under it.
+func testDirectoryObjects(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testDirectoryObjects"
+	name := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	args := map[string]interface{}{
+		"bucketName": name,
+	}
+
+	scope, err := mintest.NewScope(s3Client, name)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	args["bucketName"], args["prefix"] = scope.Bucket, scope.Prefix
+	defer func() {
+		if err := scope.Remove(s3Client); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	objects := map[string]string{
+		"dir/":           "",
+		"dir/object":     "content",
+		"dir/sub/":       "",
+		"dir/sub/object": "content",
+		"empty/":         "",
+		"file":           "content",
+	}
+	for object, content := range objects {
+		_, err = s3Client.PutObject(&s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader(content)),
+			Bucket: aws.String(scope.Bucket),
+			Key:    aws.String(scope.Key(object)),
+		})
+		if err != nil {
+			args["objectName"] = object
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+	}
+
+	// Directory objects read back empty, with the ETag of no content
+	for _, object := range []string{"dir/", "empty/"} {
+		args["objectName"] = object
+		output, err := s3Client.GetObject(&s3.GetObjectInput{
+			Bucket: aws.String(scope.Bucket),
+			Key:    aws.String(scope.Key(object)),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GET of a directory object Failed", err).Fatal()
+			return
+		}
+		data, err := ioutil.ReadAll(output.Body)
+		output.Body.Close()
+		if err != nil || len(data) != 0 || aws.Int64Value(output.ContentLength) != 0 {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GET of a directory object expected no content but got %d bytes", len(data)), err).Fatal()
+			return
+		}
+		if etag, err := headETag(s3Client, scope.Bucket, scope.Key(object), nil); err != nil || etag != md5Hex(nil) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HEAD of a directory object expected ETag %s but got %q", md5Hex(nil), etag), err).Fatal()
+			return
+		}
+	}
+
+	// The keys without the slash are other objects, which do not exist
+	for _, object := range []string{"dir", "empty", "file/"} {
+		args["objectName"] = object
+		_, err = s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket: aws.String(scope.Bucket),
+			Key:    aws.String(scope.Key(object)),
+		})
+		if rerr, ok := err.(awserr.RequestFailure); !ok || rerr.StatusCode() != http.StatusNotFound {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HEAD of a key differing from an object by a trailing slash expected to fail with 404", err).Fatal()
+			return
+		}
+	}
+	delete(args, "objectName")
+
+	// check compares the listing of prefix with expected
+	check := func(step, prefix, delimiter string, expected dirListing) bool {
+		listing, err := listDir(s3Client, scope, prefix, delimiter)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 %s Failed", step), err).Fatal()
+			return false
+		}
+		if !reflect.DeepEqual(listing, expected) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 %s of prefix %q and delimiter %q expected %+v but got %+v", step, prefix, delimiter, expected, listing), nil).Fatal()
+			return false
+		}
+		return true
+	}
+
+	if !check("recursive", "", "", dirListing{Keys: []string{"dir/", "dir/object", "dir/sub/", "dir/sub/object", "empty/", "file"}}) ||
+		!check("of the top level", "", "/", dirListing{Keys: []string{"file"}, Prefixes: []string{"dir/", "empty/"}}) ||
+		!check("of a directory", "dir/", "/", dirListing{Keys: []string{"dir/", "dir/object"}, Prefixes: []string{"dir/sub/"}}) ||
+		!check("of an empty directory", "empty/", "/", dirListing{Keys: []string{"empty/"}}) ||
+		!check("of a prefix without slash", "dir", "/", dirListing{Prefixes: []string{"dir/"}}) {
+		return
+	}
+
+	// remove deletes objects, which must be gone afterwards
+	remove := func(objects ...string) bool {
+		for _, object := range objects {
+			args["objectName"] = object
+			_, err := s3Client.DeleteObject(&s3.DeleteObjectInput{
+				Bucket: aws.String(scope.Bucket),
+				Key:    aws.String(scope.Key(object)),
+			})
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteObject Failed", err).Fatal()
+				return false
+			}
+			_, err = s3Client.HeadObject(&s3.HeadObjectInput{
+				Bucket: aws.String(scope.Bucket),
+				Key:    aws.String(scope.Key(object)),
+			})
+			if rerr, ok := err.(awserr.RequestFailure); !ok || rerr.StatusCode() != http.StatusNotFound {
+				mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HEAD of a deleted object expected to fail with 404", err).Fatal()
+				return false
+			}
+		}
+		delete(args, "objectName")
+		return true
+	}
+
+	// Deleting a directory object leaves the objects under its prefix
+	if !remove("dir/") ||
+		!check("after deleting a directory object", "dir/", "/", dirListing{Keys: []string{"dir/object"}, Prefixes: []string{"dir/sub/"}}) ||
+		!check("of the top level after deleting a directory object", "", "/", dirListing{Keys: []string{"file"}, Prefixes: []string{"dir/", "empty/"}}) {
+		return
+	}
+
+	// Deleting the objects under a directory object leaves it
+	if !remove("dir/sub/object") ||
+		!check("after deleting the objects under a directory object", "dir/", "/", dirListing{Keys: []string{"dir/object"}, Prefixes: []string{"dir/sub/"}}) ||
+		!check("of a directory object alone", "dir/sub/", "", dirListing{Keys: []string{"dir/sub/"}}) {
+		return
+	}
+
+	// The prefixes are gone with their last object
+	if !remove("dir/sub/", "dir/object", "empty/") ||
+		!check("of the top level after deleting the directories", "", "/", dirListing{Keys: []string{"file"}}) {
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
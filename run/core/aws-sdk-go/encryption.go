@@ -0,0 +1,144 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Error returned when reading the encryption of a bucket without any
+const errCodeNoBucketEncryption = "ServerSideEncryptionConfigurationNotFoundError"
+
+// Set a default encryption rule of the given algorithm, AES256 or aws:kms,
+// on a bucket and read it back. Objects uploaded without encryption
+// headers must then be encrypted with it. Once the rule is deleted,
+// reading it must fail with ServerSideEncryptionConfigurationNotFoundError.
+// Servers without a KMS configured, which MinIO needs for both, are
+// skipped.
+func testBucketEncryption(s3Client *s3.S3, algorithm string) {
+	startTime := time.Now()
+	function := "testBucketEncryption"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"algorithm":  algorithm,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	getEncryption := func() (*s3.GetBucketEncryptionOutput, error) {
+		return s3Client.GetBucketEncryption(&s3.GetBucketEncryptionInput{
+			Bucket: aws.String(bucket),
+		})
+	}
+	isNoBucketEncryption := func(err error) bool {
+		aerr, ok := err.(awserr.Error)
+		return ok && aerr.Code() == errCodeNoBucketEncryption
+	}
+
+	if _, err = getEncryption(); !isNoBucketEncryption(err) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetBucketEncryption of a new bucket expected %s but got %v", errCodeNoBucketEncryption, err), err).Fatal()
+		return
+	}
+
+	_, err = s3Client.PutBucketEncryption(&s3.PutBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+		ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+			Rules: []*s3.ServerSideEncryptionRule{{
+				ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{
+					SSEAlgorithm: aws.String(algorithm),
+				},
+			}},
+		},
+	})
+	if err != nil {
+		if mintest.IsNotImplemented(err) {
+			mintest.IgnoreLog(function, args, startTime, fmt.Sprintf("PutBucketEncryption with %s is NotImplemented", algorithm)).Info()
+			return
+		}
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutBucketEncryption Failed", err).Fatal()
+		return
+	}
+
+	output, err := getEncryption()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetBucketEncryption Failed", err).Fatal()
+		return
+	}
+	rules := output.ServerSideEncryptionConfiguration.Rules
+	if len(rules) != 1 || rules[0].ApplyServerSideEncryptionByDefault == nil ||
+		aws.StringValue(rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm) != algorithm {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetBucketEncryption expected a single %s rule but got %v", algorithm, rules), nil).Fatal()
+		return
+	}
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader("fileToUpload")),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutObject Failed", err).Fatal()
+		return
+	}
+	head, err := s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadObject Failed", err).Fatal()
+		return
+	}
+	if aws.StringValue(head.ServerSideEncryption) != algorithm {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject expected the object encrypted with %s but got %q", algorithm, aws.StringValue(head.ServerSideEncryption)), nil).Fatal()
+		return
+	}
+
+	_, err = s3Client.DeleteBucketEncryption(&s3.DeleteBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucketEncryption Failed", err).Fatal()
+		return
+	}
+	if _, err = getEncryption(); !isNoBucketEncryption(err) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetBucketEncryption after delete expected %s but got %v", errCodeNoBucketEncryption, err), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
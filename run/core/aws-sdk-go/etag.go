@@ -0,0 +1,290 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// md5Hex returns the MD5 sum of data in hexadecimal
+func md5Hex(data []byte) string {
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// multipartETag returns the ETag of an object uploaded in parts of
+// partSize, which is the MD5 sum of the MD5 sums of its parts followed by
+// the number of parts
+func multipartETag(content []byte, partSize int) string {
+	var sums []byte
+	parts := 0
+	for start := 0; start < len(content); start += partSize {
+		end := start + partSize
+		if end > len(content) {
+			end = len(content)
+		}
+		sum := md5.Sum(content[start:end])
+		sums = append(sums, sum[:]...)
+		parts++
+	}
+	return fmt.Sprintf("%s-%d", md5Hex(sums), parts)
+}
+
+// headETag returns the ETag of an object without its quotes
+func headETag(s3Client *s3.S3, bucket, object string, sseKey *string) (string, error) {
+	output, err := s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(object),
+		SSECustomerAlgorithm: sseCustomerAlgorithm(sseKey),
+		SSECustomerKey:       sseKey,
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.Trim(aws.StringValue(output.ETag), `"`), nil
+}
+
+// etagDeviations logs the ETags deviating from their expected semantics,
+// given by case, as a failure of the test
+func etagDeviations(function string, args map[string]interface{}, startTime time.Time, deviations map[string]string) {
+	cases := make([]string, 0, len(deviations))
+	for name := range deviations {
+		cases = append(cases, name)
+	}
+	sort.Strings(cases)
+	args["deviations"] = deviations
+	mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("ETags deviate from the S3 semantics for %s", strings.Join(cases, ", ")), nil).Fatal()
+}
+
+// Check the ETags of unencrypted objects, case by case: the ETag of an
+// object PUT at once is the MD5 sum of its content, the one of an object
+// uploaded in parts is the MD5 sum of the MD5 sums of the parts followed
+// by -N, N being the number of parts, and a copy of the latter is a new
+// object whose ETag is the MD5 sum of its content. The cases deviating
+// are all reported in the failure.
+func testETagSemantics(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testETagSemantics"
+	name := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	seed := mintest.DataSeed()
+	args := map[string]interface{}{
+		"bucketName": name,
+		"seed":       seed,
+	}
+
+	scope, err := mintest.NewScope(s3Client, name)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	args["bucketName"], args["prefix"] = scope.Bucket, scope.Prefix
+	defer func() {
+		if err := scope.Remove(s3Client); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	content, err := ioutil.ReadAll(mintest.NewDataReader(seed, rangeObjectSize))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Generating the object content failed", err).Fatal()
+		return
+	}
+
+	deviations := make(map[string]string)
+	// check records a deviation when the ETag of object is not expected
+	check := func(name, object, expected string) bool {
+		etag, err := headETag(s3Client, scope.Bucket, scope.Key(object), nil)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject of %s Failed", object), err).Fatal()
+			return false
+		}
+		if etag != expected {
+			deviations[name] = fmt.Sprintf("expected %s but got %s", expected, etag)
+		}
+		return true
+	}
+
+	output, err := s3Client.PutObject(&s3.PutObjectInput{
+		Body:   bytes.NewReader(content),
+		Bucket: aws.String(scope.Bucket),
+		Key:    aws.String(scope.Key("single-part")),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	if etag := strings.Trim(aws.StringValue(output.ETag), `"`); etag != md5Hex(content) {
+		deviations["single-part PUT response"] = fmt.Sprintf("expected %s but got %s", md5Hex(content), etag)
+	}
+	if !check("single-part", "single-part", md5Hex(content)) {
+		return
+	}
+
+	if err = putMultipartContent(s3Client, scope.Bucket, scope.Key("multipart"), content, nil); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go multipart upload Failed", err).Fatal()
+		return
+	}
+	if !check("multipart", "multipart", multipartETag(content, rangePartSize)) {
+		return
+	}
+
+	_, err = s3Client.CopyObject(&s3.CopyObjectInput{
+		Bucket:     aws.String(scope.Bucket),
+		Key:        aws.String(scope.Key("copy")),
+		CopySource: aws.String(scope.Bucket + "/" + scope.Key("multipart")),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CopyObject Failed", err).Fatal()
+		return
+	}
+	if !check("copy of multipart", "copy", md5Hex(content)) {
+		return
+	}
+
+	if len(deviations) > 0 {
+		etagDeviations(function, args, startTime, deviations)
+		return
+	}
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Check encrypted objects do not have the MD5 sum of their content as
+// ETag, whether PUT at once or uploaded in parts, with encryption set by
+// encrypt on the PUT and the multipart upload, and sseKey given to read
+// them back when encrypted with SSE-C.
+func testETagEncrypted(s3Client *s3.S3, function string, sseKey *string, encrypt func(put *s3.PutObjectInput, upload *s3.CreateMultipartUploadInput)) {
+	startTime := time.Now()
+	name := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	seed := mintest.DataSeed()
+	size := int64(1024 * 1024)
+	args := map[string]interface{}{
+		"bucketName": name,
+		"seed":       seed,
+	}
+
+	scope, err := mintest.NewScope(s3Client, name)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	args["bucketName"], args["prefix"] = scope.Bucket, scope.Prefix
+	defer func() {
+		if err := scope.Remove(s3Client); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	content, err := ioutil.ReadAll(mintest.NewDataReader(seed, size))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Generating the object content failed", err).Fatal()
+		return
+	}
+
+	put := &s3.PutObjectInput{
+		Body:   bytes.NewReader(content),
+		Bucket: aws.String(scope.Bucket),
+		Key:    aws.String(scope.Key("single-part")),
+	}
+	upload := &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(scope.Bucket),
+		Key:    aws.String(scope.Key("multipart")),
+	}
+	encrypt(put, upload)
+	if _, err = s3Client.PutObject(put); err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+	output, err := s3Client.CreateMultipartUpload(upload)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateMultipartUpload Failed", err).Fatal()
+		return
+	}
+	part, err := s3Client.UploadPart(&s3.UploadPartInput{
+		Body:                 bytes.NewReader(content),
+		Bucket:               upload.Bucket,
+		Key:                  upload.Key,
+		PartNumber:           aws.Int64(1),
+		UploadId:             output.UploadId,
+		SSECustomerAlgorithm: sseCustomerAlgorithm(sseKey),
+		SSECustomerKey:       sseKey,
+	})
+	if err == nil {
+		_, err = s3Client.CompleteMultipartUpload(&s3.CompleteMultipartUploadInput{
+			Bucket:          upload.Bucket,
+			Key:             upload.Key,
+			MultipartUpload: &s3.CompletedMultipartUpload{Parts: []*s3.CompletedPart{{ETag: part.ETag, PartNumber: aws.Int64(1)}}},
+			UploadId:        output.UploadId,
+		})
+	}
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go multipart upload Failed", err).Fatal()
+		return
+	}
+
+	deviations := make(map[string]string)
+	for object, plainETag := range map[string]string{
+		"single-part": md5Hex(content),
+		"multipart":   multipartETag(content, len(content)),
+	} {
+		etag, err := headETag(s3Client, scope.Bucket, scope.Key(object), sseKey)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject of %s Failed", object), err).Fatal()
+			return
+		}
+		if etag == plainETag {
+			deviations[object] = fmt.Sprintf("expected an ETag other than the one of the unencrypted object, %s", plainETag)
+		}
+	}
+
+	if len(deviations) > 0 {
+		etagDeviations(function, args, startTime, deviations)
+		return
+	}
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Check the ETags of SSE-C encrypted objects, see testETagEncrypted
+func testETagSSEC(s3Client *s3.S3) {
+	key := aws.String("32byteslongsecretkeymustbegiven1")
+	testETagEncrypted(s3Client, "testETagSSEC", key, func(put *s3.PutObjectInput, upload *s3.CreateMultipartUploadInput) {
+		put.SSECustomerAlgorithm, put.SSECustomerKey = sseCustomerAlgorithm(key), key
+		upload.SSECustomerAlgorithm, upload.SSECustomerKey = sseCustomerAlgorithm(key), key
+	})
+}
+
+// Check the ETags of SSE-KMS encrypted objects, see testETagEncrypted
+func testETagSSEKMS(s3Client *s3.S3) {
+	testETagEncrypted(s3Client, "testETagSSEKMS", nil, func(put *s3.PutObjectInput, upload *s3.CreateMultipartUploadInput) {
+		put.ServerSideEncryption = aws.String(s3.ServerSideEncryptionAwsKms)
+		upload.ServerSideEncryption = aws.String(s3.ServerSideEncryptionAwsKms)
+	})
+}
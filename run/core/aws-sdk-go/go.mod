@@ -5,9 +5,12 @@ go 1.19
 require (
 	github.com/aws/aws-sdk-go v1.44.257
 	github.com/sirupsen/logrus v1.9.0
+	mint.minio.io/mintest v0.0.0-00010101000000-000000000000
 )
 
 require (
 	github.com/jmespath/go-jmespath v0.4.0 // indirect
 	golang.org/x/sys v0.5.0 // indirect
 )
+
+replace mint.minio.io/mintest => ../../../pkg/mintest
@@ -0,0 +1,221 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// standardHeaders are the standard headers stored along with an object
+type standardHeaders struct {
+	ContentEncoding    string
+	CacheControl       string
+	ContentDisposition string
+	ContentLanguage    string
+	Expires            time.Time
+}
+
+// headersOf returns the standard headers of a HEAD or GET response, of
+// which Expires is left unparsed by aws-sdk-go
+func headersOf(contentEncoding, cacheControl, contentDisposition, contentLanguage, expires *string) (standardHeaders, error) {
+	headers := standardHeaders{
+		ContentEncoding:    aws.StringValue(contentEncoding),
+		CacheControl:       aws.StringValue(cacheControl),
+		ContentDisposition: aws.StringValue(contentDisposition),
+		ContentLanguage:    aws.StringValue(contentLanguage),
+	}
+	if expires == nil {
+		return headers, nil
+	}
+	t, err := http.ParseTime(*expires)
+	headers.Expires = t.UTC()
+	return headers, err
+}
+
+// identityEncoding keeps the Go transport from decompressing gzip encoded
+// responses, for their content to be read as stored
+var identityEncoding = request.WithSetRequestHeaders(map[string]string{"Accept-Encoding": "identity"})
+
+// Upload a gzipped object with Content-Encoding: gzip, along with the
+// Cache-Control, Content-Disposition, Content-Language and Expires headers,
+// and check HEAD and GET return them all, and the gzipped content as is.
+// A copy with the COPY metadata directive must keep them, while a copy
+// with the REPLACE directive must have the ones sent with it instead.
+func testStandardHeaders(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testStandardHeaders"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	copied := "copiedObject"
+	replaced := "replacedObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+	defer cleanup(s3Client, bucket, copied, function, args, startTime, false)
+	defer cleanup(s3Client, bucket, replaced, function, args, startTime, false)
+
+	content := strings.Repeat("content compressed with gzip\n", 100)
+	var gzipped bytes.Buffer
+	writer := gzip.NewWriter(&gzipped)
+	writer.Write([]byte(content))
+	writer.Close()
+
+	// Expires is sent with a precision of a second
+	uploaded := standardHeaders{
+		ContentEncoding:    "gzip",
+		CacheControl:       "max-age=3600, public",
+		ContentDisposition: `attachment; filename="content.txt"`,
+		ContentLanguage:    "en-US",
+		Expires:            time.Now().UTC().Add(24 * time.Hour).Truncate(time.Second),
+	}
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:               aws.ReadSeekCloser(bytes.NewReader(gzipped.Bytes())),
+		Bucket:             aws.String(bucket),
+		Key:                aws.String(object),
+		ContentType:        aws.String("text/plain"),
+		ContentEncoding:    aws.String(uploaded.ContentEncoding),
+		CacheControl:       aws.String(uploaded.CacheControl),
+		ContentDisposition: aws.String(uploaded.ContentDisposition),
+		ContentLanguage:    aws.String(uploaded.ContentLanguage),
+		Expires:            aws.Time(uploaded.Expires),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		return
+	}
+
+	checkHeaders := func(object string, expected standardHeaders) bool {
+		head, err := s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject of %s Failed", object), err).Fatal()
+			return false
+		}
+		headers, err := headersOf(head.ContentEncoding, head.CacheControl, head.ContentDisposition, head.ContentLanguage, head.Expires)
+		if err != nil || headers != expected {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject of %s expected headers %+v but got %+v", object, expected, headers), err).Fatal()
+			return false
+		}
+
+		output, err := s3Client.GetObjectWithContext(aws.BackgroundContext(), &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		}, identityEncoding)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of %s Failed", object), err).Fatal()
+			return false
+		}
+		defer output.Body.Close()
+		headers, err = headersOf(output.ContentEncoding, output.CacheControl, output.ContentDisposition, output.ContentLanguage, output.Expires)
+		if err != nil || headers != expected {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of %s expected headers %+v but got %+v", object, expected, headers), err).Fatal()
+			return false
+		}
+		data, err := ioutil.ReadAll(output.Body)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of %s reading body failed", object), err).Fatal()
+			return false
+		}
+		if !bytes.Equal(data, gzipped.Bytes()) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of %s expected the %d gzipped bytes uploaded but got %d bytes", object, gzipped.Len(), len(data)), errors.New("content mismatch")).Fatal()
+			return false
+		}
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err == nil {
+			data, err = ioutil.ReadAll(reader)
+		}
+		if err != nil || string(data) != content {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of %s expected gzipped content which decompresses to the content uploaded", object), err).Fatal()
+			return false
+		}
+		return true
+	}
+	if !checkHeaders(object, uploaded) {
+		return
+	}
+
+	_, err = s3Client.CopyObject(&s3.CopyObjectInput{
+		Bucket:            aws.String(bucket),
+		Key:               aws.String(copied),
+		CopySource:        aws.String(bucket + "/" + object),
+		MetadataDirective: aws.String(s3.MetadataDirectiveCopy),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CopyObject with the COPY metadata directive Failed", err).Fatal()
+		return
+	}
+	if !checkHeaders(copied, uploaded) {
+		return
+	}
+
+	replacement := standardHeaders{
+		ContentEncoding:    "gzip",
+		CacheControl:       "no-cache",
+		ContentDisposition: `inline; filename="replaced.txt"`,
+		ContentLanguage:    "fr-FR",
+		Expires:            uploaded.Expires.Add(24 * time.Hour),
+	}
+	_, err = s3Client.CopyObject(&s3.CopyObjectInput{
+		Bucket:             aws.String(bucket),
+		Key:                aws.String(replaced),
+		CopySource:         aws.String(bucket + "/" + object),
+		MetadataDirective:  aws.String(s3.MetadataDirectiveReplace),
+		ContentType:        aws.String("text/plain"),
+		ContentEncoding:    aws.String(replacement.ContentEncoding),
+		CacheControl:       aws.String(replacement.CacheControl),
+		ContentDisposition: aws.String(replacement.ContentDisposition),
+		ContentLanguage:    aws.String(replacement.ContentLanguage),
+		Expires:            aws.Time(replacement.Expires),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CopyObject with the REPLACE metadata directive Failed", err).Fatal()
+		return
+	}
+	if !checkHeaders(replaced, replacement) {
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
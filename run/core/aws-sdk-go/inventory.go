@@ -0,0 +1,225 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Page size of the listings of testListingConsistency, small enough for
+// the listings to be continued several times
+const inventoryPageSize = 100
+
+// inventoryEntry is an object as uploaded, or as listed
+type inventoryEntry struct {
+	Key  string
+	Size int64
+	ETag string
+}
+
+// inventoryListings list all the objects of a scope, page by page, by
+// name of the listing API
+var inventoryListings = map[string]func(s3Client *s3.S3, scope mintest.Scope) ([]inventoryEntry, error){
+	"ListObjectsV2": func(s3Client *s3.S3, scope mintest.Scope) ([]inventoryEntry, error) {
+		var entries []inventoryEntry
+		err := s3Client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+			Bucket:  aws.String(scope.Bucket),
+			Prefix:  aws.String(scope.Prefix),
+			MaxKeys: aws.Int64(inventoryPageSize),
+		}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+			for _, object := range page.Contents {
+				entries = append(entries, inventoryEntry{aws.StringValue(object.Key), aws.Int64Value(object.Size), strings.Trim(aws.StringValue(object.ETag), `"`)})
+			}
+			return true
+		})
+		return entries, err
+	},
+	"ListObjects": func(s3Client *s3.S3, scope mintest.Scope) ([]inventoryEntry, error) {
+		var entries []inventoryEntry
+		err := s3Client.ListObjectsPages(&s3.ListObjectsInput{
+			Bucket:  aws.String(scope.Bucket),
+			Prefix:  aws.String(scope.Prefix),
+			MaxKeys: aws.Int64(inventoryPageSize),
+		}, func(page *s3.ListObjectsOutput, lastPage bool) bool {
+			for _, object := range page.Contents {
+				entries = append(entries, inventoryEntry{aws.StringValue(object.Key), aws.Int64Value(object.Size), strings.Trim(aws.StringValue(object.ETag), `"`)})
+			}
+			return true
+		})
+		return entries, err
+	},
+	"ListObjectVersions": func(s3Client *s3.S3, scope mintest.Scope) ([]inventoryEntry, error) {
+		var entries []inventoryEntry
+		var err error
+		listErr := s3Client.ListObjectVersionsPages(&s3.ListObjectVersionsInput{
+			Bucket:  aws.String(scope.Bucket),
+			Prefix:  aws.String(scope.Prefix),
+			MaxKeys: aws.Int64(inventoryPageSize),
+		}, func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
+			if len(page.DeleteMarkers) > 0 {
+				err = fmt.Errorf("unexpected delete marker of %s in an unversioned bucket", aws.StringValue(page.DeleteMarkers[0].Key))
+				return false
+			}
+			for _, version := range page.Versions {
+				if !aws.BoolValue(version.IsLatest) {
+					err = fmt.Errorf("unexpected noncurrent version of %s in an unversioned bucket", aws.StringValue(version.Key))
+					return false
+				}
+				entries = append(entries, inventoryEntry{aws.StringValue(version.Key), aws.Int64Value(version.Size), strings.Trim(aws.StringValue(version.ETag), `"`)})
+			}
+			return true
+		})
+		if listErr != nil {
+			return nil, listErr
+		}
+		return entries, err
+	},
+}
+
+// inventoryDiff returns the first difference between the listed entries
+// and the expected ones, which are sorted by key, empty when they agree
+func inventoryDiff(listed, expected []inventoryEntry) string {
+	for i := range expected {
+		if i >= len(listed) {
+			return fmt.Sprintf("%d objects listed out of %d, %s being the first missing", len(listed), len(expected), expected[i].Key)
+		}
+		if listed[i] != expected[i] {
+			return fmt.Sprintf("object %d expected %+v but got %+v", i, expected[i], listed[i])
+		}
+	}
+	if len(listed) > len(expected) {
+		return fmt.Sprintf("%d objects listed out of %d, %s being the first unexpected", len(listed), len(expected), listed[len(expected)].Key)
+	}
+	return ""
+}
+
+// Upload a manifest of objects of known keys, sizes and MD5 sums, nested
+// under prefixes and with special characters in their names, then walk the
+// bucket with ListObjectsV2, ListObjects and ListObjectVersions, page by
+// page. Each listing must hold every object of the manifest once, in
+// order, with its size and ETag, so that the three agree. The listings
+// which deviate are all reported in the failure. The objects are kept in a
+// scope, to run with MINT_TEST_BUCKET.
+func testListingConsistency(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testListingConsistency"
+	name := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	seed := mintest.DataSeed()
+	count := 350
+	workers := 8
+	args := map[string]interface{}{
+		"bucketName": name,
+		"seed":       seed,
+		"objects":    count,
+		"pageSize":   inventoryPageSize,
+	}
+
+	scope, err := mintest.NewScope(s3Client, name)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	args["bucketName"], args["prefix"] = scope.Bucket, scope.Prefix
+	defer func() {
+		if err := scope.Remove(s3Client); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	prefixes := []string{"", "dir/", "dir/sub/", "dir-other/", "space dir/", "unicode/日本語/", "plus+sign/"}
+	manifest := make([]inventoryEntry, count)
+	contents := make([][]byte, count)
+	for i := range manifest {
+		size := int64(i * 37 % 4096)
+		content, err := ioutil.ReadAll(mintest.NewDataReader(seed+uint64(i), size))
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Generating the object content failed", err).Fatal()
+			return
+		}
+		manifest[i] = inventoryEntry{
+			Key:  scope.Key(fmt.Sprintf("%sobject-%04d", prefixes[i%len(prefixes)], i)),
+			Size: size,
+			ETag: md5Hex(content),
+		}
+		contents[i] = content
+	}
+
+	errs := make([]error, count)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < count; i += workers {
+				_, errs[i] = s3Client.PutObject(&s3.PutObjectInput{
+					Body:   bytes.NewReader(contents[i]),
+					Bucket: aws.String(scope.Bucket),
+					Key:    aws.String(manifest[i].Key),
+				})
+			}
+		}(w)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			args["objectName"] = manifest[i].Key
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to succeed but got %v", err), err).Fatal()
+			return
+		}
+	}
+	sort.Slice(manifest, func(i, j int) bool {
+		return manifest[i].Key < manifest[j].Key
+	})
+
+	deviations := make(map[string]string)
+	for name, list := range inventoryListings {
+		listed, err := list(s3Client, scope)
+		if err != nil {
+			deviations[name] = err.Error()
+			continue
+		}
+		if diff := inventoryDiff(listed, manifest); diff != "" {
+			deviations[name] = diff
+		}
+	}
+	if len(deviations) > 0 {
+		names := make([]string, 0, len(deviations))
+		for name := range deviations {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		args["deviations"] = deviations
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Listings deviating from the uploaded objects: %s", strings.Join(names, ", ")), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,191 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// countedConn counts its closing in the open connections of its dialer
+type countedConn struct {
+	net.Conn
+	dialer *countingDialer
+	once   sync.Once
+}
+
+func (c *countedConn) Close() error {
+	c.once.Do(func() { atomic.AddInt64(&c.dialer.open, -1) })
+	return c.Conn.Close()
+}
+
+// countingDialer counts the connections it dialed and those still open
+type countingDialer struct {
+	net.Dialer
+	dialed int64
+	open   int64
+}
+
+func (d *countingDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	conn, err := d.Dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	atomic.AddInt64(&d.dialed, 1)
+	atomic.AddInt64(&d.open, 1)
+	return &countedConn{Conn: conn, dialer: d}, nil
+}
+
+// Issue thousands of small GET and HEAD requests from concurrent workers
+// sharing one keep-alive client. No request may fail, connections must be
+// reused rather than dialed for each request, and none may be left open
+// once the idle ones are closed. Latency percentiles are logged.
+func testKeepAliveConnectionReuse(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testKeepAliveConnectionReuse"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	content := "keep me alive"
+	numRequests := 4000
+	concurrency := 16
+	args := map[string]interface{}{
+		"bucketName":  bucket,
+		"objectName":  object,
+		"numRequests": numRequests,
+		"concurrency": concurrency,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader(content)),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		return
+	}
+
+	dialer := &countingDialer{Dialer: net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}}
+	transport := mintest.LoadConfig().Transport()
+	transport.DialContext = dialer.DialContext
+	transport.MaxIdleConns = concurrency
+	transport.MaxIdleConnsPerHost = concurrency
+	transport.IdleConnTimeout = time.Minute
+	s3Config := s3Client.Config
+	s3Config.HTTPClient = &http.Client{Transport: transport}
+	keepAliveClient := s3.New(session.New(), &s3Config)
+
+	latencies := make([]time.Duration, numRequests)
+	errs := make([]error, numRequests)
+	var wg sync.WaitGroup
+	requestsStart := time.Now()
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < numRequests; i += concurrency {
+				start := time.Now()
+				if i%2 == 0 {
+					var output *s3.GetObjectOutput
+					output, errs[i] = keepAliveClient.GetObject(&s3.GetObjectInput{
+						Bucket: aws.String(bucket),
+						Key:    aws.String(object),
+					})
+					if errs[i] == nil {
+						// Draining the body lets the connection be reused
+						_, errs[i] = io.Copy(ioutil.Discard, output.Body)
+						output.Body.Close()
+					}
+				} else {
+					_, errs[i] = keepAliveClient.HeadObject(&s3.HeadObjectInput{
+						Bucket: aws.String(bucket),
+						Key:    aws.String(object),
+					})
+				}
+				latencies[i] = time.Since(start)
+			}
+		}(w)
+	}
+	wg.Wait()
+	elapsed := time.Since(requestsStart)
+
+	failed := 0
+	var firstErr error
+	for _, err := range errs {
+		if err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	metrics := mintest.NewBenchmarkMetrics(0, elapsed, latencies)
+	args["failedRequests"] = failed
+	args["connectionsDialed"] = atomic.LoadInt64(&dialer.dialed)
+	args["requestsPerSecond"] = metrics.OpsPerSecond
+	args["latencyP50Ms"] = metrics.LatencyP50
+	args["latencyP90Ms"] = metrics.LatencyP90
+	args["latencyP99Ms"] = metrics.LatencyP99
+	args["latencyMaxMs"] = metrics.LatencyMax
+
+	if firstErr != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go %d requests out of %d failed over keep-alive connections", failed, numRequests), firstErr).Fatal()
+		return
+	}
+
+	// Each worker needs a single connection, allow for a few being
+	// closed by the server and redialed.
+	if dialed := atomic.LoadInt64(&dialer.dialed); dialed > int64(2*concurrency) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go dialed %d connections for %d workers, connections are not reused", dialed, concurrency), errors.New("connection reuse failure")).Fatal()
+		return
+	}
+
+	transport.CloseIdleConnections()
+	if open := atomic.LoadInt64(&dialer.open); open != 0 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("%d connections still open after closing the idle ones", open), errors.New("connection leak")).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,189 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"mint.minio.io/mintest"
+)
+
+// Object sizes used when MINT_OBJECT_SIZES is not set
+const (
+	defaultObjectSizes     = "1MiB,64MiB"
+	defaultFullObjectSizes = "1MiB,64MiB,1GiB"
+)
+
+// objectSizes returns the sizes set in MINT_OBJECT_SIZES, or the default
+// ones for the mode of config.
+func objectSizes(config mintest.Config) ([]int64, error) {
+	value := os.Getenv("MINT_OBJECT_SIZES")
+	if value == "" {
+		value = defaultObjectSizes
+		if config.Full() {
+			value = defaultFullObjectSizes
+		}
+	}
+	var sizes []int64
+	for _, s := range strings.Split(value, ",") {
+		size, err := mintest.ParseSize(s)
+		if err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
+
+// hashReader returns the hex encoded SHA256 of everything read from r
+func hashReader(r io.Reader) (string, int64, error) {
+	hash := sha256.New()
+	n, err := io.Copy(hash, r)
+	return hex.EncodeToString(hash.Sum(nil)), n, err
+}
+
+// Upload objects of the sizes set in MINT_OBJECT_SIZES with a single
+// PutObject, with a PUT signed chunk by chunk in aws-chunked encoding and
+// with a multipart upload fed by a plain io.Reader, then stream them back. Contents are generated and checked on the fly
+// with SHA256, nothing is buffered whole in memory.
+func testLargeObjectStreaming(s3Client *s3.S3, config mintest.Config) {
+	startTime := time.Now()
+	function := "testLargeObjectStreaming"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	args := map[string]interface{}{
+		"bucketName": bucket,
+	}
+
+	sizes, err := objectSizes(config)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Invalid MINT_OBJECT_SIZES", err).Fatal()
+		return
+	}
+	args["sizes"] = sizes
+
+	_, err = s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, "", function, args, startTime, true)
+
+	seed := mintest.DataSeed()
+	args["seed"] = seed
+	uploader := s3manager.NewUploaderWithClient(s3Client)
+
+	// S3 single PUTs are limited to 5 GiB
+	const maxPutSize = 5 * 1024 * 1024 * 1024
+
+	for _, size := range sizes {
+		expectedHash := mintest.DataSHA256(seed, size)
+
+		var objects []string
+		if size <= maxPutSize {
+			object := fmt.Sprintf("put-%d", size)
+			defer cleanup(s3Client, bucket, object, function, args, startTime, false)
+			_, err = s3Client.PutObject(&s3.PutObjectInput{
+				Body:   mintest.NewDataReader(seed, size),
+				Bucket: aws.String(bucket),
+				Key:    aws.String(object),
+			})
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT of %d bytes expected to success but got %v", size, err), err).Fatal()
+				return
+			}
+			objects = append(objects, object)
+
+			// Sign the content chunk by chunk as it is generated, and
+			// hash what is sent.
+			object = fmt.Sprintf("streaming-%d", size)
+			defer cleanup(s3Client, bucket, object, function, args, startTime, false)
+			hash := sha256.New()
+			upload := streamingUpload{payloadHash: streamingSignedPayload}
+			status, code, err := upload.putReader(s3Client, bucket, object, io.TeeReader(mintest.NewDataReader(seed, size), hash), size)
+			if err != nil || status != http.StatusOK {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Streaming signed PUT of %d bytes expected to succeed but got %d %s", size, status, code), err).Fatal()
+				return
+			}
+			if sentHash := hex.EncodeToString(hash.Sum(nil)); sentHash != expectedHash {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Streaming signed PUT of %d bytes sent SHA256 %s, expected %s", size, sentHash, expectedHash), errors.New("upload content mismatch")).Fatal()
+				return
+			}
+			objects = append(objects, object)
+		}
+
+		// Hide Seek, so that the uploader streams the content part by part,
+		// and hash what it reads.
+		object := fmt.Sprintf("upload-%d", size)
+		defer cleanup(s3Client, bucket, object, function, args, startTime, false)
+		hash := sha256.New()
+		_, err = uploader.Upload(&s3manager.UploadInput{
+			Body:   io.TeeReader(struct{ io.Reader }{mintest.NewDataReader(seed, size)}, hash),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go upload of %d bytes expected to success but got %v", size, err), err).Fatal()
+			return
+		}
+		if uploadHash := hex.EncodeToString(hash.Sum(nil)); uploadHash != expectedHash {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go upload of %d bytes read SHA256 %s, expected %s", size, uploadHash, expectedHash), errors.New("upload content mismatch")).Fatal()
+			return
+		}
+		objects = append(objects, object)
+
+		for _, object := range objects {
+			output, err := s3Client.GetObject(&s3.GetObjectInput{
+				Bucket: aws.String(bucket),
+				Key:    aws.String(object),
+			})
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of %s Failed", object), err).Fatal()
+				return
+			}
+			downloadHash, n, err := hashReader(output.Body)
+			output.Body.Close()
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of %s reading body failed", object), err).Fatal()
+				return
+			}
+			if n != size || downloadHash != expectedHash {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of %s returned %d bytes with SHA256 %s, expected %d bytes with SHA256 %s",
+					object, n, downloadHash, size, expectedHash), errors.New("download content mismatch")).Fatal()
+				return
+			}
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
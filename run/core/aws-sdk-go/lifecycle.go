@@ -0,0 +1,408 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Time given to lifecycle rules to be applied when MINT_LIFECYCLE_TIMEOUT
+// is not set
+const defaultLifecycleTimeout = 5 * time.Minute
+
+// Interval between two checks of the objects under lifecycle rules
+const lifecyclePollInterval = 5 * time.Second
+
+// Set lifecycle rules dated in the past on a bucket, so that they apply at
+// once: expire the objects under a prefix, and transition the ones under
+// another prefix to the storage class set in TIER_STORAGE_CLASS if any.
+// Then poll the objects until the server applied the rules, which must
+// leave the objects under no rule untouched.
+func testLifecycleExpiry(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testLifecycleExpiry"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	tier := os.Getenv("TIER_STORAGE_CLASS")
+	args := map[string]interface{}{
+		"bucketName":   bucket,
+		"storageClass": tier,
+	}
+
+	timeout := defaultLifecycleTimeout
+	if value := os.Getenv("MINT_LIFECYCLE_TIMEOUT"); value != "" {
+		var err error
+		if timeout, err = time.ParseDuration(value); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Invalid MINT_LIFECYCLE_TIMEOUT", err).Fatal()
+			return
+		}
+	}
+	args["timeout"] = timeout.String()
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	// Lifecycle dates must be at midnight UTC
+	yesterday := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	rules := []*s3.LifecycleRule{{
+		ID:         aws.String("expire"),
+		Status:     aws.String(s3.ExpirationStatusEnabled),
+		Filter:     &s3.LifecycleRuleFilter{Prefix: aws.String("expire/")},
+		Expiration: &s3.LifecycleExpiration{Date: aws.Time(yesterday)},
+	}}
+	if tier != "" {
+		rules = append(rules, &s3.LifecycleRule{
+			ID:          aws.String("transition"),
+			Status:      aws.String(s3.ExpirationStatusEnabled),
+			Filter:      &s3.LifecycleRuleFilter{Prefix: aws.String("transition/")},
+			Transitions: []*s3.Transition{{Date: aws.Time(yesterday), StorageClass: aws.String(tier)}},
+		})
+	}
+
+	_, err = s3Client.PutBucketLifecycleConfiguration(&s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 aws.String(bucket),
+		LifecycleConfiguration: &s3.BucketLifecycleConfiguration{Rules: rules},
+	})
+	if mintest.IsNotImplemented(err) {
+		mintest.IgnoreLog(function, args, startTime, "Bucket lifecycle is not implemented").Info()
+		return
+	}
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutBucketLifecycleConfiguration Failed", err).Fatal()
+		return
+	}
+
+	config, err := s3Client.GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetBucketLifecycleConfiguration Failed", err).Fatal()
+		return
+	}
+	var ids []string
+	for _, rule := range config.Rules {
+		ids = append(ids, aws.StringValue(rule.ID))
+	}
+	sort.Strings(ids)
+	expectedIDs := []string{"expire"}
+	if tier != "" {
+		expectedIDs = []string{"expire", "transition"}
+	}
+	if !reflect.DeepEqual(ids, expectedIDs) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetBucketLifecycleConfiguration expected rules %v but got %v", expectedIDs, ids),
+			errors.New("lifecycle configuration mismatch")).Fatal()
+		return
+	}
+
+	objects := []string{"expire/object-1", "expire/object-2", "keep/object"}
+	if tier != "" {
+		objects = append(objects, "transition/object")
+	}
+	for _, object := range objects {
+		_, err = s3Client.PutObject(&s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader("lifecycle")),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+			return
+		}
+	}
+
+	// applied reports whether the objects under the expiry rule are gone
+	// and the ones under the transition rule are in the tier, checking
+	// the others are left untouched
+	applied := func() (bool, error) {
+		list, err := s3Client.ListObjectsV2(&s3.ListObjectsV2Input{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			return false, err
+		}
+		done := true
+		listed := make(map[string]string)
+		for _, object := range list.Contents {
+			listed[aws.StringValue(object.Key)] = aws.StringValue(object.StorageClass)
+		}
+		for _, object := range objects {
+			storageClass, ok := listed[object]
+			switch {
+			case strings.HasPrefix(object, "expire/"):
+				done = done && !ok
+			case !ok:
+				return false, fmt.Errorf("%s expired while under no expiry rule", object)
+			case strings.HasPrefix(object, "transition/"):
+				done = done && storageClass == tier
+			case storageClass != s3.StorageClassStandard:
+				return false, fmt.Errorf("%s moved to storage class %s while under no transition rule", object, storageClass)
+			}
+		}
+		return done, nil
+	}
+
+	for start := time.Now(); ; time.Sleep(lifecyclePollInterval) {
+		done, err := applied()
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "Lifecycle rules applied to the wrong objects", err).Fatal()
+			return
+		}
+		if done {
+			args["delay"] = time.Since(start).String()
+			break
+		}
+		if time.Since(start) > timeout {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Lifecycle rules not applied after %s", timeout), errors.New("lifecycle not executed")).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// lifecycleRulesByID sorts lifecycle rules by ID, servers being free to
+// return them in any order
+func lifecycleRulesByID(rules []*s3.LifecycleRule) []*s3.LifecycleRule {
+	sort.Slice(rules, func(i, j int) bool {
+		return aws.StringValue(rules[i].ID) < aws.StringValue(rules[j].ID)
+	})
+	return rules
+}
+
+// Set lifecycle rules filtering objects by size, by tags and by a prefix
+// and tags combined, and check the configuration read back is the one set.
+// Then check invalid configurations, with conflicting or overlapping rules,
+// are rejected and leave the configuration unchanged.
+func testLifecycleFilters(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testLifecycleFilters"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	args := map[string]interface{}{
+		"bucketName": bucket,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	// Rules are sorted by ID to be compared with the ones read back
+	rules := []*s3.LifecycleRule{
+		{
+			ID:     aws.String("large"),
+			Status: aws.String(s3.ExpirationStatusEnabled),
+			Filter: &s3.LifecycleRuleFilter{
+				ObjectSizeGreaterThan: aws.Int64(64 * 1024 * 1024),
+			},
+			Expiration: &s3.LifecycleExpiration{Days: aws.Int64(7)},
+		},
+		{
+			ID:     aws.String("prefix-and-tags"),
+			Status: aws.String(s3.ExpirationStatusEnabled),
+			Filter: &s3.LifecycleRuleFilter{
+				And: &s3.LifecycleRuleAndOperator{
+					Prefix: aws.String("data/"),
+					Tags: []*s3.Tag{
+						{Key: aws.String("env"), Value: aws.String("dev")},
+						{Key: aws.String("team"), Value: aws.String("mint")},
+					},
+				},
+			},
+			Expiration: &s3.LifecycleExpiration{Days: aws.Int64(90)},
+		},
+		{
+			ID:     aws.String("size-range"),
+			Status: aws.String(s3.ExpirationStatusEnabled),
+			Filter: &s3.LifecycleRuleFilter{
+				And: &s3.LifecycleRuleAndOperator{
+					Prefix:                aws.String("logs/"),
+					ObjectSizeGreaterThan: aws.Int64(1024),
+					ObjectSizeLessThan:    aws.Int64(1024 * 1024),
+				},
+			},
+			Expiration: &s3.LifecycleExpiration{Days: aws.Int64(30)},
+		},
+		{
+			ID:     aws.String("small"),
+			Status: aws.String(s3.ExpirationStatusDisabled),
+			Filter: &s3.LifecycleRuleFilter{
+				ObjectSizeLessThan: aws.Int64(128),
+			},
+			Expiration: &s3.LifecycleExpiration{Days: aws.Int64(1)},
+		},
+		{
+			ID:     aws.String("tag"),
+			Status: aws.String(s3.ExpirationStatusEnabled),
+			Filter: &s3.LifecycleRuleFilter{
+				Tag: &s3.Tag{Key: aws.String("archive"), Value: aws.String("true")},
+			},
+			Expiration: &s3.LifecycleExpiration{Days: aws.Int64(365)},
+		},
+	}
+	_, err = s3Client.PutBucketLifecycleConfiguration(&s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 aws.String(bucket),
+		LifecycleConfiguration: &s3.BucketLifecycleConfiguration{Rules: rules},
+	})
+	if mintest.IsNotImplemented(err) {
+		mintest.IgnoreLog(function, args, startTime, "Bucket lifecycle is not implemented").Info()
+		return
+	}
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutBucketLifecycleConfiguration Failed", err).Fatal()
+		return
+	}
+
+	// checkRules reads the configuration back and compares it to rules
+	checkRules := func() bool {
+		output, err := s3Client.GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetBucketLifecycleConfiguration Failed", err).Fatal()
+			return false
+		}
+		if got := lifecycleRulesByID(output.Rules); !reflect.DeepEqual(got, rules) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetBucketLifecycleConfiguration expected %v but got %v", rules, got),
+				errors.New("lifecycle configuration mismatch")).Fatal()
+			return false
+		}
+		return true
+	}
+	if !checkRules() {
+		return
+	}
+
+	invalid := []struct {
+		name  string
+		rules []*s3.LifecycleRule
+	}{
+		{"duplicate rule ID", []*s3.LifecycleRule{
+			{
+				ID:         aws.String("rule"),
+				Status:     aws.String(s3.ExpirationStatusEnabled),
+				Filter:     &s3.LifecycleRuleFilter{Prefix: aws.String("a/")},
+				Expiration: &s3.LifecycleExpiration{Days: aws.Int64(1)},
+			},
+			{
+				ID:         aws.String("rule"),
+				Status:     aws.String(s3.ExpirationStatusEnabled),
+				Filter:     &s3.LifecycleRuleFilter{Prefix: aws.String("b/")},
+				Expiration: &s3.LifecycleExpiration{Days: aws.Int64(2)},
+			},
+		}},
+		{"empty size range", []*s3.LifecycleRule{{
+			ID:     aws.String("rule"),
+			Status: aws.String(s3.ExpirationStatusEnabled),
+			Filter: &s3.LifecycleRuleFilter{
+				And: &s3.LifecycleRuleAndOperator{
+					Prefix:                aws.String("logs/"),
+					ObjectSizeGreaterThan: aws.Int64(1024 * 1024),
+					ObjectSizeLessThan:    aws.Int64(1024),
+				},
+			},
+			Expiration: &s3.LifecycleExpiration{Days: aws.Int64(1)},
+		}}},
+		{"duplicate tag key", []*s3.LifecycleRule{{
+			ID:     aws.String("rule"),
+			Status: aws.String(s3.ExpirationStatusEnabled),
+			Filter: &s3.LifecycleRuleFilter{
+				And: &s3.LifecycleRuleAndOperator{
+					Tags: []*s3.Tag{
+						{Key: aws.String("env"), Value: aws.String("dev")},
+						{Key: aws.String("env"), Value: aws.String("prod")},
+					},
+				},
+			},
+			Expiration: &s3.LifecycleExpiration{Days: aws.Int64(1)},
+		}}},
+		{"expiration by days and date", []*s3.LifecycleRule{{
+			ID:     aws.String("rule"),
+			Status: aws.String(s3.ExpirationStatusEnabled),
+			Filter: &s3.LifecycleRuleFilter{Prefix: aws.String("a/")},
+			Expiration: &s3.LifecycleExpiration{
+				Days: aws.Int64(1),
+				Date: aws.Time(time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)),
+			},
+		}}},
+		{"expired delete markers along with days", []*s3.LifecycleRule{{
+			ID:     aws.String("rule"),
+			Status: aws.String(s3.ExpirationStatusEnabled),
+			Filter: &s3.LifecycleRuleFilter{Prefix: aws.String("a/")},
+			Expiration: &s3.LifecycleExpiration{
+				Days:                      aws.Int64(1),
+				ExpiredObjectDeleteMarker: aws.Bool(true),
+			},
+		}}},
+		{"negative size", []*s3.LifecycleRule{{
+			ID:         aws.String("rule"),
+			Status:     aws.String(s3.ExpirationStatusEnabled),
+			Filter:     &s3.LifecycleRuleFilter{ObjectSizeGreaterThan: aws.Int64(-1)},
+			Expiration: &s3.LifecycleExpiration{Days: aws.Int64(1)},
+		}}},
+	}
+	for _, testCase := range invalid {
+		args["invalidConfiguration"] = testCase.name
+		_, err = s3Client.PutBucketLifecycleConfiguration(&s3.PutBucketLifecycleConfigurationInput{
+			Bucket:                 aws.String(bucket),
+			LifecycleConfiguration: &s3.BucketLifecycleConfiguration{Rules: testCase.rules},
+		})
+		// Servers differ on the code of each validation error
+		aerr, ok := err.(awserr.Error)
+		if !ok || (aerr.Code() != "InvalidRequest" && aerr.Code() != "InvalidArgument" && aerr.Code() != "MalformedXML") {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PutBucketLifecycleConfiguration with a %s expected to be rejected as invalid", testCase.name), err).Fatal()
+			return
+		}
+		if !checkRules() {
+			return
+		}
+	}
+	delete(args, "invalidConfiguration")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
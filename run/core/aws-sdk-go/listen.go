@@ -0,0 +1,192 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// notificationEvent is an event record as sent by the listen API
+type notificationEvent struct {
+	EventName string `json:"eventName"`
+	S3        struct {
+		Object struct {
+			Key  string `json:"key"`
+			Size int64  `json:"size"`
+			ETag string `json:"eTag"`
+		} `json:"object"`
+	} `json:"s3"`
+}
+
+// listenBucketNotification opens the MinIO listen API of bucket for the
+// given events, and streams the received records until the response is
+// closed. The response is returned as-is when its status is not 200, and
+// an error when its headers are not received before timeout.
+func listenBucketNotification(s3Client *s3.S3, bucket string, events []string, timeout time.Duration) (*http.Response, <-chan notificationEvent, error) {
+	query := url.Values{"events": events}
+	req, err := http.NewRequest(http.MethodGet, s3Client.Endpoint+"/"+bucket+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	signer := v4.NewSigner(s3Client.Config.Credentials)
+	if _, err = signer.Sign(req, nil, "s3", aws.StringValue(s3Client.Config.Region), time.Now()); err != nil {
+		return nil, nil, err
+	}
+	// The response is streamed for as long as the body is open, only
+	// bound the wait for its headers.
+	transport := mintest.LoadConfig().Transport()
+	transport.ResponseHeaderTimeout = timeout
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil, nil
+	}
+
+	ch := make(chan notificationEvent, 16)
+	go func() {
+		defer close(ch)
+		// Records are separated by whitespace, also sent to keep the
+		// connection alive.
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var info struct {
+				Records []notificationEvent
+			}
+			if err := dec.Decode(&info); err != nil {
+				return
+			}
+			for _, record := range info.Records {
+				ch <- record
+			}
+		}
+	}()
+	return resp, ch, nil
+}
+
+// waitEvent returns the first event named eventName for key received on
+// ch before timeout.
+func waitEvent(ch <-chan notificationEvent, eventName, key string, timeout time.Duration) (notificationEvent, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				return event, errors.New("notification stream closed")
+			}
+			eventKey, _ := url.QueryUnescape(event.S3.Object.Key)
+			if event.EventName == eventName && eventKey == key {
+				return event, nil
+			}
+		case <-timer.C:
+			return notificationEvent{}, fmt.Errorf("no %s event for %s received in %v", eventName, key, timeout)
+		}
+	}
+}
+
+// Listen to the events of a bucket with the MinIO listen API, then PUT and
+// DELETE an object and check the matching events arrive in time with the
+// right key, size and ETag.
+func testListenBucketNotification(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testListenBucketNotification"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	content := "listen to me"
+	timeout := 30 * time.Second
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"timeout":    timeout,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	resp, events, err := listenBucketNotification(s3Client, bucket, []string{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"}, timeout)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Listen bucket notification request failed", err).Fatal()
+		return
+	}
+	defer resp.Body.Close()
+	if events == nil {
+		// Not a MinIO server
+		mintest.IgnoreLog(function, args, startTime, fmt.Sprintf("ListenBucketNotification returned %s", resp.Status)).Info()
+		return
+	}
+
+	putOutput, err := s3Client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader(content)),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		return
+	}
+
+	event, err := waitEvent(events, "s3:ObjectCreated:Put", object, timeout)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Listen bucket notification missed the PUT event", err).Fatal()
+		return
+	}
+	etag := strings.Trim(aws.StringValue(putOutput.ETag), `"`)
+	if event.S3.Object.Size != int64(len(content)) || strings.Trim(event.S3.Object.ETag, `"`) != etag {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Listen bucket notification PUT event expected size %d and ETag %s but got %d and %s",
+			len(content), etag, event.S3.Object.Size, event.S3.Object.ETag), errors.New("event mismatch")).Fatal()
+		return
+	}
+
+	_, err = s3Client.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteObject Failed", err).Fatal()
+		return
+	}
+
+	if _, err = waitEvent(events, "s3:ObjectRemoved:Delete", object, timeout); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Listen bucket notification missed the DELETE event", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,156 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Standard AWS regions, tried as the location constraint of new buckets
+var standardRegions = []string{
+	"us-east-1", "us-east-2", "us-west-1", "us-west-2", "ca-central-1",
+	"eu-west-1", "eu-west-2", "eu-west-3", "eu-central-1", "eu-north-1",
+	"ap-south-1", "ap-southeast-1", "ap-southeast-2", "ap-northeast-1",
+	"ap-northeast-2", "sa-east-1",
+}
+
+// regionClient returns an S3 client for the same server as s3Client,
+// signing its requests for region
+func regionClient(s3Client *s3.S3, region string) *s3.S3 {
+	client := s3.New(session.New(), s3Client.Config.Copy(&aws.Config{Region: aws.String(region)}))
+	mintest.TrackBuckets(client)
+	return client
+}
+
+// Create a bucket in each standard region, with the region as location
+// constraint, us-east-1 taking none, and signing the request for it.
+// Servers serving a single region may reject the other ones with
+// InvalidRegion, but a bucket created in a region must report it in
+// GetBucketLocation, and creating it again must fail, but in us-east-1 on
+// AWS, which answers 200 for a bucket the caller owns. Location constraints
+// which are not regions must be rejected.
+func testCreateBucketError(s3Client *s3.S3, config mintest.Config) {
+	startTime := time.Now()
+	function := "testCreateBucketError"
+	region := aws.StringValue(s3Client.Config.Region)
+	args := map[string]interface{}{
+		"region": region,
+	}
+
+	// Errors of the regions a server does not serve
+	unsupported := map[string]bool{
+		"InvalidRegion":                      true,
+		"IllegalLocationConstraintException": true,
+		"AuthorizationHeaderMalformed":       true,
+	}
+	// The region of the server comes first and must be supported, even
+	// when it is not a standard one
+	regions := []string{region}
+	for _, location := range standardRegions {
+		if location != region {
+			regions = append(regions, location)
+		}
+	}
+	var supported []string
+	for _, location := range regions {
+		bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+		args["bucketName"] = bucket
+		args["locationConstraint"] = location
+		client := regionClient(s3Client, location)
+
+		input := &s3.CreateBucketInput{
+			Bucket: aws.String(bucket),
+		}
+		expected := ""
+		if location != "us-east-1" {
+			input.CreateBucketConfiguration = &s3.CreateBucketConfiguration{LocationConstraint: aws.String(location)}
+			expected = location
+		}
+		_, err := client.CreateBucket(input)
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); ok && unsupported[aerr.Code()] && location != region {
+				continue
+			}
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+			return
+		}
+		supported = append(supported, location)
+
+		output, err := client.GetBucketLocation(&s3.GetBucketLocationInput{
+			Bucket: aws.String(bucket),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetBucketLocation Failed", err).Fatal()
+			return
+		}
+		if got := aws.StringValue(output.LocationConstraint); got != expected {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetBucketLocation expected LocationConstraint %q but got %q", expected, got), nil).Fatal()
+			return
+		}
+
+		_, err = client.CreateBucket(input)
+		// AWS answers 200 to re-creating a bucket the caller owns in us-east-1
+		legacy := err == nil && config.ServerProfile == mintest.ProfileAWS && location == "us-east-1"
+		if aerr, ok := err.(awserr.Error); !legacy && (!ok || (aerr.Code() != s3.ErrCodeBucketAlreadyExists && aerr.Code() != s3.ErrCodeBucketAlreadyOwnedByYou)) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CreateBucket of an existing bucket expected BucketAlreadyExists or BucketAlreadyOwnedByYou but got %v", err), err).Fatal()
+			return
+		}
+
+		if err = mintest.RemoveBucket(client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+			return
+		}
+	}
+	delete(args, "bucketName")
+	delete(args, "locationConstraint")
+	args["supportedRegions"] = supported
+
+	for _, location := range []string{"invalid-region", "US EAST 1", "eu-west-99", strings.Repeat("a", 64)} {
+		bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+		args["bucketName"] = bucket
+		args["locationConstraint"] = location
+		_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+			Bucket:                    aws.String(bucket),
+			CreateBucketConfiguration: &s3.CreateBucketConfiguration{LocationConstraint: aws.String(location)},
+		})
+		if err == nil {
+			mintest.RemoveBucket(s3Client, bucket)
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket with an invalid location constraint expected to fail", nil).Fatal()
+			return
+		}
+		if aerr, ok := err.(awserr.Error); !ok || (aerr.Code() != "InvalidLocationConstraint" && !unsupported[aerr.Code()]) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CreateBucket with an invalid location constraint expected InvalidLocationConstraint or InvalidRegion but got %v", err), err).Fatal()
+			return
+		}
+	}
+	delete(args, "bucketName")
+	delete(args, "locationConstraint")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
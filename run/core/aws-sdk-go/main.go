@@ -21,166 +21,24 @@ package main
 
 import (
 	"bytes"
-	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"math/rand"
 	"net/http"
-	"os"
 	"reflect"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	log "github.com/sirupsen/logrus"
+	"mint.minio.io/mintest"
 )
 
-const letterBytes = "abcdefghijklmnopqrstuvwxyz01234569"
-const (
-	letterIdxBits = 6                    // 6 bits to represent a letter index
-	letterIdxMask = 1<<letterIdxBits - 1 // All 1-bits, as many as letterIdxBits
-	letterIdxMax  = 63 / letterIdxBits   // # of letter indices fitting in 63 bits
-)
-
-// different kinds of test failures
-const (
-	PASS = "PASS" // Indicate that a test passed
-	FAIL = "FAIL" // Indicate that a test failed
-)
-
-type errorResponse struct {
-	XMLName    xml.Name `xml:"Error" json:"-"`
-	Code       string
-	Message    string
-	BucketName string
-	Key        string
-	RequestID  string `xml:"RequestId"`
-	HostID     string `xml:"HostId"`
-
-	// Region where the bucket is located. This header is returned
-	// only in HEAD bucket and ListObjects response.
-	Region string
-
-	// Headers of the returned S3 XML error
-	Headers http.Header `xml:"-" json:"-"`
-}
-
-type mintJSONFormatter struct{}
-
-func (f *mintJSONFormatter) Format(entry *log.Entry) ([]byte, error) {
-	data := make(log.Fields, len(entry.Data))
-	for k, v := range entry.Data {
-		switch v := v.(type) {
-		case error:
-			// Otherwise errors are ignored by `encoding/json`
-			// https://github.com/sirupsen/logrus/issues/137
-			data[k] = v.Error()
-		default:
-			data[k] = v
-		}
-	}
-
-	serialized, err := json.Marshal(data)
-	if err != nil {
-		return nil, fmt.Errorf("Failed to marshal fields to JSON, %w", err)
-	}
-	return append(serialized, '\n'), nil
-}
-
-// log successful test runs
-func successLogger(function string, args map[string]interface{}, startTime time.Time) *log.Entry {
-	// calculate the test case duration
-	duration := time.Since(startTime)
-	// log with the fields as per mint
-	fields := log.Fields{"name": "aws-sdk-go", "function": function, "args": args, "duration": duration.Nanoseconds() / 1000000, "status": PASS}
-	return log.WithFields(fields)
-}
-
-// log failed test runs
-func failureLog(function string, args map[string]interface{}, startTime time.Time, alert string, message string, err error) *log.Entry {
-	// calculate the test case duration
-	duration := time.Since(startTime)
-	var fields log.Fields
-	// log with the fields as per mint
-	if err != nil {
-		fields = log.Fields{
-			"name": "aws-sdk-go", "function": function, "args": args,
-			"duration": duration.Nanoseconds() / 1000000, "status": FAIL, "alert": alert, "message": message, "error": err,
-		}
-	} else {
-		fields = log.Fields{
-			"name": "aws-sdk-go", "function": function, "args": args,
-			"duration": duration.Nanoseconds() / 1000000, "status": FAIL, "alert": alert, "message": message,
-		}
-	}
-	return log.WithFields(fields)
-}
-
-func randString(n int, src rand.Source, prefix string) string {
-	b := make([]byte, n)
-	// A rand.Int63() generates 63 random bits, enough for letterIdxMax letters!
-	for i, cache, remain := n-1, src.Int63(), letterIdxMax; i >= 0; {
-		if remain == 0 {
-			cache, remain = src.Int63(), letterIdxMax
-		}
-		if idx := int(cache & letterIdxMask); idx < len(letterBytes) {
-			b[i] = letterBytes[idx]
-			i--
-		}
-		cache >>= letterIdxBits
-		remain--
-	}
-	return prefix + string(b[0:30-len(prefix)])
-}
-
-func isObjectTaggingImplemented(s3Client *s3.S3) bool {
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
-	object := randString(60, rand.NewSource(time.Now().UnixNano()), "")
-	startTime := time.Now()
-	function := "isObjectTaggingImplemented"
-	args := map[string]interface{}{
-		"bucketName": bucket,
-		"objectName": object,
-	}
-	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
-
-	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
-		Bucket: aws.String(bucket),
-	})
-	if err != nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
-		return false
-	}
-
-	_, err = s3Client.PutObject(&s3.PutObjectInput{
-		Body:   aws.ReadSeekCloser(strings.NewReader("testfile")),
-		Bucket: aws.String(bucket),
-		Key:    aws.String(object),
-	})
-
-	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
-		return false
-	}
-
-	_, err = s3Client.GetObjectTagging(&s3.GetObjectTaggingInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(object),
-	})
-	if err != nil {
-		if awsErr, ok := err.(awserr.Error); ok {
-			if awsErr.Code() == "NotImplemented" {
-				return false
-			}
-		}
-	}
-	return true
-}
+// Prefix of the names of all the buckets created by this suite
+const bucketPrefix = "aws-sdk-go-test-"
 
 func cleanup(s3Client *s3.S3, bucket string, object string, function string,
 	args map[string]interface{}, startTime time.Time, deleteBucket bool,
@@ -196,7 +54,7 @@ func cleanup(s3Client *s3.S3, bucket string, object string, function string,
 			Bucket: aws.String(bucket),
 		})
 		if err != nil {
-			failureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
 			return
 		}
 	}
@@ -205,7 +63,7 @@ func cleanup(s3Client *s3.S3, bucket string, object string, function string,
 func testPresignedPutInvalidHash(s3Client *s3.S3) {
 	startTime := time.Now()
 	function := "PresignedPut"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
 	object := "presignedTest"
 	expiry := 1 * time.Minute
 	args := map[string]interface{}{
@@ -218,7 +76,7 @@ func testPresignedPutInvalidHash(s3Client *s3.S3) {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
 		return
 	}
 	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
@@ -232,46 +90,46 @@ func testPresignedPutInvalidHash(s3Client *s3.S3) {
 	req.HTTPRequest.Header.Set("X-Amz-Content-Sha256", "invalid-sha256")
 	url, err := req.Presign(expiry)
 	if err != nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go presigned Put request creation failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go presigned Put request creation failed", err).Fatal()
 		return
 	}
 
 	rreq, err := http.NewRequest(http.MethodPut, url, bytes.NewReader([]byte("")))
 	if err != nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go presigned PUT request failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go presigned PUT request failed", err).Fatal()
 		return
 	}
 
 	rreq.Header.Set("X-Amz-Content-Sha256", "invalid-sha256")
 	rreq.Header.Set("Content-Type", "application/octet-stream")
 
-	resp, err := http.DefaultClient.Do(rreq)
+	resp, err := s3Client.Config.HTTPClient.Do(rreq)
 	if err != nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go presigned put request failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go presigned put request failed", err).Fatal()
 		return
 	}
 	defer resp.Body.Close()
 
 	dec := xml.NewDecoder(resp.Body)
-	errResp := errorResponse{}
+	errResp := mintest.ErrorResponse{}
 	err = dec.Decode(&errResp)
 	if err != nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go unmarshalling xml failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go unmarshalling xml failed", err).Fatal()
 		return
 	}
 
 	if errResp.Code != "XAmzContentSHA256Mismatch" {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go presigned PUT expected to fail with XAmzContentSHA256Mismatch but got %v", errResp.Code), errors.New("AWS S3 error code mismatch")).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go presigned PUT expected to fail with XAmzContentSHA256Mismatch but got %v", errResp.Code), errors.New("AWS S3 error code mismatch")).Fatal()
 		return
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 func testListObjects(s3Client *s3.S3) {
 	startTime := time.Now()
 	function := "testListObjects"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
 	object1 := "testObject1"
 	object2 := "testObject2"
 	expiry := 1 * time.Minute
@@ -293,7 +151,7 @@ func testListObjects(s3Client *s3.S3) {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
 		return
 	}
 	defer cleanup(s3Client, bucket, object1, function, args, startTime, true)
@@ -306,11 +164,11 @@ func testListObjects(s3Client *s3.S3) {
 	}
 	result, err := s3Client.ListObjectsV2(listInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go listobjects expected to success but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go listobjects expected to success but got %v", err), err).Fatal()
 		return
 	}
 	if *result.KeyCount != 0 {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go listobjects with prefix '' expected 0 key but got %v, %v", result.KeyCount, getKeys(result.Contents)), errors.New("AWS S3 key count mismatch")).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go listobjects with prefix '' expected 0 key but got %v, %v", result.KeyCount, getKeys(result.Contents)), errors.New("AWS S3 key count mismatch")).Fatal()
 		return
 	}
 	putInput1 := &s3.PutObjectInput{
@@ -320,7 +178,7 @@ func testListObjects(s3Client *s3.S3) {
 	}
 	_, err = s3Client.PutObject(putInput1)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
 		return
 	}
 	putInput2 := &s3.PutObjectInput{
@@ -330,26 +188,26 @@ func testListObjects(s3Client *s3.S3) {
 	}
 	_, err = s3Client.PutObject(putInput2)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
 		return
 	}
 	result, err = s3Client.ListObjectsV2(listInput)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go listobjects expected to success but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go listobjects expected to success but got %v", err), err).Fatal()
 		return
 	}
 	if *result.KeyCount != 2 {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go listobjects with prefix '' expected 2 key but got %v, %v", *result.KeyCount, getKeys(result.Contents)), errors.New("AWS S3 key count mismatch")).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go listobjects with prefix '' expected 2 key but got %v, %v", *result.KeyCount, getKeys(result.Contents)), errors.New("AWS S3 key count mismatch")).Fatal()
 		return
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 func testSelectObject(s3Client *s3.S3) {
 	startTime := time.Now()
 	function := "testSelectObject"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
 	object1 := "object1.csv"
 	object2 := "object2.csv"
 	args := map[string]interface{}{
@@ -362,7 +220,7 @@ func testSelectObject(s3Client *s3.S3) {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
 		return
 	}
 
@@ -399,7 +257,7 @@ func testSelectObject(s3Client *s3.S3) {
 	}
 	_, err = s3Client.PutObject(putInput1)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object failed %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object failed %v", err), err).Fatal()
 		return
 	}
 
@@ -426,7 +284,7 @@ func testSelectObject(s3Client *s3.S3) {
 
 	resp, err := s3Client.SelectObjectContent(params)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object failed %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object failed %v", err), err).Fatal()
 		return
 	}
 	defer resp.EventStream.Close()
@@ -441,12 +299,12 @@ func testSelectObject(s3Client *s3.S3) {
 	}
 
 	if err := resp.EventStream.Err(); err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object failed %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object failed %v", err), err).Fatal()
 		return
 	}
 
 	if payload != outputCSV1 {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object output mismatch %v", payload), errors.New("AWS S3 select object mismatch")).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object output mismatch %v", payload), errors.New("AWS S3 select object mismatch")).Fatal()
 		return
 	}
 
@@ -483,7 +341,7 @@ func testSelectObject(s3Client *s3.S3) {
 	}
 	_, err = s3Client.PutObject(putInput2)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object upload failed: %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object upload failed: %v", err), err).Fatal()
 		return
 	}
 
@@ -510,7 +368,7 @@ func testSelectObject(s3Client *s3.S3) {
 
 	resp, err = s3Client.SelectObjectContent(params2)
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object failed for unicode separator %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object failed for unicode separator %v", err), err).Fatal()
 		return
 	}
 	defer resp.EventStream.Close()
@@ -524,23 +382,23 @@ func testSelectObject(s3Client *s3.S3) {
 	}
 
 	if err := resp.EventStream.Err(); err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object failed for unicode separator %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object failed for unicode separator %v", err), err).Fatal()
 		return
 	}
 
 	if payload != outputCSV2 {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object output mismatch %v", payload), errors.New("AWS S3 select object mismatch")).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object output mismatch %v", payload), errors.New("AWS S3 select object mismatch")).Fatal()
 		return
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 func testObjectTagging(s3Client *s3.S3) {
 	startTime := time.Now()
 	function := "testObjectTagging"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
-	object := randString(60, rand.NewSource(time.Now().UnixNano()), "")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "")
 	args := map[string]interface{}{
 		"bucketName": bucket,
 		"objectName": object,
@@ -550,7 +408,7 @@ func testObjectTagging(s3Client *s3.S3) {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
 		return
 	}
 	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
@@ -570,7 +428,7 @@ func testObjectTagging(s3Client *s3.S3) {
 	})
 
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
 		return
 	}
 
@@ -580,12 +438,12 @@ func testObjectTagging(s3Client *s3.S3) {
 	})
 	if err != nil {
 		if awsErr, ok := err.(awserr.Error); ok {
-			failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUTObjectTagging expected to success but got %v", awsErr.Code()), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUTObjectTagging expected to success but got %v", awsErr.Code()), err).Fatal()
 			return
 		}
 	}
 	if !reflect.DeepEqual(tagop.TagSet, tagInputSet) {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUTObject Tag input did not match with GetObjectTagging output %v", nil), nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUTObject Tag input did not match with GetObjectTagging output %v", nil), nil).Fatal()
 		return
 	}
 
@@ -604,7 +462,7 @@ func testObjectTagging(s3Client *s3.S3) {
 	})
 	if err != nil {
 		if awsErr, ok := err.(awserr.Error); ok {
-			failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUTObjectTagging expected to success but got %v", awsErr.Code()), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUTObjectTagging expected to success but got %v", awsErr.Code()), err).Fatal()
 			return
 		}
 	}
@@ -615,22 +473,22 @@ func testObjectTagging(s3Client *s3.S3) {
 	})
 	if err != nil {
 		if awsErr, ok := err.(awserr.Error); ok {
-			failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUTObjectTagging expected to success but got %v", awsErr.Code()), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUTObjectTagging expected to success but got %v", awsErr.Code()), err).Fatal()
 			return
 		}
 	}
 	if !reflect.DeepEqual(tagop.TagSet, taginputSet1) {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUTObjectTagging input did not match with GetObjectTagging output %v", nil), nil).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUTObjectTagging input did not match with GetObjectTagging output %v", nil), nil).Fatal()
 		return
 	}
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 func testObjectTaggingErrors(s3Client *s3.S3) {
 	startTime := time.Now()
 	function := "testObjectTaggingErrors"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
-	object := randString(60, rand.NewSource(time.Now().UnixNano()), "")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "")
 	args := map[string]interface{}{
 		"bucketName": bucket,
 		"objectName": object,
@@ -640,7 +498,7 @@ func testObjectTaggingErrors(s3Client *s3.S3) {
 		Bucket: aws.String(bucket),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
 		return
 	}
 	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
@@ -652,7 +510,7 @@ func testObjectTaggingErrors(s3Client *s3.S3) {
 	})
 
 	if err != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
 		return
 	}
 
@@ -712,13 +570,13 @@ func testObjectTaggingErrors(s3Client *s3.S3) {
 
 	_, err = s3Client.PutObjectTagging(input)
 	if err == nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go PUT expected to fail but succeeded", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PUT expected to fail but succeeded", err).Fatal()
 		return
 	}
 
 	if aerr, ok := err.(awserr.Error); ok {
 		if aerr.Code() != "BadRequest" && aerr.Message() != "BadRequest: Object tags cannot be greater than 10" {
-			failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to fail but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to fail but got %v", err), err).Fatal()
 			return
 		}
 	}
@@ -743,13 +601,13 @@ func testObjectTaggingErrors(s3Client *s3.S3) {
 
 	_, err = s3Client.PutObjectTagging(input)
 	if err == nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go PUT expected to fail but succeeded", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PUT expected to fail but succeeded", err).Fatal()
 		return
 	}
 
 	if aerr, ok := err.(awserr.Error); ok {
 		if aerr.Code() != "InvalidTag" && aerr.Message() != "InvalidTag: Cannot provide multiple Tags with the same key" {
-			failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to fail but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to fail but got %v", err), err).Fatal()
 			return
 		}
 	}
@@ -774,13 +632,13 @@ func testObjectTaggingErrors(s3Client *s3.S3) {
 
 	_, err = s3Client.PutObjectTagging(input)
 	if err == nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go PUT expected to fail but succeeded", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PUT expected to fail but succeeded", err).Fatal()
 		return
 	}
 
 	if aerr, ok := err.(awserr.Error); ok {
 		if aerr.Code() != "InvalidTag" && aerr.Message() != "InvalidTag: The TagKey you have provided is invalid" {
-			failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to fail but got %v", err), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to fail but got %v", err), err).Fatal()
 			return
 		}
 	}
@@ -805,75 +663,25 @@ func testObjectTaggingErrors(s3Client *s3.S3) {
 
 	_, err = s3Client.PutObjectTagging(input)
 	if err == nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go PUT expected to fail but succeeded", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PUT expected to fail but succeeded", err).Fatal()
 		return
 	}
 
 	if aerr, ok := err.(awserr.Error); ok {
 		if aerr.Code() != "InvalidTag" && aerr.Message() != "InvalidTag: The TagValue you have provided is invalid" {
-			failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to fail but got %v", err), err).Fatal()
-			return
-		}
-	}
-
-	successLogger(function, args, startTime).Info()
-}
-
-// Tests bucket re-create errors.
-func testCreateBucketError(s3Client *s3.S3) {
-	region := s3Client.Config.Region
-	// Amazon S3 returns error in all AWS Regions except in the North Virginia Region.
-	// More details in https://docs.aws.amazon.com/sdk-for-go/api/service/s3/#S3.CreateBucket
-	s3Client.Config.Region = aws.String("us-west-1")
-
-	// initialize logging params
-	startTime := time.Now()
-	function := "testMakeBucketError"
-	bucketName := randString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
-	args := map[string]interface{}{
-		"bucketName": bucketName,
-	}
-	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
-		Bucket: aws.String(bucketName),
-	})
-	if err != nil {
-		// InvalidRegion is a valid error if the endpoint doesn't support
-		// different 'regions', we simply skip this test in such scenarios.
-		if err.(s3.RequestFailure).Code() == "InvalidRegion" {
-			// Restore region in s3Client
-			s3Client.Config.Region = region
-			successLogger(function, args, startTime).Info()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to fail but got %v", err), err).Fatal()
 			return
 		}
-		failureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
-		return
-	}
-	defer cleanup(s3Client, bucketName, "", function, args, startTime, true)
-
-	_, errCreating := s3Client.CreateBucket(&s3.CreateBucketInput{
-		Bucket: aws.String(bucketName),
-	})
-	if errCreating == nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Should Return Error for Existing bucket", err).Fatal()
-		return
-	}
-	// Verify valid error response from server.
-	if errCreating.(s3.RequestFailure).Code() != "BucketAlreadyExists" &&
-		errCreating.(s3.RequestFailure).Code() != "BucketAlreadyOwnedByYou" {
-		failureLog(function, args, startTime, "", "Invalid error returned by server", err).Fatal()
-		return
 	}
 
-	// Restore region in s3Client
-	s3Client.Config.Region = region
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 func testListMultipartUploads(s3Client *s3.S3) {
 	startTime := time.Now()
 	function := "testListMultipartUploads"
-	bucket := randString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
-	object := randString(60, rand.NewSource(time.Now().UnixNano()), "")
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "")
 	args := map[string]interface{}{
 		"bucketName": bucket,
 		"objectName": object,
@@ -882,7 +690,7 @@ func testListMultipartUploads(s3Client *s3.S3) {
 		Bucket: aws.String(bucket),
 	})
 	if errCreating != nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", errCreating).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", errCreating).Fatal()
 		return
 	}
 	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
@@ -892,7 +700,7 @@ func testListMultipartUploads(s3Client *s3.S3) {
 		Key:    aws.String(object),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go createMultipartupload API failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go createMultipartupload API failed", err).Fatal()
 		return
 	}
 	parts := make([]*string, 5)
@@ -910,7 +718,7 @@ func testListMultipartUploads(s3Client *s3.S3) {
 				Key:      aws.String(object),
 				UploadId: multipartUpload.UploadId,
 			})
-			failureLog(function, args, startTime, "", "AWS SDK Go uploadPart API failed for", errUpload).Fatal()
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go uploadPart API failed for", errUpload).Fatal()
 			return
 		}
 		parts[i] = result.ETag
@@ -922,12 +730,12 @@ func testListMultipartUploads(s3Client *s3.S3) {
 		UploadId: multipartUpload.UploadId,
 	})
 	if errParts != nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go ListPartsInput API failed for", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListPartsInput API failed for", err).Fatal()
 		return
 	}
 
 	if len(parts) != len(listParts.Parts) {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListParts.Parts len mismatch want: %d got: %d", len(parts), len(listParts.Parts)), err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListParts.Parts len mismatch want: %d got: %d", len(parts), len(listParts.Parts)), err).Fatal()
 		return
 	}
 
@@ -935,7 +743,7 @@ func testListMultipartUploads(s3Client *s3.S3) {
 	for i, part := range listParts.Parts {
 		tag := parts[i]
 		if *tag != *part.ETag {
-			failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListParts.Parts output mismatch want: %#v got: %#v", tag, part.ETag), err).Fatal()
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListParts.Parts output mismatch want: %#v got: %#v", tag, part.ETag), err).Fatal()
 			return
 		}
 		completedParts[i] = &s3.CompletedPart{
@@ -953,12 +761,12 @@ func testListMultipartUploads(s3Client *s3.S3) {
 		UploadId: multipartUpload.UploadId,
 	})
 	if err == nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go CompleteMultipartUpload is expected to fail but succeeded", errors.New("expected nil")).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CompleteMultipartUpload is expected to fail but succeeded", errors.New("expected nil")).Fatal()
 		return
 	}
 
 	if err.(s3.RequestFailure).Code() != "EntityTooSmall" {
-		failureLog(function, args, startTime, "", "AWS SDK Go CompleteMultipartUpload is expected to fail with EntityTooSmall", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CompleteMultipartUpload is expected to fail with EntityTooSmall", err).Fatal()
 		return
 	}
 
@@ -973,7 +781,7 @@ func testListMultipartUploads(s3Client *s3.S3) {
 	}
 	listParts, err = s3Client.ListParts(lpInput)
 	if err == nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go ListPartsInput API (MaxParts < 0) failed for", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListPartsInput API (MaxParts < 0) failed for", err).Fatal()
 		return
 	}
 
@@ -981,19 +789,19 @@ func testListMultipartUploads(s3Client *s3.S3) {
 	lpInput.PartNumberMarker = aws.Int64(-1)
 	listParts, err = s3Client.ListParts(lpInput)
 	if err == nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go ListPartsInput API (PartNumberMarker < 0) failed for", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListPartsInput API (PartNumberMarker < 0) failed for", err).Fatal()
 		return
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 func testSSECopyObject(s3Client *s3.S3) {
 	// initialize logging params
 	startTime := time.Now()
 	function := "testSSECopyObjectSourceEncrypted"
-	bucketName := randString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
-	object := randString(60, rand.NewSource(time.Now().UnixNano()), "")
+	bucketName := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "")
 	args := map[string]interface{}{
 		"bucketName": bucketName,
 		"objectName": object,
@@ -1002,7 +810,7 @@ func testSSECopyObject(s3Client *s3.S3) {
 		Bucket: aws.String(bucketName),
 	})
 	if err != nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
 		return
 	}
 	defer cleanup(s3Client, bucketName, object+"-enc", function, args, startTime, true)
@@ -1019,7 +827,7 @@ func testSSECopyObject(s3Client *s3.S3) {
 		SSECustomerKey:       sseCustomerKey,
 	})
 	if errPutEnc != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to succeed but got %v", errPutEnc), errPutEnc).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to succeed but got %v", errPutEnc), errPutEnc).Fatal()
 		return
 	}
 
@@ -1032,12 +840,12 @@ func testSSECopyObject(s3Client *s3.S3) {
 		Key:                  aws.String(object + "-copy"),
 	})
 	if errCopyEnc == nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go CopyObject expected to fail, but it succeeds ", wrongSuccess).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CopyObject expected to fail, but it succeeds ", wrongSuccess).Fatal()
 		return
 	}
 	invalidSSECustomerAlgorithm := "Requests specifying Server Side Encryption with Customer provided keys must provide a valid encryption algorithm"
 	if !strings.Contains(errCopyEnc.Error(), invalidSSECustomerAlgorithm) {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CopyObject expected error %v got %v", invalidSSECustomerAlgorithm, errCopyEnc), errCopyEnc).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CopyObject expected error %v got %v", invalidSSECustomerAlgorithm, errCopyEnc), errCopyEnc).Fatal()
 		return
 	}
 
@@ -1048,7 +856,7 @@ func testSSECopyObject(s3Client *s3.S3) {
 		Key:    aws.String(object + "-noenc"),
 	})
 	if errPut != nil {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to succeed but got %v", errPut), errPut).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to succeed but got %v", errPut), errPut).Fatal()
 		return
 	}
 
@@ -1063,65 +871,122 @@ func testSSECopyObject(s3Client *s3.S3) {
 		Key:                            aws.String(object + "-copy"),
 	})
 	if errCopy == nil {
-		failureLog(function, args, startTime, "", "AWS SDK Go CopyObject expected to fail, but it succeeds ", wrongSuccess).Fatal()
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CopyObject expected to fail, but it succeeds ", wrongSuccess).Fatal()
 		return
 	}
 	invalidEncryptionParameters := "The encryption parameters are not applicable to this object."
 	if !strings.Contains(errCopy.Error(), invalidEncryptionParameters) {
-		failureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CopyObject expected error %v got %v", invalidEncryptionParameters, errCopy), errCopy).Fatal()
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CopyObject expected error %v got %v", invalidEncryptionParameters, errCopy), errCopy).Fatal()
 		return
 	}
 
-	successLogger(function, args, startTime).Info()
+	mintest.SuccessLogger(function, args, startTime).Info()
 }
 
 func main() {
-	endpoint := os.Getenv("SERVER_ENDPOINT")
-	accessKey := os.Getenv("ACCESS_KEY")
-	secretKey := os.Getenv("SECRET_KEY")
-	secure := os.Getenv("ENABLE_HTTPS")
-	if strings.HasSuffix(endpoint, ":443") {
-		endpoint = strings.ReplaceAll(endpoint, ":443", "")
-	}
-	if strings.HasSuffix(endpoint, ":80") {
-		endpoint = strings.ReplaceAll(endpoint, ":80", "")
+	runStartTime := time.Now()
+	mintest.Init("aws-sdk-go")
+	config := mintest.LoadConfig()
+	if strings.HasSuffix(config.Endpoint, ":443") {
+		config.Endpoint = strings.ReplaceAll(config.Endpoint, ":443", "")
 	}
-	sdkEndpoint := "http://" + endpoint
-	if secure == "1" {
-		sdkEndpoint = "https://" + endpoint
+	if strings.HasSuffix(config.Endpoint, ":80") {
+		config.Endpoint = strings.ReplaceAll(config.Endpoint, ":80", "")
 	}
 
-	creds := credentials.NewStaticCredentials(accessKey, secretKey, "")
-	newSession := session.New()
-	s3Config := &aws.Config{
-		Credentials:      creds,
-		Endpoint:         aws.String(sdkEndpoint),
-		Region:           aws.String("us-east-1"),
-		S3ForcePathStyle: aws.Bool(true),
+	// Create an S3 service object in the default region.
+	s3Client := config.NewS3Client()
+	trackRequestIDs(s3Client)
+	mintest.TrackBuckets(s3Client)
+	// Remove the buckets left behind, even by a failed test
+	defer mintest.StartJanitor(s3Client)()
+	// request IDs of the operations which failed during the test
+	mintest.SetFailureFields(func(startTime time.Time) log.Fields {
+		if records := failedRequestIDs(startTime); len(records) > 0 {
+			return log.Fields{"requestIDs": records}
+		}
+		return nil
+	})
+
+	// Features required by the tests are probed once, before the first one
+	capabilities := mintest.NewCapabilities(config, s3Client)
+	withClient := func(test func(*s3.S3)) func() {
+		return func() { test(s3Client) }
 	}
 
-	// Create an S3 service object in the default region.
-	s3Client := s3.New(newSession, s3Config)
-
-	// Output to stdout instead of the default stderr
-	log.SetOutput(os.Stdout)
-	// create custom formatter
-	mintFormatter := mintJSONFormatter{}
-	// set custom formatter
-	log.SetFormatter(&mintFormatter)
-	// log Info or above -- success cases are Info level, failures are Fatal level
-	log.SetLevel(log.InfoLevel)
 	// execute tests
-	testPresignedPutInvalidHash(s3Client)
-	testListObjects(s3Client)
-	testSelectObject(s3Client)
-	testCreateBucketError(s3Client)
-	testListMultipartUploads(s3Client)
-	if secure == "1" {
-		testSSECopyObject(s3Client)
-	}
-	if isObjectTaggingImplemented(s3Client) {
-		testObjectTagging(s3Client)
-		testObjectTaggingErrors(s3Client)
-	}
+	mintest.RunTests([]mintest.Test{
+		{Name: "testPresignedPutInvalidHash", Description: "Check a presigned PUT with a mismatching payload hash is rejected", Run: withClient(testPresignedPutInvalidHash)},
+		{Name: "testPresignedGetBurst", Description: "Generate thousands of presigned GET URLs concurrently and execute a sample", Run: withClient(testPresignedGetBurst)},
+		{Name: "testPresignedURLTampering", Description: "Check tampered presigned GET and PUT URLs are rejected", Run: withClient(testPresignedURLTampering)},
+		{Name: "testSignatureClockSkew", Description: "Sign and presign requests 10 and 20 minutes off, and check only the ones within the 15 minutes of skew allowed are accepted", Run: withClient(testSignatureClockSkew)},
+		{Name: "testGetObjectResponseOverrides", Description: "GET an object overriding its response headers", Run: withClient(testGetObjectResponseOverrides)},
+		{Name: "testListObjects", Description: "List objects with ListObjects and ListObjectsV2", Run: withClient(testListObjects)},
+		{Name: "testListingConsistency", Description: "Check ListObjectsV2, ListObjects and ListObjectVersions agree with the uploaded keys, sizes and ETags", Scoped: true, Run: withClient(testListingConsistency)},
+		{Name: "testListObjectsV2Continuation", Description: "List objects with MaxKeys 0, and with continuation tokens used on another bucket, another prefix or after deletions", Run: withClient(testListObjectsV2Continuation)},
+		{Name: "testObjectKeyNames", Description: "Store and list objects under keys needing escaping or at the length limit", Run: withClient(testObjectKeyNames)},
+		{Name: "testDirectoryObjects", Description: "Store, read, list and delete directory objects, keys ending in a slash, along with objects under their prefixes", Scoped: true, Run: withClient(testDirectoryObjects)},
+		{Name: "testSelectObject", Description: "Select the records of CSV and JSON objects", Requires: []string{mintest.RequiresSelect}, Run: withClient(testSelectObject)},
+		{Name: "testSelectObjectEvents", Description: "Check the progress, stats, end and error events of a select", Requires: []string{mintest.RequiresSelect}, Run: withClient(testSelectObjectEvents)},
+		{Name: "testSelectObjectCancel", Description: "Cancel half of concurrent select streams and check the others complete without leaks", Requires: []string{mintest.RequiresSelect}, Run: withClient(testSelectObjectCancel)},
+		{Name: "testCreateBucketError", Description: "Create buckets in each standard region, reject invalid regions and re-creating a bucket", Run: func() { testCreateBucketError(s3Client, config) }},
+		{Name: "testDeleteBucketNotEmpty", Description: "Check deleting a bucket holding an object or an upload fails with BucketNotEmpty", Run: withClient(testDeleteBucketNotEmpty)},
+		{Name: "testDeleteBucketForce", Description: "Delete a bucket along with its objects and uploads with x-minio-force-delete", Requires: []string{mintest.RequiresMinIO}, Run: withClient(testDeleteBucketForce)},
+		{Name: "testBucketNameAddressing", Description: "Use buckets with names at the edges of the naming rules in the configured addressing style", Run: func() { testBucketNameAddressing(newAddressedS3Client(config)) }},
+		{Name: "testInvalidBucketNames", Description: "Check buckets with invalid names are rejected with InvalidBucketName", Run: withClient(testInvalidBucketNames)},
+		{Name: "testListMultipartUploads", Description: "List multipart uploads", Run: withClient(testListMultipartUploads)},
+		{Name: "testCompleteMultipartUploadErrors", Description: "Check completing with a wrong ETag, parts out of order, a missing part, no parts or an aborted upload fails", Run: withClient(testCompleteMultipartUploadErrors)},
+		{Name: "testListPartsPaging", Description: "Page through 12 parts one at a time with ListParts, checking their sizes, ETags and CRC32C checksums", Run: withClient(testListPartsPaging)},
+		{Name: "testMultipartPartSizeBoundaries", Description: "Complete uploads with a part of exactly 5 MiB, an empty last part or a single part, and reject a part one byte under 5 MiB", Run: withClient(testMultipartPartSizeBoundaries)},
+		{Name: "testMultipartMaxParts", Description: "Upload, list and complete an object of 10000 parts, in full mode", Requires: []string{mintest.RequiresFull}, Run: withClient(testMultipartMaxParts)},
+		{Name: "testBucketEncryption", Description: "Encrypt objects by default with an AES256 bucket encryption rule", Run: func() { testBucketEncryption(s3Client, s3.ServerSideEncryptionAes256) }},
+		{Name: "testBucketEncryption", Description: "Encrypt objects by default with an aws:kms bucket encryption rule", Requires: []string{mintest.RequiresKMS}, Run: func() { testBucketEncryption(s3Client, s3.ServerSideEncryptionAwsKms) }},
+		{Name: "testObjectACL", Description: "Set and get the private canned ACL of an object", Requires: []string{mintest.RequiresACL}, Run: withClient(testObjectACL)},
+		{Name: "testObjectCannedACLs", Description: "Set and get the public-read and authenticated-read canned ACLs of objects", Requires: []string{mintest.RequiresACL}, Run: withClient(testObjectCannedACLs)},
+		{Name: "testOwnerConsistency", Description: "Check ListBuckets, GetBucketAcl, ListObjectsV2 with fetch-owner, ListObjects and GetObjectAcl agree on the owner", Requires: []string{mintest.RequiresACL}, Run: withClient(testOwnerConsistency)},
+		{Name: "testRestoreObjectNotArchived", Description: "Check restoring an object which is not archived is rejected", Run: withClient(testRestoreObjectNotArchived)},
+		{Name: "testRestoreArchivedObject", Description: "Restore an object of the archive storage class set in TIER_STORAGE_CLASS", Requires: []string{mintest.RequiresTier}, Run: withClient(testRestoreArchivedObject)},
+		{Name: "testStorageClass", Description: "Check the storage class of objects is reported by every API", Run: withClient(testStorageClass)},
+		{Name: "testLifecycleExpiry", Description: "Check lifecycle rules expire objects and transition them to the tier if any", Run: withClient(testLifecycleExpiry)},
+		{Name: "testLifecycleFilters", Description: "Round-trip lifecycle rules filtered by size, tags and prefix, and reject invalid rules", Run: withClient(testLifecycleFilters)},
+		{Name: "testObjectSizeConsistency", Description: "Check every API reporting the size of an object agrees on it", Run: withClient(testObjectSizeConsistency)},
+		{Name: "testGetObjectAttributesMultipart", Description: "Get the attributes and parts of a multipart object", Run: withClient(testGetObjectAttributesMultipart)},
+		{Name: "testPutObjectIfNoneMatch", Description: "Check PUTs and multipart completions with If-None-Match: * only create objects", Run: withClient(testPutObjectIfNoneMatch)},
+		{Name: "testAppendObject", Description: "Append chunks to an object at x-amz-write-offset-bytes, and reject wrong offsets", Run: withClient(testAppendObject)},
+		{Name: "testGetObjectRange", Description: "Read a multipart object by ranges and by part number", Run: withClient(testGetObjectRange)},
+		{Name: "testGetObjectRangeLarge", Description: "Read ranges at offsets of hundreds of MiB of a large object, in full mode", Requires: []string{mintest.RequiresFull}, Run: withClient(testGetObjectRangeLarge)},
+		{Name: "testStandardHeaders", Description: "Store Content-Encoding, Cache-Control, Content-Disposition, Content-Language and Expires, and copy them", Run: withClient(testStandardHeaders)},
+		{Name: "testUserMetadata", Description: "Store user metadata up to and over 2KiB, with UTF-8 values, duplicate headers and lower case keys", Run: withClient(testUserMetadata)},
+		{Name: "testLargeObjectStreaming", Description: "Stream objects of the sizes set in MINT_OBJECT_SIZES up and back", Run: func() { testLargeObjectStreaming(s3Client, config) }},
+		{Name: "testGetObjectThroughput", Description: "Read an object of 1 GiB back and fail when the throughput is below MINT_MIN_GET_MBPS", Requires: []string{mintest.RequiresGetFloor}, Run: withClient(testGetObjectThroughput)},
+		{Name: "testKeepAliveConnectionReuse", Description: "Check thousands of concurrent requests reuse keep-alive connections", Run: withClient(testKeepAliveConnectionReuse)},
+		{Name: "testProxyPassThrough", Description: "Send the requests of a client configured with a forward proxy through a local one, tunneled over HTTPS", Run: withClient(testProxyPassThrough)},
+		{Name: "testUnsignedPayload", Description: "Upload an object with UNSIGNED-PAYLOAD", Run: withClient(testUnsignedPayload)},
+		{Name: "testStreamingSignedPayload", Description: "Upload an object in signed chunks, and reject a corrupted chunk", Run: withClient(testStreamingSignedPayload)},
+		{Name: "testStreamingTrailerChecksum", Description: "Upload objects in chunks with a checksum trailer", Run: withClient(testStreamingTrailerChecksum)},
+		{Name: "testPutObjectChecksums", Description: "Upload objects with a checksum of each algorithm, and reject mismatching ones", Run: withClient(testPutObjectChecksums)},
+		{Name: "testMultipartChecksumType", Description: "Check the COMPOSITE and FULL_OBJECT checksums of multipart objects", Run: withClient(testMultipartChecksumType)},
+		{Name: "testChecksumHeaderValidation", Description: "Check requests with conflicting checksums are rejected", Run: withClient(testChecksumHeaderValidation)},
+		{Name: "testMalformedConfigBodies", Description: "Check malformed, wrongly rooted and 8 MiB XML bodies of bucket configurations and DeleteObjects get a 4xx error", Run: withClient(testMalformedConfigBodies)},
+		{Name: "testContentMD5Enforcement", Description: "Check DeleteObjects, bucket tagging and lifecycle requests without a valid Content-MD5 are rejected, and the SDK sends it", Run: withClient(testContentMD5Enforcement)},
+		{Name: "testBucketNotification", Description: "Set and remove a notification configuration sending to NOTIFY_ARN", Requires: []string{mintest.RequiresNotification}, Run: withClient(testBucketNotification)},
+		{Name: "testBucketNotificationErrors", Description: "Check notification configurations with invalid ARNs are rejected", Run: withClient(testBucketNotificationErrors)},
+		{Name: "testListenBucketNotification", Description: "Listen to the events of a bucket with the MinIO listen API", Requires: []string{mintest.RequiresMinIO}, Run: withClient(testListenBucketNotification)},
+		{Name: "testSSECopyObject", Description: "Check copying an unencrypted object with SSE-C source keys is rejected", Requires: []string{mintest.RequiresHTTPS}, Run: withClient(testSSECopyObject)},
+		{Name: "testSSECLifecycle", Description: "PUT, GET, HEAD and copy SSE-C encrypted objects", Requires: []string{mintest.RequiresHTTPS}, Run: withClient(testSSECLifecycle)},
+		{Name: "testETagSemantics", Description: "Check the ETags of single-part, multipart and copied objects against their MD5 sums", Scoped: true, Run: withClient(testETagSemantics)},
+		{Name: "testETagSSEC", Description: "Check SSE-C encrypted objects do not have the MD5 sum of their content as ETag", Requires: []string{mintest.RequiresHTTPS}, Scoped: true, Run: withClient(testETagSSEC)},
+		{Name: "testETagSSEKMS", Description: "Check SSE-KMS encrypted objects do not have the MD5 sum of their content as ETag", Requires: []string{mintest.RequiresKMS}, Scoped: true, Run: withClient(testETagSSEKMS)},
+		{Name: "testUploadPartCopySSEC", Description: "Assemble an SSE-C encrypted multipart object from an SSE-C encrypted source", Requires: []string{mintest.RequiresHTTPS}, Run: withClient(testUploadPartCopySSEC)},
+		{Name: "testGetObjectRangeSSEC", Description: "Read an SSE-C encrypted multipart object by ranges and by part number", Requires: []string{mintest.RequiresHTTPS}, Run: withClient(testGetObjectRangeSSEC)},
+		{Name: "testGetObjectAttributesSSEC", Description: "Get the attributes of an SSE-C encrypted multipart object", Requires: []string{mintest.RequiresHTTPS}, Run: withClient(testGetObjectAttributesSSEC)},
+		{Name: "testObjectTagging", Description: "Set and get the tags of an object", Requires: []string{mintest.RequiresObjectTagging}, Run: withClient(testObjectTagging)},
+		{Name: "testObjectTaggingErrors", Description: "Check invalid object tags are rejected", Requires: []string{mintest.RequiresObjectTagging}, Run: withClient(testObjectTaggingErrors)},
+		{Name: "testObjectTaggingHeader", Description: "Tag objects through the x-amz-tagging header", Requires: []string{mintest.RequiresObjectTagging}, Run: withClient(testObjectTaggingHeader)},
+		{Name: "testExpectedBucketOwner", Description: "Send PUT, GET, list and DELETE requests expecting the wrong and the right bucket owner", Run: withClient(testExpectedBucketOwner)},
+		{Name: "testTLSHandshake", Description: "Check the TLS handshake with the configured CA, client certificate and verification", Requires: []string{mintest.RequiresHTTPS}, Run: withClient(testTLSHandshake)},
+		{Name: "testRequestIDs", Description: "Check every response carries a unique well formed request ID", Run: withClient(testRequestIDs)},
+		{Name: "benchmarkListObjects", Description: "Time listing MINT_BENCH_LIST_OBJECTS objects page by page and check their order in benchmark mode", Requires: []string{mintest.RequiresBenchmark}, Run: withClient(benchmarkListObjects)},
+	}, capabilities.Supports)
+	mintest.TestResourceLeaks(s3Client, bucketPrefix, runStartTime)
 }
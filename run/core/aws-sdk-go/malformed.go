@@ -0,0 +1,177 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+const (
+	// Size of the bodies sent over the limits of the configuration APIs
+	oversizedBodySize = 8 << 20
+	// Time the server is given to answer a malformed request
+	malformedTimeout = 2 * time.Minute
+)
+
+// sendRawBody sends req with body in place of the one of its input,
+// along with its Content-MD5, and returns the status of the answer
+func sendRawBody(req *request.Request, body string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), malformedTimeout)
+	defer cancel()
+	req.SetContext(ctx)
+	req.Handlers.Build.PushBack(func(r *request.Request) {
+		r.SetStringBody(body)
+		sum := md5.Sum([]byte(body))
+		r.HTTPRequest.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+	})
+	err := req.Send()
+	if req.HTTPResponse == nil {
+		return 0, err
+	}
+	return req.HTTPResponse.StatusCode, err
+}
+
+// Send malformed XML, XML of the wrong root element and bodies of 8 MiB
+// to PutBucketTagging, PutBucketVersioning, PutBucketLifecycleConfiguration
+// and DeleteObjects. The first two must be rejected with MalformedXML, the
+// last one with a 4xx error, within 2 minutes and without taking the
+// server down.
+func testMalformedConfigBodies(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testMalformedConfigBodies"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	args := map[string]interface{}{
+		"bucketName": bucket,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	// Errors are not retried, a 5xx must not be hidden by a retry
+	noRetryClient := s3.New(session.New(), s3Client.Config.Copy().WithMaxRetries(0))
+
+	operations := []struct {
+		name    string
+		request func() *request.Request
+		root    string // root element of the body
+		element string // element repeated to make the body oversized
+	}{
+		{"PutBucketTagging", func() *request.Request {
+			req, _ := noRetryClient.PutBucketTaggingRequest(&s3.PutBucketTaggingInput{
+				Bucket:  aws.String(bucket),
+				Tagging: &s3.Tagging{TagSet: []*s3.Tag{}},
+			})
+			return req
+		}, "Tagging", "<Tag><Key>key</Key><Value>value</Value></Tag>"},
+		{"PutBucketVersioning", func() *request.Request {
+			req, _ := noRetryClient.PutBucketVersioningRequest(&s3.PutBucketVersioningInput{
+				Bucket:                  aws.String(bucket),
+				VersioningConfiguration: &s3.VersioningConfiguration{},
+			})
+			return req
+		}, "VersioningConfiguration", "<Status>Enabled</Status>"},
+		{"PutBucketLifecycleConfiguration", func() *request.Request {
+			req, _ := noRetryClient.PutBucketLifecycleConfigurationRequest(&s3.PutBucketLifecycleConfigurationInput{
+				Bucket:                 aws.String(bucket),
+				LifecycleConfiguration: &s3.BucketLifecycleConfiguration{},
+			})
+			return req
+		}, "LifecycleConfiguration", "<Rule><Status>Enabled</Status><Filter><Prefix>p</Prefix></Filter><Expiration><Days>1</Days></Expiration></Rule>"},
+		{"DeleteObjects", func() *request.Request {
+			req, _ := noRetryClient.DeleteObjectsRequest(&s3.DeleteObjectsInput{
+				Bucket: aws.String(bucket),
+				Delete: &s3.Delete{Objects: []*s3.ObjectIdentifier{}},
+			})
+			return req
+		}, "Delete", "<Object><Key>key</Key></Object>"},
+	}
+
+	for _, operation := range operations {
+		args["operation"] = operation.name
+		open, end := "<"+operation.root+">", "</"+operation.root+">"
+		oversized := open + strings.Repeat(operation.element, oversizedBodySize/len(operation.element)+1) + end
+		for _, testCase := range []struct {
+			name       string
+			body       string
+			expectCode string
+		}{
+			{"malformed", open + operation.element[:len(operation.element)/2], "MalformedXML"},
+			{"wrongRoot", "<WrongRoot>" + operation.element + "</WrongRoot>", "MalformedXML"},
+			{"oversized", oversized, ""},
+		} {
+			args["body"] = testCase.name
+			status, err := sendRawBody(operation.request(), testCase.body)
+			if errors.Is(err, context.DeadlineExceeded) || status == 0 {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("%s with a %s body got no answer within %v", operation.name, testCase.name, malformedTimeout), err).Fatal()
+				return
+			}
+			if status < http.StatusBadRequest || status >= http.StatusInternalServerError {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("%s with a %s body expected a 4xx error but got %d", operation.name, testCase.name, status), err).Fatal()
+				return
+			}
+			if testCase.expectCode != "" && !mintest.IsErrorCode(err, testCase.expectCode) {
+				code := ""
+				if aerr, ok := err.(awserr.Error); ok {
+					code = aerr.Code()
+				}
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("%s with a %s body expected %s but got %s", operation.name, testCase.name, testCase.expectCode, code), err).Fatal()
+				return
+			}
+		}
+	}
+	delete(args, "operation")
+	delete(args, "body")
+
+	// The server still answers
+	_, err = s3Client.HeadBucket(&s3.HeadBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadBucket after the malformed requests Failed", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
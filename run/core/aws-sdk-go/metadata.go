@@ -0,0 +1,189 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Limit of the size of the user metadata of an object, the keys and
+// values added up
+const maxUserMetadataSize = 2 * 1024
+
+// metadataValue returns the value of the user metadata key, looked up
+// regardless of case
+func metadataValue(metadata map[string]*string, key string) (string, bool) {
+	for k, v := range metadata {
+		if strings.EqualFold(k, key) {
+			return aws.StringValue(v), true
+		}
+	}
+	return "", false
+}
+
+// Separators of the values of duplicate headers, combined into one
+var combinedValues = regexp.MustCompile(`^first, ?second$`)
+
+// Store user metadata at the edges of what S3 accepts: metadata up to
+// 2KiB must be stored and over it rejected with MetadataTooLarge, UTF-8
+// values must read back either as sent or RFC 2047 encoded, unless they
+// are rejected with a 400, duplicate headers must be combined into a
+// single value, and keys sent in lower case must read back whatever
+// their case.
+func testUserMetadata(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testUserMetadata"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	args := map[string]interface{}{
+		"bucketName": bucket,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	// put stores object with metadata, and the extra headers set by build
+	put := func(object string, metadata map[string]*string, build func(r *request.Request)) error {
+		req, _ := s3Client.PutObjectRequest(&s3.PutObjectInput{
+			Body:     aws.ReadSeekCloser(strings.NewReader("metadata")),
+			Bucket:   aws.String(bucket),
+			Key:      aws.String(object),
+			Metadata: metadata,
+		})
+		if build != nil {
+			req.Handlers.Build.PushBack(build)
+		}
+		return req.Send()
+	}
+	head := func(object string) (map[string]*string, error) {
+		output, err := s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return output.Metadata, nil
+	}
+
+	// Metadata within the limit, even counting the x-amz-meta- prefix of
+	// its key, is stored, and over it rejected
+	withinValue := strings.Repeat("w", maxUserMetadataSize-len("within")-16)
+	args["objectName"] = "within-limit"
+	if err = put("within-limit", map[string]*string{"within": aws.String(withinValue)}, nil); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PUT with metadata within 2KiB expected to succeed", err).Fatal()
+		return
+	}
+	metadata, err := head("within-limit")
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HEAD Failed", err).Fatal()
+		return
+	}
+	if value, _ := metadataValue(metadata, "within"); value != withinValue {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HEAD expected metadata of %d bytes but got %d", len(withinValue), len(value)), nil).Fatal()
+		return
+	}
+	over := make(map[string]*string)
+	for i := 0; i < 3; i++ {
+		over[fmt.Sprintf("over-%d", i)] = aws.String(strings.Repeat("o", maxUserMetadataSize/2))
+	}
+	args["objectName"] = "over-limit"
+	if err = put("over-limit", over, nil); !mintest.IsErrorCode(err, "MetadataTooLarge") {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PUT with metadata over 2KiB expected to fail with MetadataTooLarge", err).Fatal()
+		return
+	}
+
+	// UTF-8 values read back as sent or RFC 2047 encoded, or are rejected
+	utf8Value := "naïve café, 日本語"
+	args["objectName"] = "utf8"
+	err = put("utf8", map[string]*string{"utf8": aws.String(utf8Value)}, nil)
+	if rerr, ok := err.(awserr.RequestFailure); ok && rerr.StatusCode() == http.StatusBadRequest {
+		args["utf8"] = "rejected"
+	} else if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PUT with a UTF-8 metadata value expected to succeed or be rejected with a 400", err).Fatal()
+		return
+	} else {
+		metadata, err = head("utf8")
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HEAD Failed", err).Fatal()
+			return
+		}
+		value, _ := metadataValue(metadata, "utf8")
+		decoded, derr := new(mime.WordDecoder).DecodeHeader(value)
+		if derr != nil || decoded != utf8Value {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HEAD expected UTF-8 metadata %q but got %q", utf8Value, value), derr).Fatal()
+			return
+		}
+		args["utf8"] = "round-tripped"
+		if decoded != value {
+			args["utf8"] = "RFC 2047 encoded"
+		}
+	}
+
+	// Duplicate headers are combined, and keys found regardless of case
+	args["objectName"] = "headers"
+	err = put("headers", nil, func(r *request.Request) {
+		r.HTTPRequest.Header.Add("X-Amz-Meta-Duplicate", "first")
+		r.HTTPRequest.Header.Add("X-Amz-Meta-Duplicate", "second")
+		// Left as is rather than canonicalized by Header.Set
+		r.HTTPRequest.Header["x-amz-meta-lower-case-key"] = []string{"lower"}
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PUT with duplicate and lower case metadata headers expected to succeed", err).Fatal()
+		return
+	}
+	metadata, err = head("headers")
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HEAD Failed", err).Fatal()
+		return
+	}
+	if value, _ := metadataValue(metadata, "duplicate"); !combinedValues.MatchString(value) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HEAD expected the duplicate metadata headers combined as \"first,second\" but got %q", value), nil).Fatal()
+		return
+	}
+	if value, ok := metadataValue(metadata, "Lower-Case-Key"); !ok || value != "lower" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HEAD expected metadata Lower-Case-Key regardless of case but got %v", aws.StringValueMap(metadata)), nil).Fatal()
+		return
+	}
+	delete(args, "objectName")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
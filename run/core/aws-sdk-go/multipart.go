@@ -0,0 +1,661 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Upload 12 parts of different sizes, without checksums and then with
+// CRC32C checksums, and page through them with ListParts one part at a
+// time, continuing from PartNumberMarker. Each page must hold the next
+// part, with the size, ETag and checksum UploadPart returned for it.
+func testListPartsPaging(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testListPartsPaging"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	numParts := 12
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"numParts":   numParts,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	crc32c := findChecksumAlgorithm("CRC32C")
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for _, withChecksum := range []bool{false, true} {
+		args["checksumAlgorithm"] = ""
+		input := &s3.CreateMultipartUploadInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		}
+		if withChecksum {
+			args["checksumAlgorithm"] = crc32c.name
+			input.ChecksumAlgorithm = aws.String(crc32c.name)
+		}
+		upload, err := s3Client.CreateMultipartUpload(input)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateMultipartUpload Failed", err).Fatal()
+			return
+		}
+		args["uploadId"] = aws.StringValue(upload.UploadId)
+		abort := func() {
+			s3Client.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+				Bucket:   aws.String(bucket),
+				Key:      aws.String(object),
+				UploadId: upload.UploadId,
+			})
+		}
+
+		// Parts listed are expected as UploadPart returned them
+		var expected []*s3.Part
+		for i := 1; i <= numParts; i++ {
+			part := make([]byte, 1024+i*100)
+			random.Read(part)
+			input := &s3.UploadPartInput{
+				Body:       bytes.NewReader(part),
+				Bucket:     aws.String(bucket),
+				Key:        aws.String(object),
+				PartNumber: aws.Int64(int64(i)),
+				UploadId:   upload.UploadId,
+			}
+			if withChecksum {
+				input.ChecksumCRC32C = aws.String(crc32c.checksum(part))
+			}
+			output, err := s3Client.UploadPart(input)
+			if err != nil {
+				abort()
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go UploadPart %d Failed", i), err).Fatal()
+				return
+			}
+			if withChecksum && aws.StringValue(output.ChecksumCRC32C) != aws.StringValue(input.ChecksumCRC32C) {
+				abort()
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go UploadPart %d expected CRC32C %s but got %q",
+					i, aws.StringValue(input.ChecksumCRC32C), aws.StringValue(output.ChecksumCRC32C)), nil).Fatal()
+				return
+			}
+			expected = append(expected, &s3.Part{
+				PartNumber:     aws.Int64(int64(i)),
+				ETag:           output.ETag,
+				Size:           aws.Int64(int64(len(part))),
+				ChecksumCRC32C: output.ChecksumCRC32C,
+			})
+		}
+
+		var marker *int64
+		for i, want := range expected {
+			output, err := s3Client.ListParts(&s3.ListPartsInput{
+				Bucket:           aws.String(bucket),
+				Key:              aws.String(object),
+				MaxParts:         aws.Int64(1),
+				PartNumberMarker: marker,
+				UploadId:         upload.UploadId,
+			})
+			if err != nil {
+				abort()
+				mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListParts Failed", err).Fatal()
+				return
+			}
+			last := i == len(expected)-1
+			if len(output.Parts) != 1 || aws.BoolValue(output.IsTruncated) == last {
+				abort()
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListParts with MaxParts 1 after part %d expected part %d, truncated %v, but got %d parts, truncated %v",
+					aws.Int64Value(marker), i+1, !last, len(output.Parts), aws.BoolValue(output.IsTruncated)), nil).Fatal()
+				return
+			}
+			got := output.Parts[0]
+			if aws.Int64Value(got.PartNumber) != aws.Int64Value(want.PartNumber) ||
+				aws.StringValue(got.ETag) != aws.StringValue(want.ETag) ||
+				aws.Int64Value(got.Size) != aws.Int64Value(want.Size) ||
+				aws.StringValue(got.ChecksumCRC32C) != aws.StringValue(want.ChecksumCRC32C) {
+				abort()
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListParts expected part %v but got %v", want, got), nil).Fatal()
+				return
+			}
+			if !last && aws.Int64Value(output.NextPartNumberMarker) != aws.Int64Value(got.PartNumber) {
+				abort()
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListParts expected NextPartNumberMarker %d but got %d",
+					aws.Int64Value(got.PartNumber), aws.Int64Value(output.NextPartNumberMarker)), nil).Fatal()
+				return
+			}
+			marker = output.NextPartNumberMarker
+		}
+		abort()
+	}
+	delete(args, "checksumAlgorithm")
+	delete(args, "uploadId")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Complete multipart uploads at the edges of the part size limits: a part
+// of exactly the minimum of 5 MiB followed by a last part of 1 byte or of
+// none, and a single small part. GET must return every byte uploaded. A
+// part one byte under 5 MiB which is not the last one must be rejected
+// with EntityTooSmall.
+func testMultipartPartSizeBoundaries(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testMultipartPartSizeBoundaries"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	minPartSize := 5 << 20
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+	// uploadParts uploads parts of the given sizes and completes the
+	// upload, returning the content uploaded
+	uploadParts := func(sizes []int) ([]byte, error) {
+		upload, err := s3Client.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer s3Client.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bucket),
+			Key:      aws.String(object),
+			UploadId: upload.UploadId,
+		})
+		var content []byte
+		var parts []*s3.CompletedPart
+		for i, size := range sizes {
+			part := make([]byte, size)
+			random.Read(part)
+			output, err := s3Client.UploadPart(&s3.UploadPartInput{
+				Body:       bytes.NewReader(part),
+				Bucket:     aws.String(bucket),
+				Key:        aws.String(object),
+				PartNumber: aws.Int64(int64(i + 1)),
+				UploadId:   upload.UploadId,
+			})
+			if err != nil {
+				return nil, err
+			}
+			content = append(content, part...)
+			parts = append(parts, &s3.CompletedPart{ETag: output.ETag, PartNumber: aws.Int64(int64(i + 1))})
+		}
+		_, err = s3Client.CompleteMultipartUpload(&s3.CompleteMultipartUploadInput{
+			Bucket:          aws.String(bucket),
+			Key:             aws.String(object),
+			MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+			UploadId:        upload.UploadId,
+		})
+		return content, err
+	}
+
+	for _, sizes := range [][]int{
+		{minPartSize, 1},
+		{minPartSize, 0},
+		{1024},
+	} {
+		args["partSizes"] = sizes
+		content, err := uploadParts(sizes)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go multipart upload of parts of %v bytes Failed", sizes), err).Fatal()
+			return
+		}
+		got, err := getObjectContent(s3Client, bucket, object)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject Failed", err).Fatal()
+			return
+		}
+		if !bytes.Equal(got, content) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject expected the %d bytes uploaded but got %d bytes", len(content), len(got)), nil).Fatal()
+			return
+		}
+	}
+
+	sizes := []int{minPartSize - 1, 1}
+	args["partSizes"] = sizes
+	_, err = uploadParts(sizes)
+	if !mintest.IsErrorCode(err, "EntityTooSmall") {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CompleteMultipartUpload with a part one byte under 5 MiB expected to fail with EntityTooSmall", err).Fatal()
+		return
+	}
+	delete(args, "partSizes")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Upload an object in the maximum of 10000 parts, all of the minimum size
+// of 5 MiB but the last one, from concurrent workers. Page through the parts
+// with ListParts, 1000 at a time, the most a page may hold, and around the
+// last part, then complete the upload and check the ETag of the object
+// combines the ETags of all the parts, and its content is the one uploaded.
+// A part number past the maximum must be rejected. Uploading close to
+// 50 GiB, it only runs in full mode.
+func testMultipartMaxParts(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testMultipartMaxParts"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	const (
+		maxParts     = 10000
+		minPartSize  = 5 * 1024 * 1024
+		lastPartSize = 1024 * 1024
+		maxListParts = 1000
+		workers      = 16
+	)
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"parts":      maxParts,
+		"partSize":   minPartSize,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	seed := mintest.DataSeed()
+	args["seed"] = seed
+	// Each part is generated out of its own seed, so that any of them can be
+	// uploaded on its own
+	part := func(partNumber int) *mintest.DataReader {
+		size := int64(minPartSize)
+		if partNumber == maxParts {
+			size = lastPartSize
+		}
+		return mintest.NewDataReader(seed+uint64(partNumber), size)
+	}
+
+	upload, err := s3Client.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateMultipartUpload Failed", err).Fatal()
+		return
+	}
+	args["uploadId"] = aws.StringValue(upload.UploadId)
+
+	etags := make([]string, maxParts+1)
+	errs := make([]error, maxParts+1)
+	partNumbers := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for partNumber := range partNumbers {
+				output, err := s3Client.UploadPart(&s3.UploadPartInput{
+					Body:       part(partNumber),
+					Bucket:     aws.String(bucket),
+					Key:        aws.String(object),
+					PartNumber: aws.Int64(int64(partNumber)),
+					UploadId:   upload.UploadId,
+				})
+				if err != nil {
+					errs[partNumber] = err
+					continue
+				}
+				etags[partNumber] = aws.StringValue(output.ETag)
+			}
+		}()
+	}
+	for partNumber := 1; partNumber <= maxParts; partNumber++ {
+		partNumbers <- partNumber
+	}
+	close(partNumbers)
+	wg.Wait()
+	for partNumber, err := range errs {
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go UploadPart of part %d Failed", partNumber), err).Fatal()
+			return
+		}
+	}
+
+	_, err = s3Client.UploadPart(&s3.UploadPartInput{
+		Body:       part(maxParts),
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(object),
+		PartNumber: aws.Int64(maxParts + 1),
+		UploadId:   upload.UploadId,
+	})
+	if err == nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go UploadPart of part %d expected to fail but succeeded", maxParts+1), errors.New("part number past the maximum accepted")).Fatal()
+		return
+	}
+
+	// Every page must be full and hold the next parts in order
+	input := &s3.ListPartsInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(object),
+		UploadId: upload.UploadId,
+		MaxParts: aws.Int64(maxListParts),
+	}
+	for page, next := 1, 1; ; page++ {
+		if page > maxParts/maxListParts {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListParts expected %d pages but does not stop paging", maxParts/maxListParts), nil).Fatal()
+			return
+		}
+		output, err := s3Client.ListParts(input)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListParts of page %d Failed", page), err).Fatal()
+			return
+		}
+		if len(output.Parts) != maxListParts {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListParts expected %d parts on page %d but got %d", maxListParts, page, len(output.Parts)), nil).Fatal()
+			return
+		}
+		for _, p := range output.Parts {
+			if partNumber := aws.Int64Value(p.PartNumber); partNumber != int64(next) || aws.StringValue(p.ETag) != etags[next] || aws.Int64Value(p.Size) != part(next).Size() {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListParts expected part %d with ETag %s and size %d but got part %d with ETag %s and size %d",
+					next, etags[next], part(next).Size(), partNumber, aws.StringValue(p.ETag), aws.Int64Value(p.Size)), nil).Fatal()
+				return
+			}
+			next++
+		}
+		if truncated := aws.BoolValue(output.IsTruncated); truncated != (next <= maxParts) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListParts expected IsTruncated %v on page %d but got %v", next <= maxParts, page, truncated), nil).Fatal()
+			return
+		}
+		if next > maxParts {
+			break
+		}
+		input.PartNumberMarker = output.NextPartNumberMarker
+	}
+
+	// Around the last part
+	for marker, expected := range map[int64]int{maxParts - 1: 1, maxParts: 0} {
+		output, err := s3Client.ListParts(&s3.ListPartsInput{
+			Bucket:           aws.String(bucket),
+			Key:              aws.String(object),
+			UploadId:         upload.UploadId,
+			PartNumberMarker: aws.Int64(marker),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListParts after part %d Failed", marker), err).Fatal()
+			return
+		}
+		if len(output.Parts) != expected || aws.BoolValue(output.IsTruncated) ||
+			(expected == 1 && aws.Int64Value(output.Parts[0].PartNumber) != maxParts) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListParts after part %d expected %d parts, not truncated, but got %v", marker, expected, output.Parts), nil).Fatal()
+			return
+		}
+	}
+
+	completed := make([]*s3.CompletedPart, maxParts)
+	partsMD5 := md5.New()
+	for partNumber := 1; partNumber <= maxParts; partNumber++ {
+		completed[partNumber-1] = &s3.CompletedPart{ETag: aws.String(etags[partNumber]), PartNumber: aws.Int64(int64(partNumber))}
+		sum, err := hex.DecodeString(strings.Trim(etags[partNumber], `"`))
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go UploadPart returned ETag %s, not an MD5, for part %d", etags[partNumber], partNumber), err).Fatal()
+			return
+		}
+		partsMD5.Write(sum)
+	}
+	output, err := s3Client.CompleteMultipartUpload(&s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(object),
+		UploadId:        upload.UploadId,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: completed},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CompleteMultipartUpload Failed", err).Fatal()
+		return
+	}
+	expectedETag := fmt.Sprintf(`"%s-%d"`, hex.EncodeToString(partsMD5.Sum(nil)), maxParts)
+	if etag := aws.StringValue(output.ETag); etag != expectedETag {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CompleteMultipartUpload expected ETag %s but got %s", expectedETag, etag), nil).Fatal()
+		return
+	}
+
+	head, err := s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(object),
+		PartNumber: aws.Int64(maxParts),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject of part %d Failed", maxParts), err).Fatal()
+		return
+	}
+	if aws.Int64Value(head.PartsCount) != maxParts || aws.Int64Value(head.ContentLength) != lastPartSize {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject of part %d expected %d parts and %d bytes but got %d and %d",
+			maxParts, maxParts, lastPartSize, aws.Int64Value(head.PartsCount), aws.Int64Value(head.ContentLength)), nil).Fatal()
+		return
+	}
+
+	parts := make([]io.Reader, maxParts)
+	for partNumber := 1; partNumber <= maxParts; partNumber++ {
+		parts[partNumber-1] = part(partNumber)
+	}
+	expectedHash, size, err := hashReader(io.MultiReader(parts...))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Hashing the uploaded content failed", err).Fatal()
+		return
+	}
+	getOutput, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject Failed", err).Fatal()
+		return
+	}
+	downloadHash, n, err := hashReader(getOutput.Body)
+	getOutput.Body.Close()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject reading body failed", err).Fatal()
+		return
+	}
+	if n != size || downloadHash != expectedHash {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject returned %d bytes with SHA256 %s, expected %d bytes with SHA256 %s",
+			n, downloadHash, size, expectedHash), errors.New("download content mismatch")).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Complete a multipart upload of two parts with invalid part lists: a
+// wrong ETag, parts out of order, a part which was never uploaded and no
+// parts at all. Each attempt must fail with its own error and leave the
+// upload intact, which must then complete with the right part list.
+// Completing an aborted upload must fail with NoSuchUpload.
+func testCompleteMultipartUploadErrors(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testCompleteMultipartUploadErrors"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	seed := mintest.DataSeed()
+	sizes := []int64{5 * 1024 * 1024, 1024}
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"seed":       seed,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	upload, err := s3Client.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateMultipartUpload Failed", err).Fatal()
+		return
+	}
+	args["uploadId"] = aws.StringValue(upload.UploadId)
+
+	var parts []*s3.CompletedPart
+	for i, size := range sizes {
+		output, err := s3Client.UploadPart(&s3.UploadPartInput{
+			Body:       mintest.NewDataReader(seed+uint64(i), size),
+			Bucket:     aws.String(bucket),
+			Key:        aws.String(object),
+			PartNumber: aws.Int64(int64(i + 1)),
+			UploadId:   upload.UploadId,
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go UploadPart Failed", err).Fatal()
+			return
+		}
+		parts = append(parts, &s3.CompletedPart{ETag: output.ETag, PartNumber: aws.Int64(int64(i + 1))})
+	}
+	complete := func(uploadID *string, parts []*s3.CompletedPart) error {
+		_, err := s3Client.CompleteMultipartUpload(&s3.CompleteMultipartUploadInput{
+			Bucket:          aws.String(bucket),
+			Key:             aws.String(object),
+			MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+			UploadId:        uploadID,
+		})
+		return err
+	}
+
+	testCases := []struct {
+		name  string
+		parts []*s3.CompletedPart
+		codes []string
+	}{
+		{"wrong ETag", []*s3.CompletedPart{
+			{ETag: aws.String(`"` + strings.Repeat("0", 32) + `"`), PartNumber: aws.Int64(1)},
+			parts[1],
+		}, []string{"InvalidPart"}},
+		{"parts out of order", []*s3.CompletedPart{parts[1], parts[0]}, []string{"InvalidPartOrder"}},
+		{"part never uploaded", []*s3.CompletedPart{
+			parts[0],
+			parts[1],
+			{ETag: parts[1].ETag, PartNumber: aws.Int64(3)},
+		}, []string{"InvalidPart"}},
+		// AWS answers MalformedXML, others InvalidRequest
+		{"no parts", []*s3.CompletedPart{}, []string{"MalformedXML", "InvalidRequest"}},
+	}
+	for _, testCase := range testCases {
+		args["case"] = testCase.name
+		err = complete(upload.UploadId, testCase.parts)
+		aerr, ok := err.(awserr.Error)
+		matched := false
+		for _, code := range testCase.codes {
+			matched = matched || (ok && aerr.Code() == code)
+		}
+		if !matched {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CompleteMultipartUpload with %s expected to fail with %s but got %v",
+				testCase.name, strings.Join(testCase.codes, " or "), err), err).Fatal()
+			return
+		}
+	}
+	delete(args, "case")
+
+	if err = complete(upload.UploadId, parts); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CompleteMultipartUpload after failed attempts Failed", err).Fatal()
+		return
+	}
+	head, err := s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadObject Failed", err).Fatal()
+		return
+	}
+	if expected := sizes[0] + sizes[1]; aws.Int64Value(head.ContentLength) != expected {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject expected %d bytes but got %d", expected, aws.Int64Value(head.ContentLength)), nil).Fatal()
+		return
+	}
+
+	aborted, err := s3Client.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateMultipartUpload Failed", err).Fatal()
+		return
+	}
+	args["abortedUploadId"] = aws.StringValue(aborted.UploadId)
+	_, err = s3Client.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(object),
+		UploadId: aborted.UploadId,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go AbortMultipartUpload Failed", err).Fatal()
+		return
+	}
+	err = complete(aborted.UploadId, parts)
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != s3.ErrCodeNoSuchUpload {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go CompleteMultipartUpload of an aborted upload expected NoSuchUpload but got %v", err), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,241 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// notificationConfiguration returns a configuration sending the given
+// events for keys matching prefix and suffix to arn, as a queue, topic or
+// lambda function configuration depending on the service of the ARN.
+func notificationConfiguration(arn, id, prefix, suffix string, events ...string) *s3.NotificationConfiguration {
+	filter := &s3.NotificationConfigurationFilter{
+		Key: &s3.KeyFilter{
+			FilterRules: []*s3.FilterRule{
+				{Name: aws.String(s3.FilterRuleNamePrefix), Value: aws.String(prefix)},
+				{Name: aws.String(s3.FilterRuleNameSuffix), Value: aws.String(suffix)},
+			},
+		},
+	}
+	config := &s3.NotificationConfiguration{}
+	// arn:partition:service:region:account-id:resource
+	switch fields := strings.SplitN(arn, ":", 6); {
+	case len(fields) > 2 && fields[2] == "sns":
+		config.TopicConfigurations = []*s3.TopicConfiguration{
+			{Id: aws.String(id), TopicArn: aws.String(arn), Events: aws.StringSlice(events), Filter: filter},
+		}
+	case len(fields) > 2 && fields[2] == "lambda":
+		config.LambdaFunctionConfigurations = []*s3.LambdaFunctionConfiguration{
+			{Id: aws.String(id), LambdaFunctionArn: aws.String(arn), Events: aws.StringSlice(events), Filter: filter},
+		}
+	default:
+		config.QueueConfigurations = []*s3.QueueConfiguration{
+			{Id: aws.String(id), QueueArn: aws.String(arn), Events: aws.StringSlice(events), Filter: filter},
+		}
+	}
+	return config
+}
+
+// notificationTarget is the part of a notification configuration which
+// is compared after a round-trip.
+type notificationTarget struct {
+	ARN     string
+	Events  []string
+	Filters map[string]string
+}
+
+// notificationTargets returns the targets of all the configurations of config
+func notificationTargets(config *s3.NotificationConfiguration) []notificationTarget {
+	var targets []notificationTarget
+	add := func(arn *string, events []*string, filter *s3.NotificationConfigurationFilter) {
+		target := notificationTarget{ARN: aws.StringValue(arn), Events: aws.StringValueSlice(events), Filters: map[string]string{}}
+		sort.Strings(target.Events)
+		if filter != nil && filter.Key != nil {
+			for _, rule := range filter.Key.FilterRules {
+				// Names are case insensitive
+				target.Filters[strings.ToLower(aws.StringValue(rule.Name))] = aws.StringValue(rule.Value)
+			}
+		}
+		targets = append(targets, target)
+	}
+	for _, c := range config.QueueConfigurations {
+		add(c.QueueArn, c.Events, c.Filter)
+	}
+	for _, c := range config.TopicConfigurations {
+		add(c.TopicArn, c.Events, c.Filter)
+	}
+	for _, c := range config.LambdaFunctionConfigurations {
+		add(c.LambdaFunctionArn, c.Events, c.Filter)
+	}
+	return targets
+}
+
+// Set a notification configuration sending to NOTIFY_ARN, which must be a
+// target configured on the server, filtered on a prefix and a suffix. Read
+// it back, then remove it by setting an empty configuration.
+func testBucketNotification(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testBucketNotification"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	arn := os.Getenv("NOTIFY_ARN")
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"arn":        arn,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, "", function, args, startTime, true)
+
+	config := notificationConfiguration(arn, "mint-notification", "images/", ".jpg", "s3:ObjectCreated:*", "s3:ObjectRemoved:Delete")
+	_, err = s3Client.PutBucketNotificationConfiguration(&s3.PutBucketNotificationConfigurationInput{
+		Bucket:                    aws.String(bucket),
+		NotificationConfiguration: config,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutBucketNotificationConfiguration Failed", err).Fatal()
+		return
+	}
+
+	output, err := s3Client.GetBucketNotificationConfiguration(&s3.GetBucketNotificationConfigurationRequest{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetBucketNotificationConfiguration Failed", err).Fatal()
+		return
+	}
+	if expected, got := notificationTargets(config), notificationTargets(output); !reflect.DeepEqual(expected, got) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetBucketNotificationConfiguration expected %+v but got %+v", expected, got),
+			errors.New("notification configuration mismatch")).Fatal()
+		return
+	}
+
+	_, err = s3Client.PutBucketNotificationConfiguration(&s3.PutBucketNotificationConfigurationInput{
+		Bucket:                    aws.String(bucket),
+		NotificationConfiguration: &s3.NotificationConfiguration{},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutBucketNotificationConfiguration with an empty configuration Failed", err).Fatal()
+		return
+	}
+	output, err = s3Client.GetBucketNotificationConfiguration(&s3.GetBucketNotificationConfigurationRequest{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetBucketNotificationConfiguration Failed", err).Fatal()
+		return
+	}
+	if targets := notificationTargets(output); len(targets) != 0 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetBucketNotificationConfiguration expected no configuration but got %+v", targets),
+			errors.New("notification configuration mismatch")).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Check that an empty notification configuration round-trips, and that
+// configurations with malformed or unknown queue, topic and lambda
+// function ARNs are rejected.
+func testBucketNotificationErrors(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testBucketNotificationErrors"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	args := map[string]interface{}{
+		"bucketName": bucket,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, "", function, args, startTime, true)
+
+	output, err := s3Client.GetBucketNotificationConfiguration(&s3.GetBucketNotificationConfigurationRequest{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetBucketNotificationConfiguration Failed", err).Fatal()
+		return
+	}
+	if targets := notificationTargets(output); len(targets) != 0 {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetBucketNotificationConfiguration of a new bucket expected no configuration but got %+v", targets),
+			errors.New("notification configuration mismatch")).Fatal()
+		return
+	}
+
+	_, err = s3Client.PutBucketNotificationConfiguration(&s3.PutBucketNotificationConfigurationInput{
+		Bucket:                    aws.String(bucket),
+		NotificationConfiguration: &s3.NotificationConfiguration{},
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutBucketNotificationConfiguration with an empty configuration Failed", err).Fatal()
+		return
+	}
+
+	invalidARNs := []string{
+		"not-an-arn",
+		"arn:aws:sqs",
+		"arn:aws:sqs:us-east-1",
+		"arn:aws:sqs:us-east-1:123456789012:mint-nonexistent-queue",
+		"arn:aws:sns:us-east-1:123456789012:mint-nonexistent-topic",
+		"arn:aws:lambda:us-east-1:123456789012:function:mint-nonexistent",
+		"arn:minio:sqs::mint-nonexistent:webhook",
+	}
+	for _, arn := range invalidARNs {
+		config := notificationConfiguration(arn, "mint-notification", "", "", "s3:ObjectCreated:*")
+		_, err = s3Client.PutBucketNotificationConfiguration(&s3.PutBucketNotificationConfigurationInput{
+			Bucket:                    aws.String(bucket),
+			NotificationConfiguration: config,
+		})
+		if err == nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PutBucketNotificationConfiguration with ARN %q expected to fail but succeeded", arn), nil).Fatal()
+			return
+		}
+		if rerr, ok := err.(awserr.RequestFailure); !ok || rerr.StatusCode() != http.StatusBadRequest {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PutBucketNotificationConfiguration with ARN %q expected to fail with 400 Bad Request", arn), err).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,184 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// needsURLEncoding reports whether r is to be URL encoded in keys, which
+// leave the unreserved characters and slashes as they are
+func needsURLEncoding(r rune) bool {
+	return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || strings.ContainsRune("-_.~/", r))
+}
+
+// Upload objects under keys made of characters needing to be escaped in
+// URLs or XML, of multi-byte characters, or at the limits of the key
+// length. Check they are read back under the same key through GetObject,
+// HeadObject and ListObjectsV2, including when listing with
+// encoding-type=url, where the keys returned must be URL encoded.
+func testObjectKeyNames(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testObjectKeyNames"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	args := map[string]interface{}{
+		"bucketName": bucket,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	// The key length is limited to 1024 bytes, and MinIO limits each of
+	// the path segments to 255 bytes
+	longKey := strings.Repeat(strings.Repeat("k", 200)+"/", 5)
+	longKey += strings.Repeat("y", 1024-len(longKey))
+
+	testCases := []struct {
+		key  string
+		name string
+	}{
+		{"with space", "space"},
+		{"plus+sign", "plus"},
+		{"percent%20sign%", "percent"},
+		{"hash#sign", "hash"},
+		{"question?mark", "question mark"},
+		{"ampersand&less<than>", "XML special characters"},
+		{"unicode/日本語/ключ/ü", "unicode"},
+		{"emoji-😀🚀", "emoji"},
+		{"deep/" + strings.Repeat("prefix/", 20) + "object", "deep prefix"},
+		{longKey, "1024 bytes long"},
+		{"trailing-slash/", "trailing slash"},
+	}
+	var keys []string
+	for _, testCase := range testCases {
+		args["objectName"] = testCase.key
+		args["case"] = testCase.name
+
+		// Objects ending with a slash are directories, which MinIO
+		// only accepts empty
+		data := []byte(testCase.key)
+		if strings.HasSuffix(testCase.key, "/") {
+			data = nil
+		}
+
+		_, err = s3Client.PutObject(&s3.PutObjectInput{
+			Body:   bytes.NewReader(data),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(testCase.key),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutObject Failed", err).Fatal()
+			return
+		}
+		keys = append(keys, testCase.key)
+
+		head, err := s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(testCase.key),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadObject Failed", err).Fatal()
+			return
+		}
+		if aws.Int64Value(head.ContentLength) != int64(len(data)) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject expected %d bytes but got %d", len(data), aws.Int64Value(head.ContentLength)), nil).Fatal()
+			return
+		}
+
+		got, err := getObjectContent(s3Client, bucket, testCase.key)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject Failed", err).Fatal()
+			return
+		}
+		if !bytes.Equal(got, data) {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject returned unexpected content", nil).Fatal()
+			return
+		}
+
+		list, err := s3Client.ListObjectsV2(&s3.ListObjectsV2Input{
+			Bucket: aws.String(bucket),
+			Prefix: aws.String(testCase.key),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListObjectsV2 Failed", err).Fatal()
+			return
+		}
+		if len(list.Contents) != 1 || aws.StringValue(list.Contents[0].Key) != testCase.key {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 with the key as prefix expected only %q but got %d objects", testCase.key, len(list.Contents)), nil).Fatal()
+			return
+		}
+	}
+	delete(args, "objectName")
+	delete(args, "case")
+	sort.Strings(keys)
+
+	// aws-sdk-go v1 leaves the keys encoded
+	var listed []string
+	err = s3Client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket:       aws.String(bucket),
+		EncodingType: aws.String(s3.EncodingTypeUrl),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			listed = append(listed, aws.StringValue(obj.Key))
+		}
+		return true
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListObjectsV2 with encoding-type=url Failed", err).Fatal()
+		return
+	}
+	if len(listed) != len(keys) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 with encoding-type=url expected %d objects but got %d", len(keys), len(listed)), nil).Fatal()
+		return
+	}
+	for i, encoded := range listed {
+		key, err := url.QueryUnescape(encoded)
+		if err != nil || key != keys[i] {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 with encoding-type=url expected %q encoded but got %q", keys[i], encoded), err).Fatal()
+			return
+		}
+		if encoded == key && strings.IndexFunc(key, needsURLEncoding) >= 0 {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 with encoding-type=url returned %q unencoded", key), nil).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,227 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// putMultipartObject uploads object in parts of the given sizes
+func putMultipartObject(s3Client *s3.S3, bucket, object string, partSizes []int64) error {
+	upload, err := s3Client.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		return err
+	}
+
+	var completedParts []*s3.CompletedPart
+	for i, size := range partSizes {
+		part, err := s3Client.UploadPart(&s3.UploadPartInput{
+			Bucket:     aws.String(bucket),
+			Key:        aws.String(object),
+			UploadId:   upload.UploadId,
+			PartNumber: aws.Int64(int64(i + 1)),
+			Body:       bytes.NewReader(bytes.Repeat([]byte("a"), int(size))),
+		})
+		if err != nil {
+			s3Client.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+				Bucket:   aws.String(bucket),
+				Key:      aws.String(object),
+				UploadId: upload.UploadId,
+			})
+			return err
+		}
+		completedParts = append(completedParts, &s3.CompletedPart{
+			ETag:       part.ETag,
+			PartNumber: aws.Int64(int64(i + 1)),
+		})
+	}
+
+	_, err = s3Client.CompleteMultipartUpload(&s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(object),
+		UploadId:        upload.UploadId,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: completedParts},
+	})
+	return err
+}
+
+// Upload objects of various sizes, some of them in multiple parts, and
+// check that every API reporting an object size agrees on it.
+func testObjectSizeConsistency(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testObjectSizeConsistency"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	args := map[string]interface{}{
+		"bucketName": bucket,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+
+	testCases := []struct {
+		object    string
+		size      int64
+		partSizes []int64
+	}{
+		{object: "empty", size: 0},
+		{object: "one-byte", size: 1},
+		{object: "small", size: 1025},
+		{object: "single-part", size: 5*1024*1024 + 1},
+		{object: "multipart", size: 5*1024*1024 + 1, partSizes: []int64{5 * 1024 * 1024, 1}},
+		{object: "multipart-3", size: 2*5*1024*1024 + 4097, partSizes: []int64{5 * 1024 * 1024, 5 * 1024 * 1024, 4097}},
+	}
+
+	// Deferred calls run in reverse order, remove the bucket last
+	defer cleanup(s3Client, bucket, "", function, args, startTime, true)
+	for _, testCase := range testCases {
+		defer cleanup(s3Client, bucket, testCase.object, function, args, startTime, false)
+	}
+
+	for _, testCase := range testCases {
+		if testCase.partSizes != nil {
+			err = putMultipartObject(s3Client, bucket, testCase.object, testCase.partSizes)
+		} else {
+			_, err = s3Client.PutObject(&s3.PutObjectInput{
+				Body:   bytes.NewReader(bytes.Repeat([]byte("a"), int(testCase.size))),
+				Bucket: aws.String(bucket),
+				Key:    aws.String(testCase.object),
+			})
+		}
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT of %s expected to success but got %v", testCase.object, err), err).Fatal()
+			return
+		}
+	}
+
+	listSizes := make(map[string]int64)
+	err = s3Client.ListObjectsV2Pages(&s3.ListObjectsV2Input{Bucket: aws.String(bucket)},
+		func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+			for _, obj := range page.Contents {
+				listSizes[*obj.Key] = *obj.Size
+			}
+			return true
+		})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListObjectsV2 Failed", err).Fatal()
+		return
+	}
+
+	versionSizes := make(map[string]int64)
+	err = s3Client.ListObjectVersionsPages(&s3.ListObjectVersionsInput{Bucket: aws.String(bucket)},
+		func(page *s3.ListObjectVersionsOutput, lastPage bool) bool {
+			for _, v := range page.Versions {
+				versionSizes[*v.Key] = *v.Size
+			}
+			return true
+		})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListObjectVersions Failed", err).Fatal()
+		return
+	}
+
+	attributesImplemented := true
+	for _, testCase := range testCases {
+		sizes := make(map[string]int64)
+
+		headOutput, err := s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(testCase.object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject of %s Failed", testCase.object), err).Fatal()
+			return
+		}
+		sizes["HeadObject"] = *headOutput.ContentLength
+
+		getOutput, err := s3Client.GetObject(&s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(testCase.object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of %s Failed", testCase.object), err).Fatal()
+			return
+		}
+		n, err := io.Copy(ioutil.Discard, getOutput.Body)
+		getOutput.Body.Close()
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of %s reading body failed", testCase.object), err).Fatal()
+			return
+		}
+		sizes["GetObject Content-Length"] = *getOutput.ContentLength
+		sizes["GetObject body"] = n
+
+		listSize, ok := listSizes[testCase.object]
+		if !ok {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 did not list %s", testCase.object), nil).Fatal()
+			return
+		}
+		sizes["ListObjectsV2"] = listSize
+
+		versionSize, ok := versionSizes[testCase.object]
+		if !ok {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectVersions did not list %s", testCase.object), nil).Fatal()
+			return
+		}
+		sizes["ListObjectVersions"] = versionSize
+
+		if attributesImplemented {
+			attrOutput, err := s3Client.GetObjectAttributes(&s3.GetObjectAttributesInput{
+				Bucket:           aws.String(bucket),
+				Key:              aws.String(testCase.object),
+				ObjectAttributes: []*string{aws.String(s3.ObjectAttributesObjectSize)},
+			})
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "NotImplemented" {
+				attributesImplemented = false
+			} else if err != nil {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObjectAttributes of %s Failed", testCase.object), err).Fatal()
+				return
+			} else {
+				sizes["GetObjectAttributes"] = aws.Int64Value(attrOutput.ObjectSize)
+			}
+		}
+
+		for api, size := range sizes {
+			if size != testCase.size {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go %s reported size %d for %s, expected %d", api, size, testCase.object, testCase.size), fmt.Errorf("sizes reported: %v", sizes)).Fatal()
+				return
+			}
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,201 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+const publicReadPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:GetBucketLocation", "s3:ListBucket"],
+      "Resource": ["arn:aws:s3:::%[1]s"]
+    },
+    {
+      "Effect": "Allow",
+      "Principal": {"AWS": ["*"]},
+      "Action": ["s3:GetObject"],
+      "Resource": ["arn:aws:s3:::%[1]s/*"]
+    }
+  ]
+}`
+
+// anonymousRequest sends an unsigned request to the server and
+// returns the response status code and the S3 error code, if any.
+func anonymousRequest(s3Client *s3.S3, method, path string) (int, string, error) {
+	req, err := http.NewRequest(method, s3Client.Endpoint+path, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	resp, err := s3Client.Config.HTTPClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, "", err
+	}
+	if resp.StatusCode < 300 || method == http.MethodHead {
+		return resp.StatusCode, "", nil
+	}
+	errResp := mintest.ErrorResponse{}
+	if err = xml.Unmarshal(body, &errResp); err != nil {
+		return resp.StatusCode, "", err
+	}
+	return resp.StatusCode, errResp.Code, nil
+}
+
+// checkResponseOverrides returns an error telling the first response header
+// not set to the value of its override, if any
+func checkResponseOverrides(header http.Header, input *s3.GetObjectInput) error {
+	expected := map[string]string{
+		"Content-Type":        aws.StringValue(input.ResponseContentType),
+		"Content-Disposition": aws.StringValue(input.ResponseContentDisposition),
+		"Cache-Control":       aws.StringValue(input.ResponseCacheControl),
+	}
+	for name, value := range expected {
+		if got := header.Get(name); got != value {
+			return fmt.Errorf("%s is %q instead of %q", name, got, value)
+		}
+	}
+	expires, err := http.ParseTime(header.Get("Expires"))
+	if err != nil || !expires.Equal(aws.TimeValue(input.ResponseExpires)) {
+		return fmt.Errorf("Expires is %q instead of %s", header.Get("Expires"), aws.TimeValue(input.ResponseExpires).Format(http.TimeFormat))
+	}
+	return nil
+}
+
+// GET an object overriding its Content-Type, Content-Disposition,
+// Cache-Control and Expires response headers, through a signed request and
+// through a presigned URL, and check the response carries the overrides.
+// Anonymous requests are not allowed to override response headers, even
+// when the object is public, and must be rejected with InvalidRequest.
+func testGetObjectResponseOverrides(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testGetObjectResponseOverrides"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:         aws.ReadSeekCloser(strings.NewReader("fileToUpload")),
+		Bucket:       aws.String(bucket),
+		Key:          aws.String(object),
+		ContentType:  aws.String("text/plain"),
+		CacheControl: aws.String("no-cache"),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		return
+	}
+
+	input := &s3.GetObjectInput{
+		Bucket:                     aws.String(bucket),
+		Key:                        aws.String(object),
+		ResponseContentType:        aws.String("application/json"),
+		ResponseContentDisposition: aws.String(`attachment; filename="overridden.json"`),
+		ResponseCacheControl:       aws.String("max-age=3600"),
+		ResponseExpires:            aws.Time(time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)),
+	}
+
+	req, output := s3Client.GetObjectRequest(input)
+	if err = req.Send(); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject with response overrides Failed", err).Fatal()
+		return
+	}
+	output.Body.Close()
+	if err = checkResponseOverrides(req.HTTPResponse.Header, input); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject ignored the response overrides", err).Fatal()
+		return
+	}
+
+	req, _ = s3Client.GetObjectRequest(input)
+	presignedURL, err := req.Presign(time.Minute)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go presigned GET request creation failed", err).Fatal()
+		return
+	}
+	resp, err := s3Client.Config.HTTPClient.Get(presignedURL)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Presigned GET with response overrides failed", err).Fatal()
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Presigned GET with response overrides expected 200 but got %s", resp.Status), nil).Fatal()
+		return
+	}
+	if err = checkResponseOverrides(resp.Header, input); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Presigned GET ignored the response overrides", err).Fatal()
+		return
+	}
+
+	_, err = s3Client.PutBucketPolicy(&s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucket),
+		Policy: aws.String(fmt.Sprintf(publicReadPolicy, bucket)),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutBucketPolicy Failed", err).Fatal()
+		return
+	}
+	objectPath := "/" + bucket + "/" + object
+	status, code, err := anonymousRequest(s3Client, http.MethodGet, objectPath)
+	if err != nil || status != http.StatusOK {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Anonymous GET of a public object expected 200 but got %d %s", status, code), err).Fatal()
+		return
+	}
+	query := url.Values{"response-content-type": []string{"application/json"}}
+	status, code, err = anonymousRequest(s3Client, http.MethodGet, objectPath+"?"+query.Encode())
+	if err != nil || status != http.StatusBadRequest || code != "InvalidRequest" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Anonymous GET with response overrides expected 400 InvalidRequest but got %d %s", status, code), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
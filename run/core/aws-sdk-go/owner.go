@@ -0,0 +1,189 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// ownerMismatches returns the fields of the owners reported by each API
+// which disagree: the IDs and display names set must all be the same, and
+// at least one API must set the ID.
+func ownerMismatches(owners map[string]*s3.Owner) []string {
+	apis := make([]string, 0, len(owners))
+	for api := range owners {
+		apis = append(apis, api)
+	}
+	sort.Strings(apis)
+
+	var mismatches []string
+	for _, field := range []struct {
+		name  string
+		value func(owner *s3.Owner) string
+	}{
+		{"ID", func(owner *s3.Owner) string { return aws.StringValue(owner.ID) }},
+		{"DisplayName", func(owner *s3.Owner) string { return aws.StringValue(owner.DisplayName) }},
+	} {
+		values := make(map[string][]string)
+		for _, api := range apis {
+			if value := field.value(owners[api]); value != "" {
+				values[value] = append(values[value], api)
+			}
+		}
+		if len(values) > 1 {
+			var reported []string
+			for value, by := range values {
+				reported = append(reported, fmt.Sprintf("%q by %s", value, strings.Join(by, ", ")))
+			}
+			sort.Strings(reported)
+			mismatches = append(mismatches, fmt.Sprintf("%s reported as %s", field.name, strings.Join(reported, " and ")))
+		}
+		if field.name == "ID" && len(values) == 0 {
+			mismatches = append(mismatches, "ID reported by none of "+strings.Join(apis, ", "))
+		}
+	}
+	return mismatches
+}
+
+// Check the owner of a bucket and an object, all created with the same
+// credentials, is the same whether reported by ListBuckets, GetBucketAcl,
+// ListObjectsV2 with fetch-owner, ListObjects or GetObjectAcl. Each must
+// report an owner, and the IDs and display names they set must agree.
+// The APIs may leave them empty, MinIO having no canonical user IDs for
+// its ACLs, but at least one must set the ID.
+func testOwnerConsistency(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testOwnerConsistency"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader("owner")),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		return
+	}
+
+	owners := make(map[string]*s3.Owner)
+	// record records the owner reported by api, which must be one
+	record := func(api string, owner *s3.Owner, err error) bool {
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go %s Failed", api), err).Fatal()
+			return false
+		}
+		if owner == nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go %s expected to report an owner", api), nil).Fatal()
+			return false
+		}
+		owners[api] = owner
+		return true
+	}
+
+	buckets, err := s3Client.ListBuckets(&s3.ListBucketsInput{})
+	var owner *s3.Owner
+	if err == nil {
+		owner = buckets.Owner
+	}
+	if !record("ListBuckets", owner, err) {
+		return
+	}
+
+	bucketACL, err := s3Client.GetBucketAcl(&s3.GetBucketAclInput{
+		Bucket: aws.String(bucket),
+	})
+	owner = nil
+	if err == nil {
+		owner = bucketACL.Owner
+	}
+	if !record("GetBucketAcl", owner, err) {
+		return
+	}
+
+	listV2, err := s3Client.ListObjectsV2(&s3.ListObjectsV2Input{
+		Bucket:     aws.String(bucket),
+		FetchOwner: aws.Bool(true),
+	})
+	owner = nil
+	if err == nil && len(listV2.Contents) == 1 {
+		owner = listV2.Contents[0].Owner
+	}
+	if !record("ListObjectsV2 with fetch-owner", owner, err) {
+		return
+	}
+
+	list, err := s3Client.ListObjects(&s3.ListObjectsInput{
+		Bucket: aws.String(bucket),
+	})
+	owner = nil
+	if err == nil && len(list.Contents) == 1 {
+		owner = list.Contents[0].Owner
+	}
+	if !record("ListObjects", owner, err) {
+		return
+	}
+
+	objectACL, err := s3Client.GetObjectAcl(&s3.GetObjectAclInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	owner = nil
+	if err == nil {
+		owner = objectACL.Owner
+	}
+	if !record("GetObjectAcl", owner, err) {
+		return
+	}
+
+	reported := make(map[string]string, len(owners))
+	for api, owner := range owners {
+		reported[api] = fmt.Sprintf("ID %q, DisplayName %q", aws.StringValue(owner.ID), aws.StringValue(owner.DisplayName))
+	}
+	args["owners"] = reported
+	if mismatches := ownerMismatches(owners); len(mismatches) > 0 {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go owners inconsistent: "+strings.Join(mismatches, "; "), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
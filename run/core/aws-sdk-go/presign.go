@@ -0,0 +1,378 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// presignedURL is a presigned GET URL along with the object it points to
+type presignedURL struct {
+	object string
+	url    string
+}
+
+// Generate thousands of presigned GET URLs from concurrent goroutines, then
+// execute a sample of them concurrently. Every executed URL must be accepted
+// by the server and return the content of the object it was generated for.
+func testPresignedGetBurst(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testPresignedGetBurst"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	numObjects := 16
+	numURLs := 5000
+	numExecuted := 500
+	concurrency := 32
+	expiry := 10 * time.Minute
+	args := map[string]interface{}{
+		"bucketName":  bucket,
+		"numObjects":  numObjects,
+		"numURLs":     numURLs,
+		"numExecuted": numExecuted,
+		"concurrency": concurrency,
+		"expiry":      expiry,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+
+	// Deferred calls run in reverse order, remove the bucket last
+	defer cleanup(s3Client, bucket, "", function, args, startTime, true)
+	objects := make([]string, numObjects)
+	for i := range objects {
+		objects[i] = fmt.Sprintf("object-%02d", i)
+		defer cleanup(s3Client, bucket, objects[i], function, args, startTime, false)
+	}
+
+	for _, object := range objects {
+		_, err = s3Client.PutObject(&s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader(object)),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+			return
+		}
+	}
+
+	// Generation is purely client side
+	urls := make([]presignedURL, numURLs)
+	errs := make([]error, numURLs)
+	var wg sync.WaitGroup
+	generationStart := time.Now()
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < numURLs; i += concurrency {
+				object := objects[i%numObjects]
+				req, _ := s3Client.GetObjectRequest(&s3.GetObjectInput{
+					Bucket: aws.String(bucket),
+					Key:    aws.String(object),
+				})
+				urls[i].object = object
+				urls[i].url, errs[i] = req.Presign(expiry)
+			}
+		}(w)
+	}
+	wg.Wait()
+	generationTime := time.Since(generationStart)
+	args["generationDuration"] = generationTime.Milliseconds()
+	args["generationPerSecond"] = int64(float64(numURLs) / generationTime.Seconds())
+
+	for i, err := range errs {
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go presigned GET request creation failed for %s", urls[i].object), err).Fatal()
+			return
+		}
+	}
+
+	// Execute an evenly spread sample of the generated URLs
+	type result struct {
+		url        presignedURL
+		statusCode int
+		code       string
+		body       string
+		err        error
+	}
+	results := make([]result, numExecuted)
+	executionStart := time.Now()
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < numExecuted; i += concurrency {
+				results[i].url = urls[i*numURLs/numExecuted]
+				resp, err := s3Client.Config.HTTPClient.Get(results[i].url.url)
+				if err != nil {
+					results[i].err = err
+					continue
+				}
+				body, err := ioutil.ReadAll(resp.Body)
+				resp.Body.Close()
+				results[i].statusCode = resp.StatusCode
+				results[i].body = string(body)
+				results[i].err = err
+				if err == nil && resp.StatusCode != http.StatusOK {
+					errResp := mintest.ErrorResponse{}
+					results[i].err = xml.Unmarshal(body, &errResp)
+					results[i].code = errResp.Code
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	args["executionDuration"] = time.Since(executionStart).Milliseconds()
+
+	accepted, signatureFailures := 0, 0
+	var firstFailure *result
+	for i := range results {
+		r := &results[i]
+		switch {
+		case r.err == nil && r.statusCode == http.StatusOK && r.body == r.url.object:
+			accepted++
+			continue
+		case r.code == "SignatureDoesNotMatch":
+			signatureFailures++
+		}
+		if firstFailure == nil {
+			firstFailure = r
+		}
+	}
+	args["accepted"] = accepted
+	args["signatureFailures"] = signatureFailures
+
+	if firstFailure != nil {
+		err := firstFailure.err
+		if err == nil {
+			err = errors.New("presigned GET response mismatch")
+		}
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go presigned GET of %s expected 200 with its content but got %d %s %q",
+			firstFailure.url.object, firstFailure.statusCode, firstFailure.code, firstFailure.body), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// doPresigned executes a presigned URL with the HTTP client of s3Client,
+// and returns the status, the S3 error code if any and the body of the
+// response
+func doPresigned(s3Client *s3.S3, method, presigned, body string) (int, string, string, error) {
+	req, err := http.NewRequest(method, presigned, strings.NewReader(body))
+	if err != nil {
+		return 0, "", "", err
+	}
+	resp, err := s3Client.Config.HTTPClient.Do(req)
+	if err != nil {
+		return 0, "", "", err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, "", "", err
+	}
+	errResp := mintest.ErrorResponse{}
+	if resp.StatusCode != http.StatusOK {
+		xml.Unmarshal(data, &errResp)
+	}
+	return resp.StatusCode, errResp.Code, string(data), nil
+}
+
+// Tamper with presigned GET and PUT URLs, their key name, expiry, date or
+// signature, or add a query parameter to them, and check the server
+// rejects each one with SignatureDoesNotMatch or AccessDenied, like it
+// rejects an expired URL. The untampered URLs must still work.
+func testPresignedURLTampering(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testPresignedURLTampering"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	otherObject := "otherObject"
+	uploaded := "uploaded"
+	expiry := 10 * time.Minute
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"expiry":     expiry,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+	defer cleanup(s3Client, bucket, otherObject, function, args, startTime, false)
+	defer cleanup(s3Client, bucket, uploaded, function, args, startTime, false)
+
+	for _, o := range []string{object, otherObject} {
+		_, err = s3Client.PutObject(&s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader(o)),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(o),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+			return
+		}
+	}
+
+	getReq, _ := s3Client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	getURL, err := getReq.Presign(expiry)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go presigned GET request creation failed", err).Fatal()
+		return
+	}
+	putReq, _ := s3Client.PutObjectRequest(&s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(uploaded),
+	})
+	putURL, err := putReq.Presign(expiry)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go presigned PUT request creation failed", err).Fatal()
+		return
+	}
+	expiredReq, _ := s3Client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	expiredURL, err := expiredReq.Presign(time.Second)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go presigned GET request creation failed", err).Fatal()
+		return
+	}
+
+	tamperings := []struct {
+		name   string
+		tamper func(u *url.URL, query url.Values)
+	}{
+		{"key name", func(u *url.URL, query url.Values) {
+			u.Path = path.Join(path.Dir(u.Path), otherObject)
+		}},
+		{"expiry", func(u *url.URL, query url.Values) {
+			query.Set("X-Amz-Expires", "604800")
+		}},
+		{"date", func(u *url.URL, query url.Values) {
+			date, _ := time.Parse("20060102T150405Z", query.Get("X-Amz-Date"))
+			query.Set("X-Amz-Date", date.Add(time.Minute).Format("20060102T150405Z"))
+		}},
+		{"signature", func(u *url.URL, query url.Values) {
+			signature := []byte(query.Get("X-Amz-Signature"))
+			if signature[len(signature)-1] == '0' {
+				signature[len(signature)-1] = '1'
+			} else {
+				signature[len(signature)-1] = '0'
+			}
+			query.Set("X-Amz-Signature", string(signature))
+		}},
+		{"additional query parameter", func(u *url.URL, query url.Values) {
+			query.Set("response-content-type", "text/html")
+		}},
+	}
+
+	rejected := func(status int, code string) bool {
+		return status == http.StatusForbidden && (code == "SignatureDoesNotMatch" || code == "AccessDenied")
+	}
+
+	for _, presigned := range []struct{ method, url string }{{http.MethodGet, getURL}, {http.MethodPut, putURL}} {
+		for _, tampering := range tamperings {
+			u, err := url.Parse(presigned.url)
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", "Parsing the presigned URL failed", err).Fatal()
+				return
+			}
+			query := u.Query()
+			tampering.tamper(u, query)
+			u.RawQuery = query.Encode()
+
+			status, code, body, err := doPresigned(s3Client, presigned.method, u.String(), "tampered")
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go presigned %s with tampered %s failed", presigned.method, tampering.name), err).Fatal()
+				return
+			}
+			if !rejected(status, code) {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go presigned %s with tampered %s expected to fail with 403 SignatureDoesNotMatch or AccessDenied but got %d %s %q",
+					presigned.method, tampering.name, status, code, body), errors.New("tampered URL accepted")).Fatal()
+				return
+			}
+		}
+	}
+
+	time.Sleep(2 * time.Second)
+	status, code, body, err := doPresigned(s3Client, http.MethodGet, expiredURL, "")
+	if err != nil || !rejected(status, code) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go expired presigned GET expected to fail with 403 AccessDenied but got %d %s %q", status, code, body), err).Fatal()
+		return
+	}
+
+	// None of the tampered PUTs may have created the object
+	_, err = s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(uploaded),
+	})
+	if err == nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadObject of the object of the presigned PUT expected to fail before the untampered PUT", errors.New("tampered URL accepted")).Fatal()
+		return
+	}
+
+	status, _, body, err = doPresigned(s3Client, http.MethodGet, getURL, "")
+	if err != nil || status != http.StatusOK || body != object {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go untampered presigned GET expected 200 with %q but got %d %q", object, status, body), err).Fatal()
+		return
+	}
+	status, _, body, err = doPresigned(s3Client, http.MethodPut, putURL, "untampered")
+	if err != nil || status != http.StatusOK {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go untampered presigned PUT expected 200 but got %d %q", status, body), err).Fatal()
+		return
+	}
+	content, err := getObjectContent(s3Client, bucket, uploaded)
+	if err != nil || string(content) != "untampered" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of the presigned PUT expected %q but got %q", "untampered", content), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
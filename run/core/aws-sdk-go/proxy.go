@@ -0,0 +1,238 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// forwardProxy is a local HTTP forward proxy, relaying plain requests and
+// tunneling the ones sent with CONNECT
+type forwardProxy struct {
+	server    *http.Server
+	listener  net.Listener
+	transport *http.Transport
+
+	mu       sync.Mutex
+	requests []string // method and host of the requests relayed
+}
+
+// startForwardProxy starts a forward proxy on a local port, relaying the
+// requests through transport
+func startForwardProxy(transport *http.Transport) (*forwardProxy, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	p := &forwardProxy{listener: listener, transport: transport}
+	p.server = &http.Server{Handler: p}
+	go p.server.Serve(listener)
+	return p, nil
+}
+
+// URL returns the URL of the proxy
+func (p *forwardProxy) URL() *url.URL {
+	return &url.URL{Scheme: "http", Host: p.listener.Addr().String()}
+}
+
+func (p *forwardProxy) Close() {
+	p.server.Close()
+}
+
+// relayed returns the method and host of the requests relayed so far
+func (p *forwardProxy) relayed() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.requests...)
+}
+
+func (p *forwardProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	p.requests = append(p.requests, r.Method+" "+r.Host)
+	p.mu.Unlock()
+
+	if r.Method == http.MethodConnect {
+		server, err := net.DialTimeout("tcp", r.Host, 30*time.Second)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		client, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			server.Close()
+			return
+		}
+		io.WriteString(client, "HTTP/1.1 200 Connection established\r\n\r\n")
+		go func() {
+			io.Copy(server, client)
+			server.Close()
+		}()
+		io.Copy(client, server)
+		client.Close()
+		return
+	}
+
+	// A plain request is forwarded with its absolute URL
+	out := r.Clone(r.Context())
+	out.RequestURI = ""
+	out.Header.Del("Proxy-Connection")
+	out.Header.Del("Proxy-Authorization")
+	resp, err := p.transport.RoundTrip(out)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	for name, values := range resp.Header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
+// Send the requests of a bucket and object lifecycle through a local
+// forward proxy, as HTTP_PROXY and HTTPS_PROXY have them sent, and check
+// every connection of the client goes to the proxy, which relays all the
+// requests, or tunnels them over HTTPS, to the server. The object read
+// back must be the one written.
+func testProxyPassThrough(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testProxyPassThrough"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	config := mintest.LoadConfig()
+	// The proxy reaches the server directly, whatever the environment says
+	proxyTransport := config.Transport()
+	proxyTransport.Proxy = nil
+	proxy, err := startForwardProxy(proxyTransport)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Starting a local forward proxy failed", err).Fatal()
+		return
+	}
+	defer proxy.Close()
+	args["proxy"] = proxy.URL().String()
+
+	// Addresses the client connected to
+	var dialed []string
+	var mu sync.Mutex
+	transport := config.Transport()
+	transport.Proxy = http.ProxyURL(proxy.URL())
+	dial := transport.DialContext
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		mu.Lock()
+		dialed = append(dialed, address)
+		mu.Unlock()
+		return dial(ctx, network, address)
+	}
+	s3Config := s3Client.Config
+	s3Config.HTTPClient = &http.Client{Transport: mintest.TraceTransport(transport)}
+	proxiedClient := s3.New(session.New(), &s3Config)
+	var sent int64
+	proxiedClient.Handlers.Send.PushBack(func(r *request.Request) {
+		atomic.AddInt64(&sent, 1)
+	})
+
+	_, err = proxiedClient.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket through the proxy Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	content := []byte(mintest.RandString(1024, rand.NewSource(time.Now().UnixNano()), "proxied-"))
+	_, err = proxiedClient.PutObject(&s3.PutObjectInput{
+		Body:   bytes.NewReader(content),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PUT through the proxy Failed", err).Fatal()
+		return
+	}
+	got, err := getObjectContent(proxiedClient, bucket, object)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GET through the proxy Failed", err).Fatal()
+		return
+	}
+	if !bytes.Equal(got, content) {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GET through the proxy expected the content written", nil).Fatal()
+		return
+	}
+	_, err = proxiedClient.ListObjectsV2(&s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListObjectsV2 through the proxy Failed", err).Fatal()
+		return
+	}
+
+	mu.Lock()
+	args["dialed"] = dialed
+	mu.Unlock()
+	for _, address := range dialed {
+		if address != proxy.listener.Addr().String() {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Client connected to %s rather than to the proxy", address), nil).Fatal()
+			return
+		}
+	}
+	requests := proxy.relayed()
+	args["relayed"] = requests
+	if len(requests) == 0 {
+		mintest.FailureLog(function, args, startTime, "", "No request relayed by the proxy", nil).Fatal()
+		return
+	}
+	if config.Secure {
+		for _, relayed := range requests {
+			if !strings.HasPrefix(relayed, http.MethodConnect+" ") {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Proxy expected to only tunnel HTTPS requests but relayed %s", relayed), nil).Fatal()
+				return
+			}
+		}
+	} else if int64(len(requests)) != atomic.LoadInt64(&sent) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Proxy expected to relay the %d requests sent but relayed %d", atomic.LoadInt64(&sent), len(requests)), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,357 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Size of the parts of the multipart objects read by range
+const rangePartSize = 5 * 1024 * 1024
+
+// Size of the multipart objects read by range, the last part being smaller
+const rangeObjectSize = 2*rangePartSize + 1024*1024
+
+// Size of the object read at large offsets in full mode
+const largeRangeObjectSize = 300 * 1024 * 1024
+
+// byteRange is a Range header along with the first and last byte it selects
+type byteRange struct {
+	header     string
+	start, end int64
+}
+
+// sseCustomerAlgorithm returns the SSE-C algorithm going along with key,
+// nil without a key
+func sseCustomerAlgorithm(key *string) *string {
+	if key == nil {
+		return nil
+	}
+	return aws.String("AES256")
+}
+
+// putMultipartContent uploads content in parts of rangePartSize, encrypted
+// with the SSE-C key when it is not nil
+func putMultipartContent(s3Client *s3.S3, bucket, object string, content []byte, sseKey *string) error {
+	upload, err := s3Client.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(object),
+		SSECustomerAlgorithm: sseCustomerAlgorithm(sseKey),
+		SSECustomerKey:       sseKey,
+	})
+	if err != nil {
+		return err
+	}
+	var parts []*s3.CompletedPart
+	for start := 0; start < len(content); start += rangePartSize {
+		end := start + rangePartSize
+		if end > len(content) {
+			end = len(content)
+		}
+		partNumber := aws.Int64(int64(len(parts) + 1))
+		output, err := s3Client.UploadPart(&s3.UploadPartInput{
+			Body:                 bytes.NewReader(content[start:end]),
+			Bucket:               aws.String(bucket),
+			Key:                  aws.String(object),
+			PartNumber:           partNumber,
+			UploadId:             upload.UploadId,
+			SSECustomerAlgorithm: sseCustomerAlgorithm(sseKey),
+			SSECustomerKey:       sseKey,
+		})
+		if err != nil {
+			s3Client.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+				Bucket:   aws.String(bucket),
+				Key:      aws.String(object),
+				UploadId: upload.UploadId,
+			})
+			return err
+		}
+		parts = append(parts, &s3.CompletedPart{ETag: output.ETag, PartNumber: partNumber})
+	}
+	_, err = s3Client.CompleteMultipartUpload(&s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(object),
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+		UploadId:        upload.UploadId,
+	})
+	return err
+}
+
+// checkRange GETs r of an object of size bytes, and checks the response
+// holds the bytes returned by contentAt, with the matching Content-Range
+// and Content-Length.
+func checkRange(s3Client *s3.S3, bucket, object string, size int64, r byteRange, sseKey *string, contentAt func(start, end int64) ([]byte, error)) error {
+	output, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(object),
+		Range:                aws.String(r.header),
+		SSECustomerAlgorithm: sseCustomerAlgorithm(sseKey),
+		SSECustomerKey:       sseKey,
+	})
+	if err != nil {
+		return fmt.Errorf("GET with Range %s failed, %w", r.header, err)
+	}
+	defer output.Body.Close()
+	data, err := ioutil.ReadAll(output.Body)
+	if err != nil {
+		return fmt.Errorf("GET with Range %s reading body failed, %w", r.header, err)
+	}
+
+	contentRange := fmt.Sprintf("bytes %d-%d/%d", r.start, r.end, size)
+	if aws.StringValue(output.ContentRange) != contentRange {
+		return fmt.Errorf("GET with Range %s returned Content-Range %q, expected %q", r.header, aws.StringValue(output.ContentRange), contentRange)
+	}
+	if length := r.end - r.start + 1; aws.Int64Value(output.ContentLength) != length || int64(len(data)) != length {
+		return fmt.Errorf("GET with Range %s returned Content-Length %d and %d bytes, expected %d", r.header, aws.Int64Value(output.ContentLength), len(data), length)
+	}
+	expected, err := contentAt(r.start, r.end)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(data, expected) {
+		return fmt.Errorf("GET with Range %s returned other content than bytes %d to %d", r.header, r.start, r.end)
+	}
+	return nil
+}
+
+// checkMultipartRanges reads a multipart object of rangeObjectSize by
+// ranges within a part, across part boundaries, open-ended and by suffix,
+// as well as by part number, which cannot be combined with a range.
+func checkMultipartRanges(s3Client *s3.S3, bucket, object string, content []byte, sseKey *string) error {
+	size := int64(len(content))
+	contentAt := func(start, end int64) ([]byte, error) {
+		return content[start : end+1], nil
+	}
+	ranges := []byteRange{
+		{"bytes=0-99", 0, 99},
+		// Across the 64 KiB packages SSE-C objects are encrypted in
+		{"bytes=65530-65545", 65530, 65545},
+		{fmt.Sprintf("bytes=%d-%d", rangePartSize-100, rangePartSize+99), rangePartSize - 100, rangePartSize + 99},
+		{fmt.Sprintf("bytes=%d-%d", rangePartSize-1, 2*rangePartSize), rangePartSize - 1, 2 * rangePartSize},
+		{fmt.Sprintf("bytes=%d-", 2*rangePartSize-10), 2*rangePartSize - 10, size - 1},
+		{fmt.Sprintf("bytes=%d-%d", size-10, size+1000), size - 10, size - 1},
+		{"bytes=-100", size - 100, size - 1},
+		// A suffix larger than the object selects all of it
+		{fmt.Sprintf("bytes=-%d", size+1024), 0, size - 1},
+	}
+	for _, r := range ranges {
+		if err := checkRange(s3Client, bucket, object, size, r, sseKey, contentAt); err != nil {
+			return err
+		}
+	}
+
+	output, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(object),
+		PartNumber:           aws.Int64(2),
+		SSECustomerAlgorithm: sseCustomerAlgorithm(sseKey),
+		SSECustomerKey:       sseKey,
+	})
+	if err != nil {
+		return fmt.Errorf("GET of part 2 failed, %w", err)
+	}
+	data, err := ioutil.ReadAll(output.Body)
+	output.Body.Close()
+	if err != nil {
+		return fmt.Errorf("GET of part 2 reading body failed, %w", err)
+	}
+	contentRange := fmt.Sprintf("bytes %d-%d/%d", rangePartSize, 2*rangePartSize-1, size)
+	if aws.StringValue(output.ContentRange) != contentRange || aws.Int64Value(output.PartsCount) != 3 {
+		return fmt.Errorf("GET of part 2 returned Content-Range %q and %d parts, expected %q and 3 parts",
+			aws.StringValue(output.ContentRange), aws.Int64Value(output.PartsCount), contentRange)
+	}
+	if !bytes.Equal(data, content[rangePartSize:2*rangePartSize]) {
+		return fmt.Errorf("GET of part 2 returned other content than the part")
+	}
+
+	_, err = s3Client.GetObject(&s3.GetObjectInput{
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(object),
+		PartNumber:           aws.Int64(2),
+		Range:                aws.String("bytes=0-99"),
+		SSECustomerAlgorithm: sseCustomerAlgorithm(sseKey),
+		SSECustomerKey:       sseKey,
+	})
+	if rerr, ok := err.(awserr.RequestFailure); !ok || rerr.StatusCode() != http.StatusBadRequest {
+		return fmt.Errorf("GET of part 2 with a Range expected to fail with status 400 but got %v", err)
+	}
+	return nil
+}
+
+// Read a multipart object by ranges and by part number.
+func testGetObjectRange(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testGetObjectRange"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "multipart"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, "", function, args, startTime, true)
+
+	seed := mintest.DataSeed()
+	args["seed"] = seed
+	content, err := ioutil.ReadAll(mintest.NewDataReader(seed, rangeObjectSize))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Generating the object content failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, false)
+	if err = putMultipartContent(s3Client, bucket, object, content, nil); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go multipart upload Failed", err).Fatal()
+		return
+	}
+	if err = checkMultipartRanges(s3Client, bucket, object, content, nil); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ranged GET of a multipart object Failed", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Read ranges at offsets of hundreds of MiB of a large object, which only
+// runs in full mode.
+func testGetObjectRangeLarge(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testGetObjectRangeLarge"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	largeObject := "large"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": largeObject,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, "", function, args, startTime, true)
+
+	seed := mintest.DataSeed()
+	args["seed"] = seed
+	defer cleanup(s3Client, bucket, largeObject, function, args, startTime, false)
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   mintest.NewDataReader(seed, largeRangeObjectSize),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(largeObject),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT of %d bytes expected to success but got %v", largeRangeObjectSize, err), err).Fatal()
+		return
+	}
+	contentAt := func(start, end int64) ([]byte, error) {
+		r := mintest.NewDataReader(seed, largeRangeObjectSize)
+		if _, err := r.Seek(start, io.SeekStart); err != nil {
+			return nil, err
+		}
+		data := make([]byte, end-start+1)
+		_, err := io.ReadFull(r, data)
+		return data, err
+	}
+	const offset = 256 * 1024 * 1024
+	ranges := []byteRange{
+		{fmt.Sprintf("bytes=%d-%d", offset, offset+1024*1024-1), offset, offset + 1024*1024 - 1},
+		{fmt.Sprintf("bytes=%d-", largeRangeObjectSize-1), largeRangeObjectSize - 1, largeRangeObjectSize - 1},
+		{"bytes=-1048576", largeRangeObjectSize - 1024*1024, largeRangeObjectSize - 1},
+	}
+	for _, r := range ranges {
+		if err = checkRange(s3Client, bucket, largeObject, largeRangeObjectSize, r, nil, contentAt); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ranged GET of a large object Failed", err).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Read an SSE-C encrypted multipart object by ranges and by part number.
+// A ranged GET without the key must be rejected.
+func testGetObjectRangeSSEC(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testGetObjectRangeSSEC"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "multipart"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, "", function, args, startTime, true)
+
+	seed := mintest.DataSeed()
+	args["seed"] = seed
+	content, err := ioutil.ReadAll(mintest.NewDataReader(seed, rangeObjectSize))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Generating the object content failed", err).Fatal()
+		return
+	}
+	sseKey := aws.String("32byteslongsecretkeymustbegiven1")
+	defer cleanup(s3Client, bucket, object, function, args, startTime, false)
+	if err = putMultipartContent(s3Client, bucket, object, content, sseKey); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go multipart upload Failed", err).Fatal()
+		return
+	}
+	if err = checkMultipartRanges(s3Client, bucket, object, content, sseKey); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ranged GET of an SSE-C multipart object Failed", err).Fatal()
+		return
+	}
+
+	_, err = s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+		Range:  aws.String("bytes=0-99"),
+	})
+	if rerr, ok := err.(awserr.RequestFailure); !ok || rerr.StatusCode() != http.StatusBadRequest {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ranged GET without the SSE-C key expected to fail with status 400 but got %v", err), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,160 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Request IDs are opaque, but servers in the wild use short
+// alphanumeric tokens, e.g. 16 upper case hex digits for MinIO.
+var requestIDRegex = regexp.MustCompile(`^[0-9A-Za-z\-]{8,64}$`)
+
+// requestIDRecord is the request ID returned for a single operation
+type requestIDRecord struct {
+	Operation string `json:"operation"`
+	RequestID string `json:"requestId"`
+	time      time.Time
+	failed    bool
+}
+
+// requestIDs collects the request IDs of all the operations sent by the
+// clients passed to trackRequestIDs, for the whole run.
+var requestIDs struct {
+	sync.Mutex
+	records []requestIDRecord
+}
+
+// trackRequestIDs records the request ID of every response received by s3Client
+func trackRequestIDs(s3Client *s3.S3) {
+	s3Client.Handlers.Complete.PushBack(func(r *request.Request) {
+		if r.HTTPResponse == nil || r.HTTPResponse.StatusCode == 0 {
+			// The request never reached the server, the SDK sets an
+			// empty response for requests failing validation.
+			return
+		}
+		requestIDs.Lock()
+		defer requestIDs.Unlock()
+		requestIDs.records = append(requestIDs.records, requestIDRecord{
+			Operation: r.Operation.Name,
+			RequestID: r.HTTPResponse.Header.Get("x-amz-request-id"),
+			time:      time.Now(),
+			failed:    r.Error != nil,
+		})
+	})
+}
+
+// failedRequestIDs returns the records of the operations which failed since startTime
+func failedRequestIDs(startTime time.Time) []requestIDRecord {
+	requestIDs.Lock()
+	defer requestIDs.Unlock()
+	var failed []requestIDRecord
+	for _, record := range requestIDs.records {
+		if record.failed && !record.time.Before(startTime) {
+			failed = append(failed, record)
+		}
+	}
+	return failed
+}
+
+// Validate the request IDs collected over the run, after issuing a few
+// hundred more operations: every response must carry a well formed
+// x-amz-request-id which is not shared with any other response.
+func testRequestIDs(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testRequestIDs"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	numOperations := 300
+	args := map[string]interface{}{
+		"bucketName":    bucket,
+		"objectName":    object,
+		"numOperations": numOperations,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	for i := 0; i < numOperations/3; i++ {
+		_, err = s3Client.PutObject(&s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader(fmt.Sprintf("content %d", i))),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+			return
+		}
+		_, err = s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HEAD expected to success but got %v", err), err).Fatal()
+			return
+		}
+		// Errors must carry a request ID as well
+		_, err = s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object + "-nonexistent"),
+		})
+		if err == nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HEAD of a nonexistent object expected to fail but succeeded", nil).Fatal()
+			return
+		}
+	}
+
+	requestIDs.Lock()
+	records := make([]requestIDRecord, len(requestIDs.records))
+	copy(records, requestIDs.records)
+	requestIDs.Unlock()
+
+	args["numRequestIDs"] = len(records)
+	seen := make(map[string]requestIDRecord, len(records))
+	for _, record := range records {
+		if !requestIDRegex.MatchString(record.RequestID) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("%s returned an invalid request ID %q", record.Operation, record.RequestID), nil).Fatal()
+			return
+		}
+		if prev, ok := seen[record.RequestID]; ok {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("%s and %s returned the same request ID %q", prev.Operation, record.Operation, record.RequestID), nil).Fatal()
+			return
+		}
+		seen[record.RequestID] = record
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
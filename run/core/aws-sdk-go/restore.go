@@ -0,0 +1,178 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// restoreObject requests a one day restore of bucket/object, and returns
+// the response status code.
+func restoreObject(s3Client *s3.S3, bucket, object string) (int, error) {
+	req, _ := s3Client.RestoreObjectRequest(&s3.RestoreObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+		RestoreRequest: &s3.RestoreRequest{
+			Days: aws.Int64(1),
+		},
+	})
+	err := req.Send()
+	if rerr, ok := err.(awserr.RequestFailure); ok {
+		return rerr.StatusCode(), err
+	}
+	if err != nil {
+		return 0, err
+	}
+	return req.HTTPResponse.StatusCode, nil
+}
+
+// Request the restore of an object stored in the standard storage class,
+// which is not archived and must be rejected with InvalidObjectState.
+// Servers without restore support at all are skipped.
+func testRestoreObjectNotArchived(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testRestoreObjectNotArchived"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader("restore")),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		return
+	}
+
+	status, err := restoreObject(s3Client, bucket, object)
+	if mintest.IsNotImplemented(err) {
+		mintest.IgnoreLog(function, args, startTime, "RestoreObject is NotImplemented").Info()
+		return
+	}
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "InvalidObjectState" || status != http.StatusForbidden {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go RestoreObject of a standard object expected 403 InvalidObjectState but got %d", status), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Upload an object in the archive storage class set in TIER_STORAGE_CLASS,
+// like GLACIER on AWS, and check it cannot be read until restored. Then
+// request its restore and check the x-amz-restore header of HeadObject
+// reports the restore, which may still be ongoing.
+func testRestoreArchivedObject(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testRestoreArchivedObject"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	storageClass := os.Getenv("TIER_STORAGE_CLASS")
+	args := map[string]interface{}{
+		"bucketName":   bucket,
+		"objectName":   object,
+		"storageClass": storageClass,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:         aws.ReadSeekCloser(strings.NewReader("restore")),
+		Bucket:       aws.String(bucket),
+		Key:          aws.String(object),
+		StorageClass: aws.String(storageClass),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT in storage class %s expected to success but got %v", storageClass, err), err).Fatal()
+		return
+	}
+
+	_, err = s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "InvalidObjectState" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of an archived object expected InvalidObjectState but got %v", err), err).Fatal()
+		return
+	}
+
+	// 202 when the restore starts, 200 when the object is already restored
+	status, err := restoreObject(s3Client, bucket, object)
+	if err != nil || (status != http.StatusAccepted && status != http.StatusOK) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go RestoreObject expected 202 Accepted but got %d", status), err).Fatal()
+		return
+	}
+
+	headOutput, err := s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadObject Failed", err).Fatal()
+		return
+	}
+	restore := aws.StringValue(headOutput.Restore)
+	args["restore"] = restore
+	if !strings.HasPrefix(restore, `ongoing-request="`) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject after RestoreObject expected an x-amz-restore header but got %q", restore), nil).Fatal()
+		return
+	}
+
+	// A second request while the restore is running is a conflict
+	if strings.HasPrefix(restore, `ongoing-request="true"`) {
+		status, err = restoreObject(s3Client, bucket, object)
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != "RestoreAlreadyInProgress" || status != http.StatusConflict {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go RestoreObject during a restore expected 409 RestoreAlreadyInProgress but got %d", status), err).Fatal()
+			return
+		}
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
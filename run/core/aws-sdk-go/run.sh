@@ -24,5 +24,11 @@ fi
 output_log_file="$1"
 error_log_file="$2"
 
+# resolve DOMAIN and its subdomains to the server for virtual host style
+if [ "$MINT_ADDRESSING_STYLE" == "virtual" ] && [ -n "$DOMAIN" ] && [ -n "$SERVER_IP" ] && ! grep -q "^nameserver 127.0.0.1$" /etc/resolv.conf; then
+	dnsmasq --address="/$DOMAIN/$SERVER_IP" --user=root
+	echo -e "nameserver 127.0.0.1\n$(cat /etc/resolv.conf)" >/etc/resolv.conf
+fi
+
 # run tests
 /mint/run/core/aws-sdk-go/aws-sdk-go 1>>"$output_log_file" 2>"$error_log_file"
@@ -0,0 +1,373 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// selectEvents holds the events received for a SelectObjectContent request
+type selectEvents struct {
+	records  string
+	progress []*s3.Progress
+	stats    *s3.Stats
+	end      bool
+}
+
+// selectObjectEvents runs a SelectObjectContent request and collects all the
+// events of the response stream. Both request and stream errors are returned.
+func selectObjectEvents(s3Client *s3.S3, params *s3.SelectObjectContentInput) (selectEvents, error) {
+	var events selectEvents
+	resp, err := s3Client.SelectObjectContent(params)
+	if err != nil {
+		return events, err
+	}
+	defer resp.EventStream.Close()
+
+	var records strings.Builder
+	for event := range resp.EventStream.Events() {
+		switch v := event.(type) {
+		case *s3.RecordsEvent:
+			records.Write(v.Payload)
+		case *s3.ProgressEvent:
+			events.progress = append(events.progress, v.Details)
+		case *s3.StatsEvent:
+			events.stats = v.Details
+		case *s3.EndEvent:
+			events.end = true
+		}
+	}
+	events.records = records.String()
+	return events, resp.EventStream.Err()
+}
+
+// Request progress events on a CSV select and check the Progress, Stats and
+// End events of the stream, then run a query failing while the records are
+// being evaluated and check the error is reported through the stream.
+func testSelectObjectEvents(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testSelectObjectEvents"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "object.csv"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	var input strings.Builder
+	input.WriteString("year,gender,firstname,num\n")
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&input, "2011,FEMALE,NAME%04d,%d\n", i, i)
+	}
+	inputCSV := input.String()
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader(inputCSV)),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object upload failed: %v", err), err).Fatal()
+		return
+	}
+
+	newParams := func(expression string) *s3.SelectObjectContentInput {
+		return &s3.SelectObjectContentInput{
+			Bucket:          aws.String(bucket),
+			Key:             aws.String(object),
+			ExpressionType:  aws.String(s3.ExpressionTypeSql),
+			Expression:      aws.String(expression),
+			RequestProgress: &s3.RequestProgress{Enabled: aws.Bool(true)},
+			InputSerialization: &s3.InputSerialization{
+				CompressionType: aws.String("NONE"),
+				CSV: &s3.CSVInput{
+					FileHeaderInfo:  aws.String(s3.FileHeaderInfoUse),
+					FieldDelimiter:  aws.String(","),
+					RecordDelimiter: aws.String("\n"),
+				},
+			},
+			OutputSerialization: &s3.OutputSerialization{
+				CSV: &s3.CSVOutput{},
+			},
+		}
+	}
+
+	events, err := selectObjectEvents(s3Client, newParams("SELECT s.firstname FROM S3Object s WHERE CAST(s.num AS INT) < 500"))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object failed %v", err), err).Fatal()
+		return
+	}
+
+	var expected strings.Builder
+	for i := 0; i < 500; i++ {
+		fmt.Fprintf(&expected, "NAME%04d\n", i)
+	}
+	if events.records != expected.String() {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go Select object output mismatch", errors.New("AWS S3 select object mismatch")).Fatal()
+		return
+	}
+
+	if !events.end {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go Select object stream ended without an End event", errors.New("missing End event")).Fatal()
+		return
+	}
+
+	stats := events.stats
+	if stats == nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go Select object stream has no Stats event", errors.New("missing Stats event")).Fatal()
+		return
+	}
+	inputSize := int64(len(inputCSV))
+	if aws.Int64Value(stats.BytesScanned) != inputSize || aws.Int64Value(stats.BytesProcessed) != inputSize {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object Stats expected %d bytes scanned and processed but got %d and %d",
+			inputSize, aws.Int64Value(stats.BytesScanned), aws.Int64Value(stats.BytesProcessed)), errors.New("Stats mismatch")).Fatal()
+		return
+	}
+	if aws.Int64Value(stats.BytesReturned) != int64(len(events.records)) {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object Stats expected %d bytes returned but got %d",
+			len(events.records), aws.Int64Value(stats.BytesReturned)), errors.New("Stats mismatch")).Fatal()
+		return
+	}
+
+	// Servers send progress events periodically, so a small object may
+	// not get any. Those received must not go backwards nor exceed Stats.
+	var last s3.Progress
+	for _, progress := range events.progress {
+		if aws.Int64Value(progress.BytesScanned) < aws.Int64Value(last.BytesScanned) ||
+			aws.Int64Value(progress.BytesProcessed) < aws.Int64Value(last.BytesProcessed) ||
+			aws.Int64Value(progress.BytesReturned) < aws.Int64Value(last.BytesReturned) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object Progress went backwards from %v to %v", last, *progress), errors.New("Progress mismatch")).Fatal()
+			return
+		}
+		if aws.Int64Value(progress.BytesScanned) > aws.Int64Value(stats.BytesScanned) ||
+			aws.Int64Value(progress.BytesProcessed) > aws.Int64Value(stats.BytesProcessed) ||
+			aws.Int64Value(progress.BytesReturned) > aws.Int64Value(stats.BytesReturned) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object Progress %v exceeds Stats %v", *progress, *stats), errors.New("Progress mismatch")).Fatal()
+			return
+		}
+		last = *progress
+	}
+	args["progressEvents"] = len(events.progress)
+
+	// Casting a name to an integer can only fail once records are read,
+	// whether the server reports it before or while streaming them.
+	events, err = selectObjectEvents(s3Client, newParams("SELECT CAST(s.firstname AS INT) FROM S3Object s"))
+	if err == nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go Select object with an invalid cast expected to fail but succeeded", nil).Fatal()
+		return
+	}
+	aerr, ok := err.(awserr.Error)
+	if !ok || aerr.Code() == "" {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go Select object with an invalid cast expected an S3 error", err).Fatal()
+		return
+	}
+	if events.end {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go Select object with an invalid cast received an End event", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// openFiles returns the number of file descriptors open in the process, or
+// -1 when they cannot be counted
+func openFiles() int {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return -1
+	}
+	return len(entries)
+}
+
+// settledResources waits for the goroutines and file descriptors of the
+// process to drop back to at most the given counts, idle connections
+// being closed, and returns the counts when they do or after timeout.
+func settledResources(s3Client *s3.S3, goroutines, files int, timeout time.Duration) (int, int) {
+	deadline := time.Now().Add(timeout)
+	for {
+		s3Client.Config.HTTPClient.CloseIdleConnections()
+		g, f := runtime.NumGoroutine(), openFiles()
+		if (g <= goroutines && f <= files) || time.Now().After(deadline) {
+			return g, f
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// Open concurrent selects streaming every record of large CSV objects, and
+// cancel half of them once their first records are received. Cancelled
+// streams must end promptly, and the others must return all the records
+// along with the End event. Once done, the goroutines and file descriptors
+// of the streams must have been released, and a new select on a cold
+// connection must succeed.
+func testSelectObjectCancel(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testSelectObjectCancel"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	objects := []string{"object-1.csv", "object-2.csv"}
+	rows := 200000
+	streams := 16
+	timeout := 2 * time.Minute
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"rows":       rows,
+		"streams":    streams,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	var input, expected strings.Builder
+	input.WriteString("num,name\n")
+	for i := 0; i < rows; i++ {
+		fmt.Fprintf(&input, "%d,NAME%07d\n", i, i)
+		fmt.Fprintf(&expected, "NAME%07d\n", i)
+	}
+	for _, object := range objects {
+		_, err = s3Client.PutObject(&s3.PutObjectInput{
+			Body:   aws.ReadSeekCloser(strings.NewReader(input.String())),
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutObject Failed", err).Fatal()
+			return
+		}
+	}
+	newParams := func(object string) *s3.SelectObjectContentInput {
+		return &s3.SelectObjectContentInput{
+			Bucket:         aws.String(bucket),
+			Key:            aws.String(object),
+			ExpressionType: aws.String(s3.ExpressionTypeSql),
+			Expression:     aws.String("SELECT s.name FROM S3Object s"),
+			InputSerialization: &s3.InputSerialization{
+				CompressionType: aws.String("NONE"),
+				CSV:             &s3.CSVInput{FileHeaderInfo: aws.String(s3.FileHeaderInfoUse)},
+			},
+			OutputSerialization: &s3.OutputSerialization{CSV: &s3.CSVOutput{}},
+		}
+	}
+
+	goroutines, files := settledResources(s3Client, 0, 0, 0)
+	results := make([]error, streams)
+	var wg sync.WaitGroup
+	for i := 0; i < streams; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cancelled := i%2 == 1
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			resp, err := s3Client.SelectObjectContentWithContext(ctx, newParams(objects[i%len(objects)]))
+			if err != nil {
+				results[i] = err
+				return
+			}
+			defer resp.EventStream.Close()
+
+			var records strings.Builder
+			end := false
+			for event := range resp.EventStream.Events() {
+				switch v := event.(type) {
+				case *s3.RecordsEvent:
+					records.Write(v.Payload)
+					if cancelled {
+						cancel()
+					}
+				case *s3.EndEvent:
+					end = true
+				}
+			}
+			err = resp.EventStream.Err()
+			switch {
+			case cancelled && ctx.Err() == context.DeadlineExceeded:
+				results[i] = fmt.Errorf("cancelled stream %d did not end in %v", i, timeout)
+			case cancelled && (end || records.Len() == 0):
+				results[i] = fmt.Errorf("stream %d was not cancelled mid-stream, %d bytes were received", i, records.Len())
+			case cancelled:
+			case err != nil:
+				results[i] = fmt.Errorf("stream %d failed: %w", i, err)
+			case !end || records.String() != expected.String():
+				results[i] = fmt.Errorf("stream %d returned %d bytes of records, expected %d with an End event", i, records.Len(), expected.Len())
+			}
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range results {
+		if err != nil {
+			args["stream"] = i
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go concurrent Select object failed", err).Fatal()
+			return
+		}
+	}
+
+	// Some goroutines, like the ones of the logging, may start meanwhile
+	g, f := settledResources(s3Client, goroutines+2, files, 10*time.Second)
+	if g > goroutines+2 || (files >= 0 && f > files) {
+		args["goroutines"] = map[string]int{"before": goroutines, "after": g}
+		args["openFiles"] = map[string]int{"before": files, "after": f}
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go Select object streams leaked goroutines or file descriptors", nil).Fatal()
+		return
+	}
+
+	events, err := selectObjectEvents(s3Client, newParams(objects[0]))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go Select object on a new connection failed", err).Fatal()
+		return
+	}
+	if !events.end || events.records != expected.String() {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go Select object on a new connection returned %d bytes of records, expected %d with an End event", len(events.records), expected.Len()), nil).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,149 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Largest difference between the time of a signature and the time of the
+// server, as documented by AWS
+const maxClockSkew = 15 * time.Minute
+
+// sendSigned sends req with the HTTP client of s3Client, and returns the
+// status and the S3 error code if any of the response
+func sendSigned(s3Client *s3.S3, req *http.Request) (int, string, error) {
+	resp, err := s3Client.Config.HTTPClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, "", err
+	}
+	errResp := mintest.ErrorResponse{}
+	if resp.StatusCode != http.StatusOK {
+		xml.Unmarshal(data, &errResp)
+	}
+	return resp.StatusCode, errResp.Code, nil
+}
+
+// GET an object with requests signed in the headers and presigned, as
+// if the clock of the client was 10 and 20 minutes ahead or behind. Within
+// the 15 minutes of skew allowed, all must be accepted. Out of it, header
+// signatures must be rejected with RequestTimeTooSkewed, and so must a
+// presigned URL dated in the future, or with AccessDenied as it is not
+// valid yet. A presigned URL dated in the past is valid until it expires,
+// an hour after its date here, so it must be accepted.
+func testSignatureClockSkew(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testSignatureClockSkew"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	expiry := time.Hour
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"expiry":     expiry,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(strings.NewReader("skew")),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		return
+	}
+
+	signer := v4.NewSigner(s3Client.Config.Credentials)
+	region := aws.StringValue(s3Client.Config.Region)
+	for _, presigned := range []bool{false, true} {
+		for _, skew := range []time.Duration{-20 * time.Minute, -10 * time.Minute, 10 * time.Minute, 20 * time.Minute} {
+			args["presigned"] = presigned
+			args["skew"] = skew.String()
+			req, err := http.NewRequest(http.MethodGet, s3Client.Endpoint+"/"+bucket+"/"+object, nil)
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", "Creating the GET request failed", err).Fatal()
+				return
+			}
+			signTime := time.Now().Add(skew)
+			if presigned {
+				_, err = signer.Presign(req, nil, "s3", region, expiry, signTime)
+			} else {
+				_, err = signer.Sign(req, nil, "s3", region, signTime)
+			}
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", "Signing the GET request failed", err).Fatal()
+				return
+			}
+
+			status, code, err := sendSigned(s3Client, req)
+			if err != nil {
+				mintest.FailureLog(function, args, startTime, "", "GET request failed", err).Fatal()
+				return
+			}
+			skewed := skew > maxClockSkew || skew < -maxClockSkew
+			switch {
+			case !skewed || (presigned && skew < 0):
+				if status != http.StatusOK {
+					mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET signed %v off expected to be accepted but got %d %s", skew, status, code), nil).Fatal()
+					return
+				}
+			case presigned:
+				if status != http.StatusForbidden || (code != "RequestTimeTooSkewed" && code != "AccessDenied") {
+					mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET presigned %v ahead expected 403 RequestTimeTooSkewed or AccessDenied but got %d %s", skew, status, code), nil).Fatal()
+					return
+				}
+			default:
+				if status != http.StatusForbidden || code != "RequestTimeTooSkewed" {
+					mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("GET signed %v off expected 403 RequestTimeTooSkewed but got %d %s", skew, status, code), nil).Fatal()
+					return
+				}
+			}
+		}
+	}
+	delete(args, "presigned")
+	delete(args, "skew")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
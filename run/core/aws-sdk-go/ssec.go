@@ -0,0 +1,204 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// getObjectContentSSEC returns the content of an SSE-C encrypted object
+func getObjectContentSSEC(s3Client *s3.S3, bucket, object string, key *string) ([]byte, error) {
+	output, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(object),
+		SSECustomerAlgorithm: sseCustomerAlgorithm(key),
+		SSECustomerKey:       key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+	return ioutil.ReadAll(output.Body)
+}
+
+// sseCustomerKeyMD5 returns the x-amz-server-side-encryption-customer-key-MD5
+// value going along with key
+func sseCustomerKeyMD5(key string) string {
+	sum := md5.Sum([]byte(key))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// Go through the lifecycle of SSE-C encrypted objects: PUT and multipart
+// upload with a customer key, HEAD and GET with that key, plain and ranged,
+// and CopyObject rotating the key. Reading with a wrong key, or without
+// any, must fail.
+func testSSECLifecycle(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testSSECLifecycle"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	multipartObject := "multipart"
+	rotated := "rotated"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+	defer cleanup(s3Client, bucket, multipartObject, function, args, startTime, false)
+	defer cleanup(s3Client, bucket, rotated, function, args, startTime, false)
+
+	key := aws.String("32byteslongsecretkeymustbegiven1")
+	newKey := aws.String("32byteslongsecretkeymustbegiven2")
+	seed := mintest.DataSeed()
+	args["seed"] = seed
+	content, err := ioutil.ReadAll(mintest.NewDataReader(seed, 1024*1024))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Generating the object content failed", err).Fatal()
+		return
+	}
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:                 bytes.NewReader(content),
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(object),
+		SSECustomerAlgorithm: sseCustomerAlgorithm(key),
+		SSECustomerKey:       key,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to succeed but got %v", err), err).Fatal()
+		return
+	}
+
+	// checkSSEC checks an object is readable with key only
+	checkSSEC := func(object string, key, wrongKey *string, content []byte) bool {
+		headOutput, err := s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket:               aws.String(bucket),
+			Key:                  aws.String(object),
+			SSECustomerAlgorithm: sseCustomerAlgorithm(key),
+			SSECustomerKey:       key,
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject of %s with its key Failed", object), err).Fatal()
+			return false
+		}
+		keyMD5 := sseCustomerKeyMD5(aws.StringValue(key))
+		if aws.StringValue(headOutput.SSECustomerAlgorithm) != "AES256" || aws.StringValue(headOutput.SSECustomerKeyMD5) != keyMD5 ||
+			aws.Int64Value(headOutput.ContentLength) != int64(len(content)) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject of %s expected AES256, key MD5 %s and %d bytes but got %s, %s and %d bytes",
+				object, keyMD5, len(content), aws.StringValue(headOutput.SSECustomerAlgorithm), aws.StringValue(headOutput.SSECustomerKeyMD5),
+				aws.Int64Value(headOutput.ContentLength)), errors.New("SSE-C headers mismatch")).Fatal()
+			return false
+		}
+
+		data, err := getObjectContentSSEC(s3Client, bucket, object, key)
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of %s with its key Failed", object), err).Fatal()
+			return false
+		}
+		if !bytes.Equal(data, content) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of %s with its key returned other content", object), errors.New("content mismatch")).Fatal()
+			return false
+		}
+
+		// AWS rejects a wrong key with 403, a missing one with 400
+		for _, k := range []*string{wrongKey, nil} {
+			_, err = getObjectContentSSEC(s3Client, bucket, object, k)
+			rerr, ok := err.(awserr.RequestFailure)
+			if !ok || (rerr.StatusCode() != http.StatusForbidden && rerr.StatusCode() != http.StatusBadRequest) {
+				mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of %s with key %q expected to fail with 400 or 403 but got %v",
+					object, aws.StringValue(k), err), err).Fatal()
+				return false
+			}
+		}
+		return true
+	}
+
+	if !checkSSEC(object, key, newKey, content) {
+		return
+	}
+	contentAt := func(start, end int64) ([]byte, error) {
+		return content[start : end+1], nil
+	}
+	size := int64(len(content))
+	for _, r := range []byteRange{
+		{"bytes=100-65635", 100, 65635},
+		{"bytes=-1000", size - 1000, size - 1},
+	} {
+		if err = checkRange(s3Client, bucket, object, size, r, key, contentAt); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ranged GET of an SSE-C object Failed", err).Fatal()
+			return
+		}
+	}
+
+	multipartContent, err := ioutil.ReadAll(mintest.NewDataReader(seed+1, rangeObjectSize))
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Generating the object content failed", err).Fatal()
+		return
+	}
+	if err = putMultipartContent(s3Client, bucket, multipartObject, multipartContent, key); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go SSE-C multipart upload Failed", err).Fatal()
+		return
+	}
+	if !checkSSEC(multipartObject, key, newKey, multipartContent) {
+		return
+	}
+
+	// Rotate the key while copying, old key for the source, new one for
+	// the destination
+	_, err = s3Client.CopyObject(&s3.CopyObjectInput{
+		Bucket:                         aws.String(bucket),
+		Key:                            aws.String(rotated),
+		CopySource:                     copySource(bucket, object),
+		CopySourceSSECustomerAlgorithm: sseCustomerAlgorithm(key),
+		CopySourceSSECustomerKey:       key,
+		SSECustomerAlgorithm:           sseCustomerAlgorithm(newKey),
+		SSECustomerKey:                 newKey,
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CopyObject rotating the SSE-C key Failed", err).Fatal()
+		return
+	}
+	if !checkSSEC(rotated, newKey, key, content) {
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
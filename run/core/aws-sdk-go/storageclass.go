@@ -0,0 +1,151 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// PUT objects in the STANDARD and REDUCED_REDUNDANCY storage classes, and
+// in the one set in TIER_STORAGE_CLASS if any, and check HeadObject,
+// ListObjectsV2 and GetObjectAttributes all report it. HeadObject omits
+// the STANDARD class. An unknown storage class must be rejected with
+// InvalidStorageClass.
+func testStorageClass(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testStorageClass"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	storageClasses := []string{s3.StorageClassStandard, s3.StorageClassReducedRedundancy}
+	if tier := os.Getenv("TIER_STORAGE_CLASS"); tier != "" {
+		storageClasses = append(storageClasses, tier)
+	}
+	args := map[string]interface{}{
+		"bucketName":     bucket,
+		"storageClasses": storageClasses,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	for _, storageClass := range storageClasses {
+		_, err = s3Client.PutObject(&s3.PutObjectInput{
+			Body:         aws.ReadSeekCloser(strings.NewReader(storageClass)),
+			Bucket:       aws.String(bucket),
+			Key:          aws.String(storageClass),
+			StorageClass: aws.String(storageClass),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT in storage class %s expected to success but got %v", storageClass, err), err).Fatal()
+			return
+		}
+
+		headOutput, err := s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(storageClass),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadObject Failed", err).Fatal()
+			return
+		}
+		expected := storageClass
+		if storageClass == s3.StorageClassStandard {
+			expected = ""
+		}
+		if got := aws.StringValue(headOutput.StorageClass); got != expected {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject of an object in storage class %s expected x-amz-storage-class %q but got %q",
+				storageClass, expected, got), errors.New("storage class mismatch")).Fatal()
+			return
+		}
+	}
+
+	list, err := s3Client.ListObjectsV2(&s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListObjectsV2 Failed", err).Fatal()
+		return
+	}
+	listed := make(map[string]string)
+	for _, object := range list.Contents {
+		listed[aws.StringValue(object.Key)] = aws.StringValue(object.StorageClass)
+	}
+	for _, storageClass := range storageClasses {
+		if listed[storageClass] != storageClass {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go ListObjectsV2 expected storage class %s for object %s but got %q",
+				storageClass, storageClass, listed[storageClass]), errors.New("storage class mismatch")).Fatal()
+			return
+		}
+	}
+
+	for _, storageClass := range storageClasses {
+		attributes, err := s3Client.GetObjectAttributes(&s3.GetObjectAttributesInput{
+			Bucket:           aws.String(bucket),
+			Key:              aws.String(storageClass),
+			ObjectAttributes: []*string{aws.String(s3.ObjectAttributesStorageClass)},
+		})
+		if mintest.IsNotImplemented(err) {
+			args["getObjectAttributes"] = "not implemented"
+			break
+		}
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObjectAttributes Failed", err).Fatal()
+			return
+		}
+		if got := aws.StringValue(attributes.StorageClass); got != storageClass {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObjectAttributes expected storage class %s but got %q", storageClass, got),
+				errors.New("storage class mismatch")).Fatal()
+			return
+		}
+	}
+
+	_, err = s3Client.PutObject(&s3.PutObjectInput{
+		Body:         aws.ReadSeekCloser(strings.NewReader("invalid")),
+		Bucket:       aws.String(bucket),
+		Key:          aws.String("invalid"),
+		StorageClass: aws.String("INVALID_STORAGE_CLASS"),
+	})
+	if rerr, ok := err.(awserr.RequestFailure); !ok || rerr.StatusCode() != http.StatusBadRequest || rerr.Code() != "InvalidStorageClass" {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PUT in an unknown storage class expected to fail with InvalidStorageClass", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -0,0 +1,459 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// Values of X-Amz-Content-Sha256 for payloads not hashed as a whole
+const (
+	unsignedPayload                 = "UNSIGNED-PAYLOAD"
+	streamingSignedPayload          = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	streamingSignedPayloadTrailer   = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER"
+	streamingUnsignedPayloadTrailer = "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
+)
+
+// Size of the chunks of the streaming uploads, except for the last one
+const streamingChunkSize = 64 << 10
+
+// Hash of an empty string, part of every chunk string to sign
+var emptySHA256 = hex.EncodeToString(sha256.New().Sum(nil))
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// crc32cChecksum returns the base64 encoded CRC32C of data, as sent in
+// x-amz-checksum-crc32c
+func crc32cChecksum(data []byte) string {
+	sum := make([]byte, 4)
+	binary.BigEndian.PutUint32(sum, crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli)))
+	return base64.StdEncoding.EncodeToString(sum)
+}
+
+// streamingUpload is a PutObject request whose payload is sent in
+// aws-chunked encoding, with or without chunk signatures and trailers.
+type streamingUpload struct {
+	payloadHash string
+	trailer     string      // trailing header, empty when there is none
+	checksum    string      // value of the trailing header
+	header      http.Header // additional request headers
+	// corrupt is called with the encoded body before it is sent
+	corrupt func(body []byte) []byte
+}
+
+// streamingSigner signs the chunks of a streaming upload, each signature
+// chaining on the previous one, starting from the seed signature of the
+// request headers.
+type streamingSigner struct {
+	key       []byte
+	amzDate   string
+	scope     string
+	signature string
+}
+
+func (s *streamingSigner) sign(algorithm, hash string) string {
+	stringToSign := strings.Join([]string{algorithm, s.amzDate, s.scope, s.signature, hash}, "\n")
+	s.signature = hex.EncodeToString(hmacSHA256(s.key, stringToSign))
+	return s.signature
+}
+
+func (s *streamingSigner) signChunk(chunk []byte) string {
+	return s.sign("AWS4-HMAC-SHA256-PAYLOAD", emptySHA256+"\n"+sha256Hex(chunk))
+}
+
+func (s *streamingSigner) signTrailer(trailer string) string {
+	return s.sign("AWS4-HMAC-SHA256-TRAILER", sha256Hex([]byte(trailer)))
+}
+
+// signed reports whether the chunks of the upload are signed
+func (u streamingUpload) signed() bool {
+	return u.payloadHash == streamingSignedPayload || u.payloadHash == streamingSignedPayloadTrailer
+}
+
+// encodedLength returns the length of the body encoding size bytes, which
+// is part of the signed headers, so known before the chunks are signed.
+func (u streamingUpload) encodedLength(size int64) int64 {
+	chunkHeader := func(n int64) int64 {
+		length := int64(len(strconv.FormatInt(n, 16))) + 2
+		if u.signed() {
+			length += int64(len(";chunk-signature=")) + 64
+		}
+		return length
+	}
+	length := size / streamingChunkSize * (chunkHeader(streamingChunkSize) + streamingChunkSize + 2)
+	if last := size % streamingChunkSize; last > 0 {
+		length += chunkHeader(last) + last + 2
+	}
+	length += chunkHeader(0)
+	if u.trailer != "" {
+		length += int64(len(u.trailer)+1+len(u.checksum)) + 2
+		if u.signed() {
+			length += int64(len("x-amz-trailer-signature:")) + 64 + 2
+		}
+	}
+	return length + 2
+}
+
+// encode writes data to w in chunks, signed by signer when the upload is
+// signed, followed by the trailer if any.
+func (u streamingUpload) encode(w io.Writer, data io.Reader, signer *streamingSigner) error {
+	buf := make([]byte, streamingChunkSize)
+	for {
+		n, err := io.ReadFull(data, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		chunk := buf[:n]
+		header := fmt.Sprintf("%x", n)
+		if u.signed() {
+			header += ";chunk-signature=" + signer.signChunk(chunk)
+		}
+		if _, err = io.WriteString(w, header+"\r\n"); err != nil {
+			return err
+		}
+		if n == 0 {
+			break
+		}
+		if _, err = w.Write(chunk); err != nil {
+			return err
+		}
+		if _, err = io.WriteString(w, "\r\n"); err != nil {
+			return err
+		}
+	}
+	var tail string
+	if u.trailer != "" {
+		trailer := u.trailer + ":" + u.checksum
+		tail = trailer + "\r\n"
+		if u.signed() {
+			tail += "x-amz-trailer-signature:" + signer.signTrailer(trailer+"\n") + "\r\n"
+		}
+	}
+	_, err := io.WriteString(w, tail+"\r\n")
+	return err
+}
+
+// put uploads data to bucket/object, and returns the response status code
+// along with the S3 error code, if any.
+func (u streamingUpload) put(s3Client *s3.S3, bucket, object string, data []byte) (int, string, error) {
+	return u.putReader(s3Client, bucket, object, bytes.NewReader(data), int64(len(data)))
+}
+
+// putReader uploads the size bytes read from data to bucket/object like
+// put does, encoding the chunks as they are read rather than buffering
+// them, unless the upload is to be corrupted.
+func (u streamingUpload) putReader(s3Client *s3.S3, bucket, object string, data io.Reader, size int64) (int, string, error) {
+	req, err := http.NewRequest(http.MethodPut, s3Client.Endpoint+"/"+bucket+"/"+object, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	req.ContentLength = u.encodedLength(size)
+	req.Header.Set("Content-Encoding", "aws-chunked")
+	req.Header.Set("X-Amz-Content-Sha256", u.payloadHash)
+	req.Header.Set("X-Amz-Decoded-Content-Length", strconv.FormatInt(size, 10))
+	if u.trailer != "" {
+		req.Header.Set("X-Amz-Trailer", u.trailer)
+	}
+	for k, v := range u.header {
+		req.Header[k] = v
+	}
+
+	signTime := time.Now().UTC()
+	region := aws.StringValue(s3Client.Config.Region)
+	if _, err = v4.NewSigner(s3Client.Config.Credentials).Sign(req, nil, "s3", region, signTime); err != nil {
+		return 0, "", err
+	}
+	auth := req.Header.Get("Authorization")
+	seed := auth[strings.LastIndex(auth, "Signature=")+len("Signature="):]
+
+	creds, err := s3Client.Config.Credentials.Get()
+	if err != nil {
+		return 0, "", err
+	}
+	date := signTime.Format("20060102")
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	for _, s := range []string{region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, s)
+	}
+	signer := &streamingSigner{
+		key:       key,
+		amzDate:   req.Header.Get("X-Amz-Date"),
+		scope:     strings.Join([]string{date, region, "s3", "aws4_request"}, "/"),
+		signature: seed,
+	}
+	if u.corrupt != nil {
+		var body bytes.Buffer
+		if err = u.encode(&body, data, signer); err != nil {
+			return 0, "", err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(u.corrupt(body.Bytes())))
+	} else {
+		reader, writer := io.Pipe()
+		go func() {
+			writer.CloseWithError(u.encode(writer, data, signer))
+		}()
+		req.Body = reader
+	}
+
+	resp, err := s3Client.Config.HTTPClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return resp.StatusCode, "", nil
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, "", err
+	}
+	errResp := mintest.ErrorResponse{}
+	if err = xml.Unmarshal(respBody, &errResp); err != nil {
+		return resp.StatusCode, "", err
+	}
+	return resp.StatusCode, errResp.Code, nil
+}
+
+// checkObjectContent returns an error unless bucket/object holds data
+func checkObjectContent(s3Client *s3.S3, bucket, object string, data []byte) error {
+	output, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		return err
+	}
+	defer output.Body.Close()
+	content, err := ioutil.ReadAll(output.Body)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(content, data) {
+		return fmt.Errorf("object %s holds %d bytes which differ from the %d uploaded", object, len(content), len(data))
+	}
+	return nil
+}
+
+// streamingTestObject returns the content of the objects uploaded by the
+// streaming tests, spanning several chunks.
+func streamingTestObject() []byte {
+	data := make([]byte, 3*streamingChunkSize+1000)
+	rand.New(rand.NewSource(time.Now().UnixNano())).Read(data)
+	return data
+}
+
+// Upload an object with UNSIGNED-PAYLOAD as payload hash, which is only
+// safe to send over TLS, and read it back.
+func testUnsignedPayload(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testUnsignedPayload"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	if !strings.HasPrefix(s3Client.Endpoint, "https://") {
+		mintest.IgnoreLog(function, args, startTime, "Unsigned payloads are only tested over TLS").Info()
+		return
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	data := streamingTestObject()
+	req, _ := s3Client.PutObjectRequest(&s3.PutObjectInput{
+		Body:   bytes.NewReader(data),
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	// Set before signing, the signer uses it instead of hashing the body
+	req.HTTPRequest.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	if err = req.Send(); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PUT with UNSIGNED-PAYLOAD expected to succeed", err).Fatal()
+		return
+	}
+
+	if err = checkObjectContent(s3Client, bucket, object, data); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GET after PUT with UNSIGNED-PAYLOAD returned unexpected content", err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Upload an object in signed chunks with STREAMING-AWS4-HMAC-SHA256-PAYLOAD
+// and read it back, then check an upload with a corrupted chunk is
+// rejected with SignatureDoesNotMatch.
+func testStreamingSignedPayload(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testStreamingSignedPayload"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	data := streamingTestObject()
+	upload := streamingUpload{payloadHash: streamingSignedPayload}
+	status, code, err := upload.put(s3Client, bucket, object, data)
+	if err != nil || status != http.StatusOK {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Streaming signed PUT expected 200 but got %d %s", status, code), err).Fatal()
+		return
+	}
+	if err = checkObjectContent(s3Client, bucket, object, data); err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GET after streaming signed PUT returned unexpected content", err).Fatal()
+		return
+	}
+
+	// Flip a byte of the second chunk, whose signature no longer matches
+	upload.corrupt = func(body []byte) []byte {
+		second := bytes.Index(body[streamingChunkSize:], []byte("chunk-signature=")) + streamingChunkSize
+		i := second + bytes.Index(body[second:], []byte("\r\n")) + 10
+		body[i] ^= 0xff
+		return body
+	}
+	status, code, err = upload.put(s3Client, bucket, object, data)
+	if err != nil || status != http.StatusForbidden || code != "SignatureDoesNotMatch" {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Streaming signed PUT with a corrupted chunk expected 403 SignatureDoesNotMatch but got %d %s", status, code), err).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
+
+// Upload objects in chunks followed by a CRC32C checksum trailer, with and
+// without chunk signatures, and check the checksum is stored. Uploads with
+// a trailer not matching the content must be rejected.
+func testStreamingTrailerChecksum(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testStreamingTrailerChecksum"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	data := streamingTestObject()
+	checksum := crc32cChecksum(data)
+	args["checksum"] = checksum
+
+	payloadHashes := []string{streamingSignedPayloadTrailer}
+	// Like UNSIGNED-PAYLOAD, unsigned chunks are only safe over TLS
+	if strings.HasPrefix(s3Client.Endpoint, "https://") {
+		payloadHashes = append(payloadHashes, streamingUnsignedPayloadTrailer)
+	}
+	for _, payloadHash := range payloadHashes {
+		args["payloadHash"] = payloadHash
+		upload := streamingUpload{
+			payloadHash: payloadHash,
+			trailer:     "x-amz-checksum-crc32c",
+			checksum:    checksum,
+		}
+		status, code, err := upload.put(s3Client, bucket, object, data)
+		if err != nil || status != http.StatusOK {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Streaming PUT with a checksum trailer expected 200 but got %d %s", status, code), err).Fatal()
+			return
+		}
+		if err = checkObjectContent(s3Client, bucket, object, data); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GET after streaming PUT returned unexpected content", err).Fatal()
+			return
+		}
+		headOutput, err := s3Client.HeadObject(&s3.HeadObjectInput{
+			Bucket:       aws.String(bucket),
+			Key:          aws.String(object),
+			ChecksumMode: aws.String(s3.ChecksumModeEnabled),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go HeadObject Failed", err).Fatal()
+			return
+		}
+		if got := aws.StringValue(headOutput.ChecksumCRC32C); got != checksum {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go HeadObject expected CRC32C %s but got %q", checksum, got), nil).Fatal()
+			return
+		}
+
+		upload.checksum = crc32cChecksum(data[1:])
+		status, code, err = upload.put(s3Client, bucket, object, data)
+		if err != nil || status != http.StatusBadRequest || (code != "BadDigest" && code != "XAmzContentChecksumMismatch") {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("Streaming PUT with a wrong checksum trailer expected 400 BadDigest but got %d %s", status, code), err).Fatal()
+			return
+		}
+	}
+	delete(args, "payloadHash")
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
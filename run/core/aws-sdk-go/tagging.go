@@ -0,0 +1,221 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// taggingHeader returns the URL-encoded x-amz-tagging value of tags
+func taggingHeader(tags map[string]string) *string {
+	values := url.Values{}
+	for k, v := range tags {
+		values.Set(k, v)
+	}
+	return aws.String(values.Encode())
+}
+
+// Tag objects through the x-amz-tagging header with keys and values made
+// of every special character allowed, which must be URL-decoded, and check
+// x-amz-tagging-count on GET. Tag a previous version by its version ID,
+// replace tags while copying and delete them.
+func testObjectTaggingHeader(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testObjectTaggingHeader"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	destination := "destination"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+	}
+
+	_, err := s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer func() {
+		if err := mintest.RemoveBucket(s3Client, bucket); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteBucket Failed", err).Fatal()
+		}
+	}()
+
+	// Version IDs are only checked when the server supports versioning
+	_, err = s3Client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket:                  aws.String(bucket),
+		VersioningConfiguration: &s3.VersioningConfiguration{Status: aws.String(s3.BucketVersioningStatusEnabled)},
+	})
+	versioned := err == nil
+	if err != nil && !mintest.IsNotImplemented(err) {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutBucketVersioning Failed", err).Fatal()
+		return
+	}
+	args["versioned"] = versioned
+
+	// checkTags checks the tags of a version of object, the latest one
+	// when versionID is nil, both by GetObjectTagging and by GetObject
+	checkTags := func(object string, versionID *string, expected map[string]string) bool {
+		tagging, err := s3Client.GetObjectTagging(&s3.GetObjectTaggingInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			VersionId: versionID,
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObjectTagging Failed", err).Fatal()
+			return false
+		}
+		tags := make(map[string]string)
+		for _, tag := range tagging.TagSet {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		if !reflect.DeepEqual(tags, expected) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObjectTagging of %s version %s expected tags %q but got %q",
+				object, aws.StringValue(versionID), expected, tags), errors.New("tags mismatch")).Fatal()
+			return false
+		}
+
+		output, err := s3Client.GetObject(&s3.GetObjectInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			VersionId: versionID,
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject Failed", err).Fatal()
+			return false
+		}
+		output.Body.Close()
+		if count := aws.Int64Value(output.TagCount); count != int64(len(expected)) {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go GetObject of %s version %s expected x-amz-tagging-count %d but got %d",
+				object, aws.StringValue(versionID), len(expected), count), errors.New("tagging count mismatch")).Fatal()
+			return false
+		}
+		return true
+	}
+
+	specialTags := map[string]string{
+		"key with spaces": "value with spaces",
+		"a+b=c":           "1/2:3@4",
+		"_.-":             "",
+		"path/to:key@":    "a=b+c",
+	}
+	putOutput, err := s3Client.PutObject(&s3.PutObjectInput{
+		Body:    aws.ReadSeekCloser(strings.NewReader("first version")),
+		Bucket:  aws.String(bucket),
+		Key:     aws.String(object),
+		Tagging: taggingHeader(specialTags),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+		return
+	}
+	if !checkTags(object, nil, specialTags) {
+		return
+	}
+
+	latestTags := specialTags
+	if versioned {
+		firstVersion := putOutput.VersionId
+		latestTags = map[string]string{"version": "2"}
+		_, err = s3Client.PutObject(&s3.PutObjectInput{
+			Body:    aws.ReadSeekCloser(strings.NewReader("second version")),
+			Bucket:  aws.String(bucket),
+			Key:     aws.String(object),
+			Tagging: taggingHeader(latestTags),
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", fmt.Sprintf("AWS SDK Go PUT expected to success but got %v", err), err).Fatal()
+			return
+		}
+		if !checkTags(object, firstVersion, specialTags) || !checkTags(object, nil, latestTags) {
+			return
+		}
+
+		versionTags := map[string]string{"version": "1"}
+		_, err = s3Client.PutObjectTagging(&s3.PutObjectTaggingInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			Tagging:   &s3.Tagging{TagSet: []*s3.Tag{{Key: aws.String("version"), Value: aws.String("1")}}},
+			VersionId: firstVersion,
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go PutObjectTagging of a previous version Failed", err).Fatal()
+			return
+		}
+		if !checkTags(object, firstVersion, versionTags) || !checkTags(object, nil, latestTags) {
+			return
+		}
+
+		_, err = s3Client.DeleteObjectTagging(&s3.DeleteObjectTaggingInput{
+			Bucket:    aws.String(bucket),
+			Key:       aws.String(object),
+			VersionId: firstVersion,
+		})
+		if err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteObjectTagging of a previous version Failed", err).Fatal()
+			return
+		}
+		if !checkTags(object, firstVersion, map[string]string{}) || !checkTags(object, nil, latestTags) {
+			return
+		}
+	}
+
+	// REPLACE must only keep the URL-decoded tags sent with the copy
+	_, err = s3Client.CopyObject(&s3.CopyObjectInput{
+		Bucket:           aws.String(bucket),
+		Key:              aws.String(destination),
+		CopySource:       copySource(bucket, object),
+		Tagging:          taggingHeader(specialTags),
+		TaggingDirective: aws.String(s3.TaggingDirectiveReplace),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CopyObject with REPLACE tagging directive failed", err).Fatal()
+		return
+	}
+	if !checkTags(destination, nil, specialTags) {
+		return
+	}
+
+	_, err = s3Client.DeleteObjectTagging(&s3.DeleteObjectTaggingInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go DeleteObjectTagging Failed", err).Fatal()
+		return
+	}
+	if !checkTags(object, nil, map[string]string{}) {
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
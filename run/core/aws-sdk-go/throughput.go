@@ -0,0 +1,129 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"mint.minio.io/mintest"
+)
+
+const (
+	// Size of the object read back to measure the GetObject throughput
+	throughputObjectSize = 1 << 30
+	// Number of times the object is read back, one after the other
+	throughputReads = 3
+)
+
+// minGetThroughput returns the floor of the GetObject throughput, in MiB
+// per second, set in MINT_MIN_GET_MBPS
+func minGetThroughput() (float64, error) {
+	value := os.Getenv("MINT_MIN_GET_MBPS")
+	floor, err := strconv.ParseFloat(value, 64)
+	if err != nil || floor <= 0 {
+		return 0, fmt.Errorf("invalid MINT_MIN_GET_MBPS %q", value)
+	}
+	return floor, nil
+}
+
+// Upload an object of 1 GiB and read it back 3 times, one GET after the
+// other, failing with the alert "performance" when the throughput of the
+// reads is below the floor set in MINT_MIN_GET_MBPS. The throughput is
+// logged with the PASS record, to follow it from run to run. Only run
+// when MINT_MIN_GET_MBPS is set.
+func testGetObjectThroughput(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testGetObjectThroughput"
+	bucket := mintest.RandString(60, rand.NewSource(time.Now().UnixNano()), "aws-sdk-go-test-")
+	object := "testObject"
+	args := map[string]interface{}{
+		"bucketName": bucket,
+		"objectName": object,
+		"objectSize": throughputObjectSize,
+		"reads":      throughputReads,
+	}
+
+	floor, err := minGetThroughput()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Invalid MINT_MIN_GET_MBPS", err).Fatal()
+		return
+	}
+	args["minThroughputMiBps"] = floor
+
+	_, err = s3Client.CreateBucket(&s3.CreateBucketInput{
+		Bucket: aws.String(bucket),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go CreateBucket Failed", err).Fatal()
+		return
+	}
+	defer cleanup(s3Client, bucket, object, function, args, startTime, true)
+
+	seed := mintest.DataSeed()
+	args["seed"] = seed
+	// Hide Seek, so that the uploader streams the content part by part
+	_, err = s3manager.NewUploaderWithClient(s3Client).Upload(&s3manager.UploadInput{
+		Body:   struct{ io.Reader }{mintest.NewDataReader(seed, throughputObjectSize)},
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go upload Failed", err).Fatal()
+		return
+	}
+
+	// The content is only counted, hashing it could be slower than the server
+	elapsed, latencies, err := mintest.RunBenchmark(throughputReads, 1, func(int) error {
+		output, err := s3Client.GetObject(&s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(object),
+		})
+		if err != nil {
+			return err
+		}
+		defer output.Body.Close()
+		n, err := io.Copy(io.Discard, output.Body)
+		if err == nil && n != throughputObjectSize {
+			err = fmt.Errorf("read %d bytes of object %s, expected %d", n, object, throughputObjectSize)
+		}
+		return err
+	})
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "AWS SDK Go GetObject Failed", err).Fatal()
+		return
+	}
+
+	metrics := mintest.NewBenchmarkMetrics(throughputObjectSize, elapsed, latencies)
+	if metrics.ThroughputMiBps < floor {
+		mintest.FailureLog(function, args, startTime, "performance", fmt.Sprintf("AWS SDK Go GetObject throughput %.1f MiB/s is below MINT_MIN_GET_MBPS %.1f MiB/s", metrics.ThroughputMiBps, floor), nil).
+			WithField("metrics", metrics).Fatal()
+		return
+	}
+
+	mintest.SuccessLogger(function, args, startTime).WithField("metrics", metrics).Info()
+}
@@ -0,0 +1,127 @@
+/*
+*
+*  Mint, (C) 2026 Minio, Inc.
+*
+*  Licensed under the Apache License, Version 2.0 (the "License");
+*  you may not use this file except in compliance with the License.
+*  You may obtain a copy of the License at
+*
+*      http://www.apache.org/licenses/LICENSE-2.0
+*
+*  Unless required by applicable law or agreed to in writing, software
+
+*  distributed under the License is distributed on an "AS IS" BASIS,
+*  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+*  See the License for the specific language governing permissions and
+*  limitations under the License.
+*
+ */
+
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"mint.minio.io/mintest"
+)
+
+// tlsHandshake connects to the server over TLS with tlsConfig, and returns
+// the state of the connection once the handshake is done
+func tlsHandshake(config mintest.Config, tlsConfig *tls.Config) (tls.ConnectionState, error) {
+	address := config.Endpoint
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "443")
+	}
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 30 * time.Second}, "tcp", address, tlsConfig)
+	if err != nil {
+		return tls.ConnectionState{}, err
+	}
+	defer conn.Close()
+	return conn.ConnectionState(), nil
+}
+
+// Connect to the server with the TLS configuration of mint, and check the
+// handshake negotiates TLS 1.2 at least and, unless MINT_INSECURE_SKIP_VERIFY
+// is set, verifies the certificate of the server, which must then not be
+// accepted for another host name. When a client certificate is configured,
+// requests presenting it must succeed, and whether the server requires it
+// is logged.
+func testTLSHandshake(s3Client *s3.S3) {
+	startTime := time.Now()
+	function := "testTLSHandshake"
+	config := mintest.LoadConfig()
+	args := map[string]interface{}{
+		"endpoint":           config.Endpoint,
+		"caCert":             config.CACert,
+		"clientCert":         config.ClientCert,
+		"insecureSkipVerify": config.InsecureSkipVerify,
+	}
+
+	tlsConfig, err := config.TLSConfig()
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "Invalid TLS configuration", err).Fatal()
+		return
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+
+	state, err := tlsHandshake(config, tlsConfig)
+	if err != nil {
+		mintest.FailureLog(function, args, startTime, "", "TLS handshake failed", err).Fatal()
+		return
+	}
+	args["version"] = fmt.Sprintf("0x%04x", state.Version)
+	args["cipherSuite"] = tls.CipherSuiteName(state.CipherSuite)
+	if state.Version < tls.VersionTLS12 {
+		mintest.FailureLog(function, args, startTime, "", "TLS handshake expected to negotiate TLS 1.2 or later", nil).Fatal()
+		return
+	}
+	if len(state.PeerCertificates) == 0 {
+		mintest.FailureLog(function, args, startTime, "", "TLS handshake expected the server to present a certificate", nil).Fatal()
+		return
+	}
+	args["serverCertificate"] = state.PeerCertificates[0].Subject.String()
+
+	if !config.InsecureSkipVerify {
+		if len(state.VerifiedChains) == 0 {
+			mintest.FailureLog(function, args, startTime, "", "TLS handshake expected to verify the certificate of the server", nil).Fatal()
+			return
+		}
+		otherHost := tlsConfig.Clone()
+		otherHost.ServerName = "mint.invalid"
+		_, err = tlsHandshake(config, otherHost)
+		if err == nil || !strings.Contains(err.Error(), "certificate") {
+			mintest.FailureLog(function, args, startTime, "", "TLS handshake for another host name expected to fail verifying the certificate", err).Fatal()
+			return
+		}
+	}
+
+	if len(tlsConfig.Certificates) > 0 {
+		if _, err = s3Client.ListBuckets(&s3.ListBucketsInput{}); err != nil {
+			mintest.FailureLog(function, args, startTime, "", "AWS SDK Go ListBuckets with a client certificate Failed", err).Fatal()
+			return
+		}
+		// Servers may ask for a client certificate without requiring
+		// one, or only check it once the handshake is done
+		withoutCert := tlsConfig.Clone()
+		withoutCert.Certificates = nil
+		transport := config.Transport()
+		transport.TLSClientConfig = withoutCert
+		s3Config := s3Client.Config
+		s3Config.HTTPClient = &http.Client{Transport: transport}
+		s3Config.MaxRetries = aws.Int(0)
+		_, err = s3.New(session.New(), &s3Config).ListBuckets(&s3.ListBucketsInput{})
+		args["clientCertRequired"] = err != nil
+	}
+
+	mintest.SuccessLogger(function, args, startTime).Info()
+}
@@ -106,6 +106,7 @@ func testLivenessEndpoint(endpoint string) {
 	}
 
 	tr := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: u.Scheme == "https"},
 	}
 	client := &http.Client{Transport: tr, Timeout: timeout}
@@ -134,6 +135,7 @@ func testReadinessEndpoint(endpoint string) {
 	}
 
 	tr := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: u.Scheme == "https"},
 	}
 	client := &http.Client{Transport: tr, Timeout: timeout}
@@ -177,6 +179,7 @@ func testPrometheusEndpointV2(endpoint string, metricsPath string) {
 	}
 
 	tr := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: u.Scheme == "https"},
 	}
 	client := &http.Client{Transport: tr, Timeout: timeout}
@@ -22,7 +22,7 @@ export WGET="wget --quiet --no-check-certificate"
 export WGET="wget --quiet --no-check-certificate"
 
 ## Software versions
-export GO_VERSION="1.21.9"
+export GO_VERSION="1.24.6"
 export GRADLE_VERSION="8.5"
 export GRADLE_INSTALL_PATH="/opt/gradle"
 export GO_INSTALL_PATH="/usr/local"